@@ -57,9 +57,10 @@ func request_Greeter_SayHello_0(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -81,9 +82,10 @@ func local_request_Greeter_SayHello_0(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -110,9 +112,10 @@ func request_Greeter_SayHello_1(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_1); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_1); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -134,9 +137,10 @@ func local_request_Greeter_SayHello_1(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_1); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_1); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -163,9 +167,10 @@ func request_Greeter_SayHello_2(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_2); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_2); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -187,9 +192,10 @@ func local_request_Greeter_SayHello_2(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_2); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_2); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -216,9 +222,10 @@ func request_Greeter_SayHello_3(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_3); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_3); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -240,9 +247,10 @@ func local_request_Greeter_SayHello_3(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_3); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_3); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -269,9 +277,10 @@ func request_Greeter_SayHello_4(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_4); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_4); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -293,9 +302,10 @@ func local_request_Greeter_SayHello_4(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_4); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_4); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -322,9 +332,10 @@ func request_Greeter_SayHello_5(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_5); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_5); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -346,9 +357,10 @@ func local_request_Greeter_SayHello_5(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_5); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_5); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -375,9 +387,10 @@ func request_Greeter_SayHello_6(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_6); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_6); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -399,9 +412,10 @@ func local_request_Greeter_SayHello_6(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_6); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_6); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -428,9 +442,10 @@ func request_Greeter_SayHello_7(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_7); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_7); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -452,9 +467,10 @@ func local_request_Greeter_SayHello_7(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_7); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_7); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -481,9 +497,10 @@ func request_Greeter_SayHello_8(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_8); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_8); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -505,9 +522,10 @@ func local_request_Greeter_SayHello_8(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_8); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_8); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -534,9 +552,10 @@ func request_Greeter_SayHello_9(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_9); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_9); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SayHello(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -558,9 +577,10 @@ func local_request_Greeter_SayHello_9(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Greeter_SayHello_9); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_Greeter_SayHello_9); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SayHello(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -571,7 +591,7 @@ func local_request_Greeter_SayHello_9(ctx context.Context, marshaler runtime.Mar
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterGreeterHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
 func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, server GreeterServer) error {
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_0, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -585,13 +605,14 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_1, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -605,13 +626,14 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_1(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_2, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -625,13 +647,14 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_2(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_3, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -645,13 +668,14 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_3(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_3(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_4, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -665,13 +689,14 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_4(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_4(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_5, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -685,13 +710,14 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_5(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_5(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_6, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -705,13 +731,14 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_6(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_6(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_7, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_7, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -725,13 +752,14 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_7(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_7(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_8, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_8, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -745,13 +773,14 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_8(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_8(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_9, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_9, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -765,6 +794,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 		resp, md, err := local_request_Greeter_SayHello_9(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -799,10 +829,35 @@ func RegisterGreeterHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeM
 	return RegisterGreeterHandler(ctx, mux, conn)
 }
 
+// RegisterGreeterHandlerFromEndpoints is same as RegisterGreeterHandlerFromEndpoint but
+// balances the calls between the grpc servers at "endpoints" with runtime.NewEndpointsClient.
+func RegisterGreeterHandlerFromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+		}()
+	}()
+	return RegisterGreeterHandler(ctx, mux, conn)
+}
+
 // RegisterGreeterHandler registers the http handlers for service Greeter to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
+// The handlers forward requests to the grpc endpoint over "conn", which also serves the gRPC-Web requests
+// to the service if "mux" has runtime.WithGRPCWeb.
 func RegisterGreeterHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterGreeterHandlerClient(ctx, mux, NewGreeterClient(conn))
+	return RegisterGreeterHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{})
 }
 
 // RegisterGreeterHandlerClient registers the http handlers for service Greeter
@@ -811,7 +866,32 @@ func RegisterGreeterHandler(ctx context.Context, mux *runtime.ServeMux, conn *gr
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "GreeterClient" to call the correct interceptors. This client ignores the HTTP middlewares.
 func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, client GreeterClient) error {
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return RegisterGreeterHandlerClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})
+}
+
+// RegisterGreeterHandlerWithOptions is same as RegisterGreeterHandler
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterGreeterHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {
+	mux.RegisterServiceConn("grpc.gateway.examples.internal.helloworld.Greeter", conn, opts)
+	return RegisterGreeterHandlerClientWithOptions(ctx, mux, NewGreeterClient(conn), opts)
+}
+
+// RegisterGreeterHandlerClientWithOptions is same as RegisterGreeterHandlerClient
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterGreeterHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client GreeterClient, opts runtime.RegisterOptions) error {
+	if opts.Includes("grpc.gateway.examples.internal.helloworld.Greeter.SayHello", "") {
+		if err := RegisterGreeter_SayHelloHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterGreeter_SayHelloHandlerClient registers the http handlers for method SayHello
+// of service Greeter to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "GreeterClient".
+func RegisterGreeter_SayHelloHandlerClient(ctx context.Context, mux *runtime.ServeMux, client GreeterClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_0, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -820,15 +900,18 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_1, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -837,15 +920,18 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_1(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_1(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_2, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -854,15 +940,18 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_2(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_2(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_3, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -871,15 +960,18 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_3(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_3(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_3(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_4, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -888,15 +980,18 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_4(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_4(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_4(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_5, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -905,15 +1000,18 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_5(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_5(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_5(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_6, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -922,15 +1020,18 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_6(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_6(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_6(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_7, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_7, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -939,15 +1040,18 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_7(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_7(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_7(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_8, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_8, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -956,15 +1060,18 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_8(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_8(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_Greeter_SayHello_8(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_Greeter_SayHello_9, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_Greeter_SayHello_9, "/grpc.gateway.examples.internal.helloworld.Greeter/SayHello", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -973,8 +1080,11 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Greeter_SayHello_9(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Greeter_SayHello_9(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
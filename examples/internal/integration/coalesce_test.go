@@ -0,0 +1,69 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+)
+
+// slowEchoServer counts its calls, which wait for release.
+type slowEchoServer struct {
+	examplepb.UnimplementedEchoServiceServer
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (s *slowEchoServer) Echo(ctx context.Context, msg *examplepb.SimpleMessage) (*examplepb.SimpleMessage, error) {
+	s.calls.Add(1)
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return msg, nil
+}
+
+func TestRequestCoalescing(t *testing.T) {
+	echo := &slowEchoServer{release: make(chan struct{})}
+	close(echo.release)
+	conn := startGRPCServer(t, func(s *grpc.Server) {
+		examplepb.RegisterEchoServiceServer(s, echo)
+	})
+	mux := runtime.NewServeMux(runtime.WithRequestCoalescing())
+	if err := examplepb.RegisterEchoServiceHandler(context.Background(), mux, conn); err != nil {
+		t.Fatalf("examplepb.RegisterEchoServiceHandler(...) failed with %v", err)
+	}
+	// The route is coalesced once it forwarded a unary response.
+	serveDynamic(mux, http.MethodGet, "/v1/example/echo/myid/3", "")
+
+	echo.release = make(chan struct{})
+	var wg sync.WaitGroup
+	responses := make([]dynamicResponse, 4)
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i] = serveDynamic(mux, http.MethodGet, "/v1/example/echo/myid/3", "")
+		}()
+	}
+	// Wait for the requests to share a call.
+	time.Sleep(100 * time.Millisecond)
+	close(echo.release)
+	wg.Wait()
+
+	for i, resp := range responses {
+		if resp.Code != http.StatusOK || resp.Body != responses[0].Body {
+			t.Errorf("response %d = %d %s; want %d %s", i, resp.Code, resp.Body, http.StatusOK, responses[0].Body)
+		}
+	}
+	if calls := echo.calls.Load(); calls != 2 {
+		t.Errorf("calls = %d; want 2", calls)
+	}
+}
@@ -0,0 +1,373 @@
+package integration_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/server"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/dynamic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// startDynamicServer starts the example gRPC server and returns a connection to it.
+func startDynamicServer(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	socket := filepath.Join(t.TempDir(), "server.sock")
+	go func() {
+		if err := server.Run(ctx, "unix", socket); err != nil {
+			t.Errorf("server.Run(...) failed with %v", err)
+		}
+	}()
+	return dialUnix(t, socket)
+}
+
+func dialUnix(t *testing.T, socket string) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient("unix:"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient(...) failed with %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+type dynamicResponse struct {
+	Code   int
+	Header http.Header
+	Body   string
+}
+
+func serveDynamic(mux *runtime.ServeMux, method, path, body string) dynamicResponse {
+	var r *http.Request
+	if body == "" {
+		r = httptest.NewRequest(method, path, nil)
+	} else {
+		r = httptest.NewRequest(method, path, strings.NewReader(body))
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return dynamicResponse{Code: w.Code, Header: w.Header(), Body: w.Body.String()}
+}
+
+type dynamicRequest struct {
+	method, path, body string
+}
+
+// checkDynamicParity checks that the requests get the same responses from the
+// generated and the dynamic handlers.
+func checkDynamicParity(t *testing.T, generated, dyn *runtime.ServeMux, requests []dynamicRequest) {
+	t.Helper()
+	for _, req := range requests {
+		want := serveDynamic(generated, req.method, req.path, req.body)
+		if want.Code == http.StatusNotFound && strings.Contains(want.Body, "Not Found") {
+			t.Errorf("%s %s is not handled by the generated handlers", req.method, req.path)
+			continue
+		}
+		got := serveDynamic(dyn, req.method, req.path, req.body)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("%s %s %s: response differs from the generated handler (-want +got):\n%s", req.method, req.path, req.body, diff)
+		}
+	}
+}
+
+func TestDynamicParity(t *testing.T) {
+	conn := startDynamicServer(t)
+	ctx := context.Background()
+
+	generated := runtime.NewServeMux()
+	for _, register := range []func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error{
+		examplepb.RegisterEchoServiceHandler,
+		examplepb.RegisterFlowCombinationHandler,
+		examplepb.RegisterResponseBodyServiceHandler,
+		examplepb.RegisterStreamServiceHandler,
+		examplepb.RegisterABitOfEverythingServiceHandler,
+	} {
+		if err := register(ctx, generated, conn); err != nil {
+			t.Fatalf("register(...) failed with %v", err)
+		}
+	}
+
+	mux := runtime.NewServeMux()
+	if err := dynamic.Register(mux, conn, protoregistry.GlobalFiles, dynamic.WithServices(
+		"grpc.gateway.examples.internal.proto.examplepb.EchoService",
+		"grpc.gateway.examples.internal.proto.examplepb.FlowCombination",
+		"grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService",
+		"grpc.gateway.examples.internal.proto.examplepb.StreamService",
+		"grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService",
+	)); err != nil {
+		t.Fatalf("dynamic.Register(...) failed with %v", err)
+	}
+
+	checkDynamicParity(t, generated, mux, []dynamicRequest{
+		// Path and query parameters.
+		{method: "POST", path: "/v1/example/echo/myid"},
+		{method: "GET", path: "/v1/example/echo/myid/10?lang=en&status.progress=5"},
+		{method: "GET", path: "/v1/example/echo/myid/10/en"},
+		{method: "GET", path: "/v1/example/echo1/myid/12/mynote"},
+		{method: "GET", path: "/v1/example/echo/nested/myid"},
+		{method: "GET", path: "/v1/example/echo/myid/notanumber"},
+		{method: "GET", path: "/v1/example/echo/myid/10?unknown=1"},
+		{method: "DELETE", path: "/v1/example/echo_delete?id=myid&num=3"},
+		// Bodies.
+		{method: "POST", path: "/v1/example/echo_body", body: `{"id":"myid","num":3,"lang":"en"}`},
+		{method: "PUT", path: "/v1/example/echo_body/myid", body: `{"progress":7,"note":"mynote"}`},
+		{method: "POST", path: "/v1/example/echo_body", body: `{"id":`},
+		{method: "PATCH", path: "/v1/example/echo_patch", body: `{"struct_field":{"a":1},"value_field":"x"}`},
+		{method: "POST", path: "/rpc/body/path/a1/b1/rpc?c=ignored", body: `"c1"`},
+		{method: "POST", path: "/rpc/body/query/rpc?a=a1&b=b1", body: `"c1"`},
+		{method: "POST", path: "/rpc/path-nested/s1/b1/rpc", body: `"c1"`},
+		{method: "POST", path: "/rpc/path/a1/query/rpc?b=b1&c=c1"},
+		// Streaming.
+		{method: "POST", path: "/rpc/empty/stream", body: `{}`},
+		{method: "POST", path: "/rpc/body/stream", body: `{"a":"a1","b":"b1","c":"c1"}`},
+		{method: "POST", path: "/stream/empty/rpc", body: `{}{}`},
+		{method: "POST", path: "/stream/empty/stream", body: `{}{}{}`},
+		{method: "POST", path: "/v1/example/a_bit_of_everything/echo", body: `{"value":"a"}{"value":"b"}`},
+		{method: "POST", path: "/v1/example/a_bit_of_everything/echo", body: `{"value":`},
+		{method: "GET", path: "/v1/example/a_bit_of_everything"},
+		{method: "GET", path: "/v1/example/download"},
+		// Response bodies.
+		{method: "GET", path: "/responsebody/foo"},
+		{method: "GET", path: "/responsebodies/foo"},
+		{method: "GET", path: "/responsestrings/foo"},
+		{method: "GET", path: "/responsebody/stream/foo"},
+		{method: "GET", path: "/responsebody/samename/foo"},
+	})
+}
+
+func TestDynamicGrpcAPIConfiguration(t *testing.T) {
+	conn := startDynamicServer(t)
+	ctx := context.Background()
+
+	generated := runtime.NewServeMux()
+	if err := examplepb.RegisterUnannotatedEchoServiceHandler(ctx, generated, conn); err != nil {
+		t.Fatalf("RegisterUnannotatedEchoServiceHandler(...) failed with %v", err)
+	}
+
+	rules, err := dynamic.LoadGrpcAPIConfiguration([]byte(`
+type: google.api.Service
+config_version: 3
+
+http:
+  rules:
+  - selector: grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService.Echo
+    post: "/v1/example/echo/{id}"
+    additional_bindings:
+    - get: "/v1/example/echo/{id}/{num}"
+  - selector: grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService.EchoBody
+    post: "/v1/example/echo_body"
+    body: "*"
+  - selector: grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService.EchoDelete
+    delete: "/v1/example/echo_delete"
+  - selector: grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService.EchoNested
+    put: "/v1/example/echo_nested"
+    body: "*"
+    response_body: "n_id"
+`))
+	if err != nil {
+		t.Fatalf("dynamic.LoadGrpcAPIConfiguration(...) failed with %v", err)
+	}
+	mux := runtime.NewServeMux()
+	if err := dynamic.Register(mux, conn, protoregistry.GlobalFiles,
+		dynamic.WithServices("grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService"),
+		dynamic.WithHTTPRules(rules...),
+	); err != nil {
+		t.Fatalf("dynamic.Register(...) failed with %v", err)
+	}
+
+	checkDynamicParity(t, generated, mux, []dynamicRequest{
+		{method: "POST", path: "/v1/example/echo/myid"},
+		{method: "GET", path: "/v1/example/echo/myid/10?lang=en"},
+		{method: "POST", path: "/v1/example/echo_body", body: `{"id":"myid","num":3}`},
+		{method: "DELETE", path: "/v1/example/echo_delete?id=myid"},
+		{method: "PUT", path: "/v1/example/echo_nested", body: `{"id":"myid","n_id":{"n_id":"nested"}}`},
+	})
+}
+
+func TestDynamicGrpcAPIConfigurationWildcard(t *testing.T) {
+	_, err := dynamic.LoadGrpcAPIConfiguration([]byte(`
+http:
+  rules:
+  - selector: example.Service.*
+    get: /v1/example
+`))
+	if err == nil {
+		t.Error("dynamic.LoadGrpcAPIConfiguration(...) did not fail with a wildcard selector")
+	}
+}
+
+func TestDynamicInvalidRule(t *testing.T) {
+	for _, rule := range []string{
+		`{"selector": "grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService.GetResponseBody", "get": "/v1/{unknown}"}`,
+		`{"selector": "grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService.GetResponseBody", "get": "/v1/x", "response_body": "unknown"}`,
+		`{"selector": "grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService.GetResponseBody", "get": "/v1/x", "body": "*"}`,
+		`{"selector": "grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService.GetResponseBody", "get": "v1/x"}`,
+	} {
+		rules, err := dynamic.LoadGrpcAPIConfiguration([]byte(`{"http": {"rules": [` + rule + `]}}`))
+		if err != nil {
+			t.Fatalf("dynamic.LoadGrpcAPIConfiguration(%s) failed with %v", rule, err)
+		}
+		mux := runtime.NewServeMux()
+		err = dynamic.Register(mux, nil, protoregistry.GlobalFiles,
+			dynamic.WithServices("grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService"),
+			dynamic.WithHTTPRules(rules...),
+		)
+		if err == nil {
+			t.Errorf("dynamic.Register(...) with rule %s did not fail", rule)
+		}
+	}
+}
+
+type responseBodyServer struct {
+	examplepb.UnimplementedResponseBodyServiceServer
+}
+
+func (responseBodyServer) GetResponseBody(_ context.Context, req *examplepb.ResponseBodyIn) (*examplepb.ResponseBodyOut, error) {
+	return &examplepb.ResponseBodyOut{
+		Response: &examplepb.ResponseBodyOut_Response{Data: req.GetData()},
+	}, nil
+}
+
+// startReflectionServer starts a gRPC server with the ResponseBodyService
+// and the server reflection service.
+func startReflectionServer(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	return startGRPCServer(t, func(s *grpc.Server) {
+		examplepb.RegisterResponseBodyServiceServer(s, responseBodyServer{})
+		reflection.Register(s)
+	})
+}
+
+// startGRPCServer starts a gRPC server with the services registered by
+// register, and returns a connection to it.
+func startGRPCServer(t *testing.T, register func(s *grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "server.sock")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("net.Listen(...) failed with %v", err)
+	}
+	s := grpc.NewServer()
+	register(s)
+	go func() {
+		_ = s.Serve(l)
+	}()
+	t.Cleanup(s.Stop)
+	return dialUnix(t, socket)
+}
+
+func TestDynamicFilesFromReflection(t *testing.T) {
+	conn := startReflectionServer(t)
+	files, err := dynamic.FilesFromReflection(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("dynamic.FilesFromReflection(...) failed with %v", err)
+	}
+	mux := runtime.NewServeMux()
+	if err := dynamic.Register(mux, conn, files); err != nil {
+		t.Fatalf("dynamic.Register(...) failed with %v", err)
+	}
+
+	got := serveDynamic(mux, "GET", "/responsebody/foo", "")
+	if got.Code != http.StatusOK || got.Body != `{"data":"foo"}` {
+		t.Errorf("GET /responsebody/foo = %d %s; want 200 {\"data\":\"foo\"}", got.Code, got.Body)
+	}
+}
+
+func TestDynamicFilesFromDescriptorSet(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	add(examplepb.File_examples_internal_proto_examplepb_response_body_service_proto)
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatalf("proto.Marshal(...) failed with %v", err)
+	}
+
+	files, err := dynamic.FilesFromDescriptorSet(data)
+	if err != nil {
+		t.Fatalf("dynamic.FilesFromDescriptorSet(...) failed with %v", err)
+	}
+	conn := startReflectionServer(t)
+	mux := runtime.NewServeMux()
+	if err := dynamic.Register(mux, conn, files); err != nil {
+		t.Fatalf("dynamic.Register(...) failed with %v", err)
+	}
+
+	got := serveDynamic(mux, "GET", "/responsebody/bar", "")
+	if got.Code != http.StatusOK || got.Body != `{"data":"bar"}` {
+		t.Errorf("GET /responsebody/bar = %d %s; want 200 {\"data\":\"bar\"}", got.Code, got.Body)
+	}
+}
+
+func TestDynamicFieldBehavior(t *testing.T) {
+	conn := startDynamicServer(t)
+	for _, spec := range []struct {
+		opts     []runtime.ServeMuxOption
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{
+			body:     `{}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			opts:     []runtime.ServeMuxOption{runtime.WithFieldBehavior()},
+			body:     `{}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `missing required fields: id, foo`,
+		},
+		{
+			opts:     []runtime.ServeMuxOption{runtime.WithFieldBehavior()},
+			body:     `{"id": "a", "foo": {"bar": {}}}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `missing required fields: foo.bar.id`,
+		},
+		{
+			opts:     []runtime.ServeMuxOption{runtime.WithFieldBehavior()},
+			body:     `{"id": "a", "foo": {"bar": {"id": "b"}}}`,
+			wantCode: http.StatusOK,
+		},
+	} {
+		mux := runtime.NewServeMux(spec.opts...)
+		if err := dynamic.Register(mux, conn, protoregistry.GlobalFiles, dynamic.WithServices(
+			"grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService",
+		)); err != nil {
+			t.Fatalf("dynamic.Register(...) failed with %v", err)
+		}
+		got := serveDynamic(mux, "POST", "/v1/example/requiredmessagetype", spec.body)
+		if got.Code != spec.wantCode || !strings.Contains(got.Body, spec.wantBody) {
+			t.Errorf("POST /v1/example/requiredmessagetype %s = %d %s; want %d %s", spec.body, got.Code, got.Body, spec.wantCode, spec.wantBody)
+		}
+	}
+}
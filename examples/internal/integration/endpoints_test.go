@@ -0,0 +1,64 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/server"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestRegisterFromEndpoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "server.sock")
+	go func() {
+		if err := server.Run(ctx, "unix", socket); err != nil {
+			t.Errorf("server.Run(...) failed with %v", err)
+		}
+	}()
+
+	for _, spec := range []struct {
+		name string
+		opts []runtime.EndpointsOption
+	}{
+		{
+			name: "round robin",
+		},
+		{
+			name: "pick first",
+			opts: []runtime.EndpointsOption{runtime.WithPickFirst()},
+		},
+		{
+			name: "health check",
+			opts: []runtime.EndpointsOption{runtime.WithEndpointHealthCheck("")},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux()
+			// The first endpoint has no server, so calls fail over to the
+			// second one.
+			endpoints := []string{"unix://" + filepath.Join(dir, "missing.sock"), "unix://" + socket}
+			opts := append(spec.opts, runtime.WithEndpointsDialOptions(
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+			))
+			if err := examplepb.RegisterEchoServiceHandlerFromEndpoints(ctx, mux, endpoints, opts); err != nil {
+				t.Fatalf("examplepb.RegisterEchoServiceHandlerFromEndpoints(...) failed with %v", err)
+			}
+			for i := 0; i < 4; i++ {
+				got := serveDynamic(mux, "POST", "/v1/example/echo/myid", "")
+				if got.Code != http.StatusOK || !strings.Contains(got.Body, `"myid"`) {
+					t.Errorf("POST /v1/example/echo/myid = %d %s; want 200 with the id", got.Code, got.Body)
+				}
+			}
+		})
+	}
+}
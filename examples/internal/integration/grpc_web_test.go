@@ -0,0 +1,232 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// listStreamServer streams three messages, or one and an error.
+type listStreamServer struct {
+	examplepb.UnimplementedStreamServiceServer
+}
+
+func (s *listStreamServer) List(opts *examplepb.Options, stream examplepb.StreamService_ListServer) error {
+	for _, v := range []string{"a", "b", "c"} {
+		if err := stream.Send(&examplepb.ABitOfEverything{StringValue: v}); err != nil {
+			return err
+		}
+		if opts.GetError() {
+			return status.Error(codes.Aborted, "list aborted")
+		}
+	}
+	return nil
+}
+
+// postGRPCWeb sends the gRPC-Web request msg to mux, with the headers header,
+// and returns the messages and the trailers of the response.
+func postGRPCWeb(t *testing.T, mux *runtime.ServeMux, path, contentType string, header http.Header, msg proto.Message) ([][]byte, string) {
+	t.Helper()
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("proto.Marshal(%v) failed with %v", msg, err)
+	}
+	body := binary.BigEndian.AppendUint32([]byte{0}, uint32(len(b)))
+	body = append(body, b...)
+	text := strings.HasPrefix(contentType, "application/grpc-web-text")
+	if text {
+		body = []byte(base64.StdEncoding.EncodeToString(body))
+	}
+	r := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	r.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST %s = %d %s; want %d", path, w.Code, w.Body, http.StatusOK)
+	}
+
+	resp := w.Body.Bytes()
+	if text {
+		// Each frame is encoded on its own, with padding.
+		var decoded []byte
+		for len(resp) > 0 {
+			n := bytes.IndexByte(resp, '=')
+			if n < 0 {
+				n = len(resp)
+			}
+			for n < len(resp) && resp[n] == '=' {
+				n++
+			}
+			frame, err := base64.StdEncoding.DecodeString(string(resp[:n]))
+			if err != nil {
+				t.Fatalf("decoding %q failed with %v", w.Body, err)
+			}
+			decoded = append(decoded, frame...)
+			resp = resp[n:]
+		}
+		resp = decoded
+	}
+	var (
+		messages [][]byte
+		trailers string
+	)
+	for len(resp) >= 5 {
+		n := binary.BigEndian.Uint32(resp[1:5])
+		if resp[0]&0x80 != 0 {
+			trailers = string(resp[5 : 5+n])
+		} else {
+			messages = append(messages, resp[5:5+n])
+		}
+		resp = resp[5+n:]
+	}
+	return messages, trailers
+}
+
+func TestGRPCWeb(t *testing.T) {
+	conn := startGRPCServer(t, func(s *grpc.Server) {
+		examplepb.RegisterEchoServiceServer(s, &cachingEchoServer{})
+		examplepb.RegisterStreamServiceServer(s, &listStreamServer{})
+	})
+	mux := runtime.NewServeMux(runtime.WithGRPCWeb(runtime.GRPCWebOptions{}))
+	if err := examplepb.RegisterEchoServiceHandler(context.Background(), mux, conn); err != nil {
+		t.Fatalf("examplepb.RegisterEchoServiceHandler(...) failed with %v", err)
+	}
+	if err := examplepb.RegisterStreamServiceHandler(context.Background(), mux, conn); err != nil {
+		t.Fatalf("examplepb.RegisterStreamServiceHandler(...) failed with %v", err)
+	}
+
+	for _, contentType := range []string{"application/grpc-web+proto", "application/grpc-web-text+proto"} {
+		messages, trailers := postGRPCWeb(t, mux, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", contentType, nil, &examplepb.SimpleMessage{Id: "myid"})
+		if len(messages) != 1 {
+			t.Fatalf("%s: got %d messages; want 1", contentType, len(messages))
+		}
+		msg := &examplepb.SimpleMessage{}
+		if err := proto.Unmarshal(messages[0], msg); err != nil {
+			t.Fatalf("%s: proto.Unmarshal(...) failed with %v", contentType, err)
+		}
+		if msg.GetId() != "myid" {
+			t.Errorf("%s: id = %q; want %q", contentType, msg.GetId(), "myid")
+		}
+		if !strings.HasPrefix(trailers, "grpc-status: 0\r\n") {
+			t.Errorf("%s: trailers = %q; want grpc-status 0", contentType, trailers)
+		}
+	}
+
+	// The REST routes are still served.
+	if resp := serveDynamic(mux, http.MethodPost, "/v1/example/echo/myid", ""); resp.Code != http.StatusOK {
+		t.Errorf("POST /v1/example/echo/myid = %d %s; want %d", resp.Code, resp.Body, http.StatusOK)
+	}
+
+	for _, spec := range []struct {
+		opts         *examplepb.Options
+		wantMessages int
+		wantTrailers string
+	}{
+		{opts: &examplepb.Options{}, wantMessages: 3, wantTrailers: "grpc-status: 0\r\n"},
+		{opts: &examplepb.Options{Error: true}, wantMessages: 1, wantTrailers: "grpc-status: 10\r\ngrpc-message: list aborted\r\n"},
+	} {
+		messages, trailers := postGRPCWeb(t, mux, "/grpc.gateway.examples.internal.proto.examplepb.StreamService/List", "application/grpc-web+proto", nil, spec.opts)
+		if len(messages) != spec.wantMessages {
+			t.Errorf("List(%v): got %d messages; want %d", spec.opts, len(messages), spec.wantMessages)
+		}
+		if trailers != spec.wantTrailers {
+			t.Errorf("List(%v): trailers = %q; want %q", spec.opts, trailers, spec.wantTrailers)
+		}
+	}
+}
+
+func TestGRPCWebVisibility(t *testing.T) {
+	conn := startGRPCServer(t, func(s *grpc.Server) {
+		examplepb.RegisterVisibilityRuleEchoServiceServer(s, visibilityRuleEchoServer{})
+	})
+	mux := runtime.NewServeMux(
+		runtime.WithGRPCWeb(runtime.GRPCWebOptions{}),
+		runtime.WithVisibility(runtime.WithVisibilityHeader("X-Visibility")),
+	)
+	if err := examplepb.RegisterVisibilityRuleEchoServiceHandler(context.Background(), mux, conn); err != nil {
+		t.Fatalf("examplepb.RegisterVisibilityRuleEchoServiceHandler(...) failed with %v", err)
+	}
+	const path = "/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService/Echo"
+
+	for _, spec := range []struct {
+		name         string
+		contentType  string
+		labels       string
+		req          *examplepb.VisibilityRuleSimpleMessage
+		wantResp     *examplepb.VisibilityRuleSimpleMessage
+		wantTrailers string
+	}{
+		{
+			name:         "hidden response fields",
+			contentType:  "application/grpc-web+proto",
+			req:          &examplepb.VisibilityRuleSimpleMessage{Id: "myid"},
+			wantResp:     &examplepb.VisibilityRuleSimpleMessage{Id: "myid"},
+			wantTrailers: "grpc-status: 0\r\n",
+		},
+		{
+			name:         "visible response fields",
+			contentType:  "application/grpc-web+proto",
+			labels:       "PREVIEW",
+			req:          &examplepb.VisibilityRuleSimpleMessage{Id: "myid"},
+			wantResp:     &examplepb.VisibilityRuleSimpleMessage{Id: "myid", PreviewField: "preview"},
+			wantTrailers: "grpc-status: 0\r\n",
+		},
+		{
+			name:         "hidden request field",
+			contentType:  "application/grpc-web+proto",
+			req:          &examplepb.VisibilityRuleSimpleMessage{Id: "myid", InternalField: "set"},
+			wantTrailers: "grpc-status: 3\r\ngrpc-message: unknown field \"internal_field\"\r\n",
+		},
+		{
+			name:         "visible request field",
+			contentType:  "application/grpc-web+proto",
+			labels:       "INTERNAL",
+			req:          &examplepb.VisibilityRuleSimpleMessage{Id: "myid", InternalField: "set"},
+			wantResp:     &examplepb.VisibilityRuleSimpleMessage{Id: "myid", InternalField: "set", PreviewField: "preview"},
+			wantTrailers: "grpc-status: 0\r\n",
+		},
+		{
+			name:         "other subtype",
+			contentType:  "application/grpc-web+json",
+			labels:       "INTERNAL",
+			req:          &examplepb.VisibilityRuleSimpleMessage{Id: "myid"},
+			wantTrailers: "grpc-status: 12\r\ngrpc-message: " + path + " only supports the proto subtype of gRPC-Web\r\n",
+		},
+	} {
+		messages, trailers := postGRPCWeb(t, mux, path, spec.contentType, http.Header{"X-Visibility": {spec.labels}}, spec.req)
+		if trailers != spec.wantTrailers {
+			t.Errorf("%s: trailers = %q; want %q", spec.name, trailers, spec.wantTrailers)
+		}
+		if spec.wantResp == nil {
+			if len(messages) != 0 {
+				t.Errorf("%s: got %d messages; want none", spec.name, len(messages))
+			}
+			continue
+		}
+		if len(messages) != 1 {
+			t.Fatalf("%s: got %d messages; want 1", spec.name, len(messages))
+		}
+		resp := &examplepb.VisibilityRuleSimpleMessage{}
+		if err := proto.Unmarshal(messages[0], resp); err != nil {
+			t.Fatalf("%s: proto.Unmarshal(...) failed with %v", spec.name, err)
+		}
+		if !proto.Equal(resp, spec.wantResp) {
+			t.Errorf("%s: response = %v; want %v", spec.name, resp, spec.wantResp)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+)
+
+// countingEchoServer counts the calls of EchoBody.
+type countingEchoServer struct {
+	examplepb.UnimplementedEchoServiceServer
+	calls atomic.Int32
+}
+
+func (s *countingEchoServer) EchoBody(_ context.Context, msg *examplepb.SimpleMessage) (*examplepb.SimpleMessage, error) {
+	s.calls.Add(1)
+	return msg, nil
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	echo := &countingEchoServer{}
+	conn := startGRPCServer(t, func(s *grpc.Server) {
+		examplepb.RegisterEchoServiceServer(s, echo)
+	})
+	mux := runtime.NewServeMux(runtime.WithIdempotencyKeys(runtime.NewMemoryIdempotencyStore(), runtime.IdempotencyPolicy{}))
+	if err := examplepb.RegisterEchoServiceHandler(context.Background(), mux, conn); err != nil {
+		t.Fatalf("examplepb.RegisterEchoServiceHandler(...) failed with %v", err)
+	}
+	post := func(key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1/example/echo_body", strings.NewReader(body))
+		r.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	first := post("payment-1", `{"id":"myid"}`)
+	retry := post("payment-1", `{"id":"myid"}`)
+	if first.Code != http.StatusOK || retry.Code != http.StatusOK || first.Body.String() != retry.Body.String() {
+		t.Errorf("POST /v1/example/echo_body = %d %s, then %d %s; want the same response", first.Code, first.Body, retry.Code, retry.Body)
+	}
+	if got := retry.Header().Get("Idempotent-Replayed"); got != "true" {
+		t.Errorf("Idempotent-Replayed = %q; want %q", got, "true")
+	}
+	if w := post("payment-1", `{"id":"otherid"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status with another body = %d; want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	post("payment-2", `{"id":"myid"}`)
+	if calls := echo.calls.Load(); calls != 2 {
+		t.Errorf("calls = %d; want 2", calls)
+	}
+}
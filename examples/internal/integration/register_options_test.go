@@ -0,0 +1,102 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestRegisterWithOptions(t *testing.T) {
+	conn := startDynamicServer(t)
+	ctx := context.Background()
+
+	mux := runtime.NewServeMux()
+	if err := examplepb.RegisterResponseBodyServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{
+		Methods: []string{"grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService.GetResponseBody"},
+	}); err != nil {
+		t.Fatalf("RegisterResponseBodyServiceHandlerWithOptions(...) failed with %v", err)
+	}
+	if err := examplepb.RegisterResponseBodyService_ListResponseStringsHandlerClient(ctx, mux, examplepb.NewResponseBodyServiceClient(conn)); err != nil {
+		t.Fatalf("RegisterResponseBodyService_ListResponseStringsHandlerClient(...) failed with %v", err)
+	}
+
+	for _, spec := range []struct {
+		path string
+		want int
+	}{
+		{path: "/responsebody/foo", want: http.StatusOK},
+		{path: "/responsestrings/foo", want: http.StatusOK},
+		{path: "/responsebodies/foo", want: http.StatusNotFound},
+		{path: "/responsebody/samename/foo", want: http.StatusNotFound},
+	} {
+		if got := serveDynamic(mux, "GET", spec.path, ""); got.Code != spec.want {
+			t.Errorf("GET %s = %d %s; want %d", spec.path, got.Code, got.Body, spec.want)
+		}
+	}
+}
+
+func TestRegisterWithOptionsVisibility(t *testing.T) {
+	conn := startDynamicServer(t)
+	ctx := context.Background()
+
+	// The example server does not implement the VisibilityRule services, so
+	// registered routes return 501 and the others 404.
+	for _, spec := range []struct {
+		selectors []string
+		want      map[string]int
+	}{
+		{
+			// Methods are not filtered by visibility without selectors.
+			want: map[string]int{
+				"POST /v1/example/echo/id":                  http.StatusNotImplemented,
+				"GET /v1/example/echo_internal":             http.StatusNotImplemented,
+				"GET /v1/example/echo_preview":              http.StatusNotImplemented,
+				"GET /v1/example/echo_internal_and_preview": http.StatusNotImplemented,
+				"POST /v1/example/internal/echo/id":         http.StatusNotImplemented,
+			},
+		},
+		{
+			selectors: []string{"PREVIEW"},
+			want: map[string]int{
+				"POST /v1/example/echo/id":                  http.StatusNotImplemented,
+				"GET /v1/example/echo_internal":             http.StatusNotFound,
+				"GET /v1/example/echo_preview":              http.StatusNotImplemented,
+				"GET /v1/example/echo_internal_and_preview": http.StatusNotImplemented,
+				"POST /v1/example/internal/echo/id":         http.StatusNotFound,
+			},
+		},
+		{
+			// The methods of the INTERNAL service are registered with its label.
+			selectors: []string{"INTERNAL"},
+			want: map[string]int{
+				"POST /v1/example/echo/id":                  http.StatusNotImplemented,
+				"GET /v1/example/echo_internal":             http.StatusNotImplemented,
+				"GET /v1/example/echo_preview":              http.StatusNotFound,
+				"GET /v1/example/echo_internal_and_preview": http.StatusNotImplemented,
+				"POST /v1/example/internal/echo/id":         http.StatusNotImplemented,
+			},
+		},
+	} {
+		mux := runtime.NewServeMux()
+		if err := examplepb.RegisterVisibilityRuleEchoServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{
+			VisibilitySelectors: spec.selectors,
+		}); err != nil {
+			t.Fatalf("RegisterVisibilityRuleEchoServiceHandlerWithOptions(...) failed with %v", err)
+		}
+		if err := examplepb.RegisterVisibilityRuleInternalEchoServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{
+			VisibilitySelectors: spec.selectors,
+		}); err != nil {
+			t.Fatalf("RegisterVisibilityRuleInternalEchoServiceHandlerWithOptions(...) failed with %v", err)
+		}
+		for route, want := range spec.want {
+			method, path, _ := strings.Cut(route, " ")
+			if got := serveDynamic(mux, method, path, ""); got.Code != want {
+				t.Errorf("with selectors %v, %s = %d %s; want %d", spec.selectors, route, got.Code, got.Body, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// cachingEchoServer counts its calls, and lets the gateway cache its
+// responses for a minute.
+type cachingEchoServer struct {
+	examplepb.UnimplementedEchoServiceServer
+	calls atomic.Int32
+}
+
+func (s *cachingEchoServer) Echo(ctx context.Context, msg *examplepb.SimpleMessage) (*examplepb.SimpleMessage, error) {
+	s.calls.Add(1)
+	if err := grpc.SetHeader(ctx, metadata.Pairs("cache-control", "max-age=60")); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func TestResponseCache(t *testing.T) {
+	echo := &cachingEchoServer{}
+	conn := startGRPCServer(t, func(s *grpc.Server) {
+		examplepb.RegisterEchoServiceServer(s, echo)
+	})
+	mux := runtime.NewServeMux(runtime.WithResponseCache(runtime.NewMemoryResponseCache(100)))
+	if err := examplepb.RegisterEchoServiceHandler(context.Background(), mux, conn); err != nil {
+		t.Fatalf("examplepb.RegisterEchoServiceHandler(...) failed with %v", err)
+	}
+
+	first := serveDynamic(mux, http.MethodGet, "/v1/example/echo/myid/3", "")
+	second := serveDynamic(mux, http.MethodGet, "/v1/example/echo/myid/3", "")
+	if first.Code != http.StatusOK || second.Code != http.StatusOK || first.Body != second.Body {
+		t.Errorf("GET /v1/example/echo/myid/3 = %d %s, then %d %s; want the same response", first.Code, first.Body, second.Code, second.Body)
+	}
+	if second.Header.Get("Age") == "" {
+		t.Errorf("second response headers = %v; want an Age header", second.Header)
+	}
+	if got, want := second.Header.Get("Grpc-Metadata-Cache-Control"), "max-age=60"; got != want {
+		t.Errorf("Grpc-Metadata-Cache-Control = %q; want %q", got, want)
+	}
+	serveDynamic(mux, http.MethodGet, "/v1/example/echo/otherid/3", "")
+	// POST requests are never cached.
+	serveDynamic(mux, http.MethodPost, "/v1/example/echo/myid", "")
+	serveDynamic(mux, http.MethodPost, "/v1/example/echo/myid", "")
+	if calls := echo.calls.Load(); calls != 4 {
+		t.Errorf("calls = %d; want 4", calls)
+	}
+}
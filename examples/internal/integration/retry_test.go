@@ -0,0 +1,61 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyEchoServer fails every other call with Unavailable, like a backend
+// which is restarting.
+type flakyEchoServer struct {
+	examplepb.UnimplementedEchoServiceServer
+	calls atomic.Int32
+}
+
+func (s *flakyEchoServer) Echo(_ context.Context, msg *examplepb.SimpleMessage) (*examplepb.SimpleMessage, error) {
+	if s.calls.Add(1)%2 == 1 {
+		return nil, status.Error(codes.Unavailable, "restarting")
+	}
+	return msg, nil
+}
+
+func TestRetryPolicy(t *testing.T) {
+	echo := &flakyEchoServer{}
+	conn := startGRPCServer(t, func(s *grpc.Server) {
+		examplepb.RegisterEchoServiceServer(s, echo)
+	})
+	mux := runtime.NewServeMux(runtime.WithRetryPolicy(runtime.RetryPolicy{
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+	}))
+	if err := examplepb.RegisterEchoServiceHandler(context.Background(), mux, conn); err != nil {
+		t.Fatalf("examplepb.RegisterEchoServiceHandler(...) failed with %v", err)
+	}
+
+	got := serveDynamic(mux, http.MethodGet, "/v1/example/echo/myid/3", "")
+	if got.Code != http.StatusOK || !strings.Contains(got.Body, `"myid"`) {
+		t.Errorf("GET /v1/example/echo/myid/3 = %d %s; want 200 with the id", got.Code, got.Body)
+	}
+	if retries := got.Header.Get(runtime.RetriesHeader); retries != "1" {
+		t.Errorf("GET /v1/example/echo/myid/3: %s = %q; want %q", runtime.RetriesHeader, retries, "1")
+	}
+
+	// Echo is not declared idempotent, so its POST binding is not retried.
+	got = serveDynamic(mux, http.MethodPost, "/v1/example/echo/myid", "")
+	if got.Code != http.StatusServiceUnavailable {
+		t.Errorf("POST /v1/example/echo/myid = %d %s; want 503", got.Code, got.Body)
+	}
+	if calls := echo.calls.Load(); calls != 3 {
+		t.Errorf("calls = %d; want 3", calls)
+	}
+}
@@ -0,0 +1,276 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/dynamic"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+type visibilityRuleEchoServer struct {
+	examplepb.UnimplementedVisibilityRuleEchoServiceServer
+	examplepb.UnimplementedVisibilityRuleInternalEchoServiceServer
+}
+
+// Echo returns the request, with its internal and preview fields set if they
+// are not.
+func (visibilityRuleEchoServer) Echo(_ context.Context, req *examplepb.VisibilityRuleSimpleMessage) (*examplepb.VisibilityRuleSimpleMessage, error) {
+	resp := proto.Clone(req).(*examplepb.VisibilityRuleSimpleMessage)
+	if resp.InternalField == "" {
+		resp.InternalField = "internal"
+	}
+	if resp.PreviewField == "" {
+		resp.PreviewField = "preview"
+	}
+	return resp, nil
+}
+
+func (s visibilityRuleEchoServer) EchoInternal(ctx context.Context, req *examplepb.VisibilityRuleSimpleMessage) (*examplepb.VisibilityRuleSimpleMessage, error) {
+	return s.Echo(ctx, req)
+}
+
+func startVisibilityServer(t *testing.T, opts ...runtime.VisibilityOption) *runtime.ServeMux {
+	t.Helper()
+	srv := visibilityRuleEchoServer{}
+	conn := startGRPCServer(t, func(s *grpc.Server) {
+		examplepb.RegisterVisibilityRuleEchoServiceServer(s, srv)
+		examplepb.RegisterVisibilityRuleInternalEchoServiceServer(s, srv)
+	})
+
+	ctx := context.Background()
+	mux := runtime.NewServeMux(runtime.WithVisibility(append([]runtime.VisibilityOption{runtime.WithVisibilityHeader("X-Visibility")}, opts...)...))
+	if err := examplepb.RegisterVisibilityRuleEchoServiceHandler(ctx, mux, conn); err != nil {
+		t.Fatalf("RegisterVisibilityRuleEchoServiceHandler(...) failed with %v", err)
+	}
+	if err := examplepb.RegisterVisibilityRuleInternalEchoServiceHandler(ctx, mux, conn); err != nil {
+		t.Fatalf("RegisterVisibilityRuleInternalEchoServiceHandler(...) failed with %v", err)
+	}
+	// A route with a body, which the generated handlers do not have.
+	if err := dynamic.Register(mux, conn, protoregistry.GlobalFiles,
+		dynamic.WithServices("grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService"),
+		dynamic.WithHTTPRules(&annotations.HttpRule{
+			Selector: "grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService.Echo",
+			Pattern:  &annotations.HttpRule_Post{Post: "/v2/example/echo"},
+			Body:     "*",
+		}),
+	); err != nil {
+		t.Fatalf("dynamic.Register(...) failed with %v", err)
+	}
+	return mux
+}
+
+func serveVisibility(mux *runtime.ServeMux, method, path, body, labels string) dynamicResponse {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	if labels != "" {
+		r.Header.Set("X-Visibility", labels)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return dynamicResponse{Code: w.Code, Header: w.Header(), Body: w.Body.String()}
+}
+
+// checkVisibleFields checks that the JSON object body has the fields of want,
+// with their values, and no other field of visibilityFields.
+func checkVisibleFields(t *testing.T, body string, want map[string]interface{}) {
+	t.Helper()
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed with %v", body, err)
+	}
+	fields := append([]string{}, visibilityFields...)
+	for field := range want {
+		fields = append(fields, field)
+	}
+	for _, field := range fields {
+		wantValue, ok := want[field]
+		gotValue, gotOK := got[field]
+		switch {
+		case ok && !gotOK:
+			t.Errorf("response %s has no field %q", body, field)
+		case !ok && gotOK:
+			t.Errorf("response %s has hidden field %q", body, field)
+		case ok && !reflect.DeepEqual(gotValue, wantValue):
+			t.Errorf("response %s has field %q = %v; want %v", body, field, gotValue, wantValue)
+		}
+	}
+}
+
+// visibilityFields are the JSON names of the fields of
+// VisibilityRuleSimpleMessage with a visibility restriction.
+var visibilityFields = []string{"internalField", "previewField"}
+
+func TestVisibility(t *testing.T) {
+	mux := startVisibilityServer(t)
+	for _, spec := range []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		labels   string
+		wantCode int
+		// wantBody is a substring of the body of errors.
+		wantBody string
+		// want are fields of the body of successful responses.
+		want map[string]interface{}
+	}{
+		{
+			name:     "hidden response fields",
+			method:   "POST",
+			path:     "/v1/example/echo/myid?num=1",
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"id": "myid", "num": "1", "anEnum": "VISIBILITY_ENUM_UNSPECIFIED"},
+		},
+		{
+			name:     "visible response fields",
+			method:   "POST",
+			path:     "/v1/example/echo/myid",
+			labels:   "PREVIEW",
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"id": "myid", "previewField": "preview"},
+		},
+		{
+			name:     "hidden query parameter",
+			method:   "POST",
+			path:     "/v1/example/echo/myid?preview_field=x",
+			wantCode: http.StatusBadRequest,
+			wantBody: `unknown field \"preview_field\"`,
+		},
+		{
+			name:     "visible query parameter",
+			method:   "POST",
+			path:     "/v1/example/echo/myid?internal_field=x",
+			labels:   "OTHER, INTERNAL",
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"id": "myid", "internalField": "x", "previewField": "preview"},
+		},
+		{
+			name:     "hidden nested query parameter",
+			method:   "POST",
+			path:     "/v1/example/echo/myid?status.internal_field=x",
+			wantCode: http.StatusBadRequest,
+			wantBody: `unknown field \"status.internal_field\"`,
+		},
+		{
+			name:     "hidden enum value",
+			method:   "POST",
+			path:     "/v1/example/echo/myid?an_enum=VISIBILITY_ENUM_PREVIEW",
+			wantCode: http.StatusBadRequest,
+			wantBody: `unknown field \"an_enum\"`,
+		},
+		{
+			name:     "visible enum value",
+			method:   "POST",
+			path:     "/v1/example/echo/myid?an_enum=VISIBILITY_ENUM_PREVIEW",
+			labels:   "PREVIEW",
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"id": "myid", "previewField": "preview", "anEnum": "VISIBILITY_ENUM_PREVIEW"},
+		},
+		{
+			name:     "hidden body field",
+			method:   "POST",
+			path:     "/v2/example/echo",
+			body:     `{"id":"myid","no":{"preview_field":"x"}}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `unknown field \"no.preview_field\"`,
+		},
+		{
+			name:     "visible body field",
+			method:   "POST",
+			path:     "/v2/example/echo",
+			body:     `{"id":"myid","no":{"preview_field":"x"}}`,
+			labels:   "PREVIEW",
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"id": "myid", "no": map[string]interface{}{"previewField": "x"}, "previewField": "preview"},
+		},
+		{
+			name:     "hidden method",
+			method:   "GET",
+			path:     "/v1/example/echo_internal",
+			labels:   "PREVIEW",
+			wantCode: http.StatusNotFound,
+			wantBody: `"message":"Not Found"`,
+		},
+		{
+			name:     "visible method",
+			method:   "GET",
+			path:     "/v1/example/echo_internal?id=myid",
+			labels:   "INTERNAL",
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"id": "myid", "internalField": "internal", "previewField": "preview"},
+		},
+		{
+			name:     "hidden service",
+			method:   "POST",
+			path:     "/v1/example/internal/echo/myid",
+			wantCode: http.StatusNotFound,
+			wantBody: `"message":"Not Found"`,
+		},
+		{
+			name:     "visible service",
+			method:   "POST",
+			path:     "/v1/example/internal/echo/myid",
+			labels:   "INTERNAL",
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"id": "myid", "internalField": "internal", "previewField": "preview"},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			got := serveVisibility(mux, spec.method, spec.path, spec.body, spec.labels)
+			if got.Code != spec.wantCode || !strings.Contains(got.Body, spec.wantBody) {
+				t.Fatalf("%s %s = %d %s; want %d %s", spec.method, spec.path, got.Code, got.Body, spec.wantCode, spec.wantBody)
+			}
+			if spec.want != nil {
+				checkVisibleFields(t, got.Body, spec.want)
+			}
+		})
+	}
+}
+
+func TestVisibilityDropHiddenFields(t *testing.T) {
+	mux := startVisibilityServer(t,
+		runtime.WithVisibilityDropHiddenFields(),
+		runtime.WithVisibilityResolver(func(_ context.Context, r *http.Request) []string {
+			if r.URL.Query().Get("preview") == "true" {
+				return []string{"PREVIEW"}
+			}
+			return nil
+		}),
+	)
+	for _, spec := range []struct {
+		path string
+		body string
+		want map[string]interface{}
+	}{
+		{
+			path: "/v1/example/echo/myid?internal_field=x&an_enum=VISIBILITY_ENUM_PREVIEW",
+			want: map[string]interface{}{"id": "myid", "anEnum": "VISIBILITY_ENUM_UNSPECIFIED"},
+		},
+		{
+			path: "/v2/example/echo",
+			body: `{"id":"myid","internal_field":"x","status":{"internal_field":"y"}}`,
+			want: map[string]interface{}{"id": "myid", "status": map[string]interface{}{}},
+		},
+		{
+			path: "/v1/example/echo/myid?preview=true&an_enum=VISIBILITY_ENUM_PREVIEW",
+			want: map[string]interface{}{"id": "myid", "previewField": "preview", "anEnum": "VISIBILITY_ENUM_PREVIEW"},
+		},
+	} {
+		got := serveVisibility(mux, "POST", spec.path, spec.body, "")
+		if got.Code != http.StatusOK {
+			t.Errorf("POST %s %s = %d %s; want 200", spec.path, spec.body, got.Code, got.Body)
+			continue
+		}
+		checkVisibleFields(t, got.Body, spec.want)
+	}
+}
@@ -211,9 +211,10 @@ func request_ABitOfEverythingService_Create_0(ctx context.Context, marshaler run
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_Create_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_Create_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Create(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -384,9 +385,10 @@ func local_request_ABitOfEverythingService_Create_0(ctx context.Context, marshal
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_Create_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_Create_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Create(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -402,6 +404,7 @@ func request_ABitOfEverythingService_CreateBody_0(ctx context.Context, marshaler
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.CreateBody(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -414,6 +417,7 @@ func local_request_ABitOfEverythingService_CreateBody_0(ctx context.Context, mar
 	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.CreateBody(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -443,9 +447,10 @@ func request_ABitOfEverythingService_CreateBook_0(ctx context.Context, marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CreateBook_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CreateBook_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.CreateBook(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -470,9 +475,10 @@ func local_request_ABitOfEverythingService_CreateBook_0(ctx context.Context, mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CreateBook_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CreateBook_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.CreateBook(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -489,7 +495,13 @@ func request_ABitOfEverythingService_UpdateBook_0(ctx context.Context, marshaler
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
+	if runtime.IsJSONPatch(req) {
+		fieldMask, err := runtime.DecodeJSONPatch(newReader(), &protoReq.Book)
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.UpdateMask = fieldMask
+	} else if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -513,9 +525,10 @@ func request_ABitOfEverythingService_UpdateBook_0(ctx context.Context, marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_UpdateBook_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_UpdateBook_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.UpdateBook(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -530,7 +543,13 @@ func local_request_ABitOfEverythingService_UpdateBook_0(ctx context.Context, mar
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
+	if runtime.IsJSONPatch(req) {
+		fieldMask, err := runtime.DecodeJSONPatch(newReader(), &protoReq.Book)
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.UpdateMask = fieldMask
+	} else if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if protoReq.UpdateMask == nil || len(protoReq.UpdateMask.GetPaths()) == 0 {
@@ -551,9 +570,10 @@ func local_request_ABitOfEverythingService_UpdateBook_0(ctx context.Context, mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_UpdateBook_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_UpdateBook_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.UpdateBook(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -575,6 +595,7 @@ func request_ABitOfEverythingService_Lookup_0(ctx context.Context, marshaler run
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "uuid", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Lookup(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -593,6 +614,7 @@ func local_request_ABitOfEverythingService_Lookup_0(ctx context.Context, marshal
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "uuid", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Lookup(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -619,9 +641,10 @@ func request_ABitOfEverythingService_Custom_0(ctx context.Context, marshaler run
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_Custom_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_Custom_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Custom(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -643,9 +666,10 @@ func local_request_ABitOfEverythingService_Custom_0(ctx context.Context, marshal
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_Custom_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_Custom_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Custom(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -672,9 +696,10 @@ func request_ABitOfEverythingService_DoubleColon_0(ctx context.Context, marshale
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_DoubleColon_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_DoubleColon_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.DoubleColon(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -696,9 +721,10 @@ func local_request_ABitOfEverythingService_DoubleColon_0(ctx context.Context, ma
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_DoubleColon_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_DoubleColon_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.DoubleColon(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -723,6 +749,7 @@ func request_ABitOfEverythingService_Update_0(ctx context.Context, marshaler run
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "uuid", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Update(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -744,6 +771,7 @@ func local_request_ABitOfEverythingService_Update_0(ctx context.Context, marshal
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "uuid", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Update(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -773,9 +801,10 @@ func request_ABitOfEverythingService_UpdateV2_0(ctx context.Context, marshaler r
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_UpdateV2_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_UpdateV2_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.UpdateV2(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -800,9 +829,10 @@ func local_request_ABitOfEverythingService_UpdateV2_0(ctx context.Context, marsh
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_UpdateV2_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_UpdateV2_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.UpdateV2(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -819,7 +849,13 @@ func request_ABitOfEverythingService_UpdateV2_1(ctx context.Context, marshaler r
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
+	if runtime.IsJSONPatch(req) {
+		fieldMask, err := runtime.DecodeJSONPatch(newReader(), &protoReq.Abe)
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.UpdateMask = fieldMask
+	} else if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -843,9 +879,10 @@ func request_ABitOfEverythingService_UpdateV2_1(ctx context.Context, marshaler r
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_UpdateV2_1); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_UpdateV2_1); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.UpdateV2(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -860,7 +897,13 @@ func local_request_ABitOfEverythingService_UpdateV2_1(ctx context.Context, marsh
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
+	if runtime.IsJSONPatch(req) {
+		fieldMask, err := runtime.DecodeJSONPatch(newReader(), &protoReq.Abe)
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.UpdateMask = fieldMask
+	} else if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if protoReq.UpdateMask == nil || len(protoReq.UpdateMask.GetPaths()) == 0 {
@@ -881,9 +924,10 @@ func local_request_ABitOfEverythingService_UpdateV2_1(ctx context.Context, marsh
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_UpdateV2_1); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_UpdateV2_1); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.UpdateV2(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -908,6 +952,7 @@ func request_ABitOfEverythingService_UpdateV2_2(ctx context.Context, marshaler r
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "abe.uuid", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.UpdateV2(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -929,6 +974,7 @@ func local_request_ABitOfEverythingService_UpdateV2_2(ctx context.Context, marsh
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "abe.uuid", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.UpdateV2(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -950,6 +996,7 @@ func request_ABitOfEverythingService_Delete_0(ctx context.Context, marshaler run
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "uuid", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Delete(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -968,6 +1015,7 @@ func local_request_ABitOfEverythingService_Delete_0(ctx context.Context, marshal
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "uuid", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Delete(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -994,9 +1042,10 @@ func request_ABitOfEverythingService_GetQuery_0(ctx context.Context, marshaler r
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_GetQuery_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_GetQuery_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.GetQuery(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1018,9 +1067,10 @@ func local_request_ABitOfEverythingService_GetQuery_0(ctx context.Context, marsh
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_GetQuery_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_GetQuery_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.GetQuery(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1168,6 +1218,7 @@ func request_ABitOfEverythingService_GetRepeatedQuery_0(ctx context.Context, mar
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "path_repeated_sint64_value", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.GetRepeatedQuery(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1312,6 +1363,7 @@ func local_request_ABitOfEverythingService_GetRepeatedQuery_0(ctx context.Contex
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "path_repeated_sint64_value", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.GetRepeatedQuery(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1333,6 +1385,7 @@ func request_ABitOfEverythingService_Echo_0(ctx context.Context, marshaler runti
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "value", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1351,6 +1404,7 @@ func local_request_ABitOfEverythingService_Echo_0(ctx context.Context, marshaler
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "value", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1366,6 +1420,7 @@ func request_ABitOfEverythingService_Echo_1(ctx context.Context, marshaler runti
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1378,6 +1433,7 @@ func local_request_ABitOfEverythingService_Echo_1(ctx context.Context, marshaler
 	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Value); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1395,9 +1451,10 @@ func request_ABitOfEverythingService_Echo_2(ctx context.Context, marshaler runti
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_Echo_2); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_Echo_2); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1410,9 +1467,10 @@ func local_request_ABitOfEverythingService_Echo_2(ctx context.Context, marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_Echo_2); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_Echo_2); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1437,6 +1495,7 @@ func request_ABitOfEverythingService_DeepPathEcho_0(ctx context.Context, marshal
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "single_nested.name", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.DeepPathEcho(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1458,6 +1517,7 @@ func local_request_ABitOfEverythingService_DeepPathEcho_0(ctx context.Context, m
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "single_nested.name", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.DeepPathEcho(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1470,6 +1530,7 @@ func request_ABitOfEverythingService_Timeout_0(ctx context.Context, marshaler ru
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Timeout(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1479,6 +1540,7 @@ func local_request_ABitOfEverythingService_Timeout_0(ctx context.Context, marsha
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Timeout(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1491,6 +1553,7 @@ func request_ABitOfEverythingService_ErrorWithDetails_0(ctx context.Context, mar
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.ErrorWithDetails(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1500,6 +1563,7 @@ func local_request_ABitOfEverythingService_ErrorWithDetails_0(ctx context.Contex
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.ErrorWithDetails(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1524,6 +1588,7 @@ func request_ABitOfEverythingService_GetMessageWithBody_0(ctx context.Context, m
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "id", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.GetMessageWithBody(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1545,6 +1610,7 @@ func local_request_ABitOfEverythingService_GetMessageWithBody_0(ctx context.Cont
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "id", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.GetMessageWithBody(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1569,6 +1635,7 @@ func request_ABitOfEverythingService_PostWithEmptyBody_0(ctx context.Context, ma
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.PostWithEmptyBody(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1590,6 +1657,7 @@ func local_request_ABitOfEverythingService_PostWithEmptyBody_0(ctx context.Conte
 	if err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "name", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.PostWithEmptyBody(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1616,9 +1684,10 @@ func request_ABitOfEverythingService_CheckGetQueryParams_0(ctx context.Context,
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CheckGetQueryParams_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CheckGetQueryParams_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.CheckGetQueryParams(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1640,9 +1709,10 @@ func local_request_ABitOfEverythingService_CheckGetQueryParams_0(ctx context.Con
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CheckGetQueryParams_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CheckGetQueryParams_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.CheckGetQueryParams(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1675,9 +1745,10 @@ func request_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(ctx context
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.CheckNestedEnumGetQueryParams(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1705,9 +1776,10 @@ func local_request_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(ctx c
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.CheckNestedEnumGetQueryParams(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1737,9 +1809,10 @@ func request_ABitOfEverythingService_CheckPostQueryParams_0(ctx context.Context,
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CheckPostQueryParams_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CheckPostQueryParams_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.CheckPostQueryParams(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1764,9 +1837,10 @@ func local_request_ABitOfEverythingService_CheckPostQueryParams_0(ctx context.Co
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CheckPostQueryParams_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CheckPostQueryParams_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.CheckPostQueryParams(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1782,6 +1856,7 @@ func request_ABitOfEverythingService_OverwriteRequestContentType_0(ctx context.C
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.OverwriteRequestContentType(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1794,6 +1869,7 @@ func local_request_ABitOfEverythingService_OverwriteRequestContentType_0(ctx con
 	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.OverwriteRequestContentType(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1806,6 +1882,7 @@ func request_ABitOfEverythingService_OverwriteResponseContentType_0(ctx context.
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.OverwriteResponseContentType(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1815,6 +1892,7 @@ func local_request_ABitOfEverythingService_OverwriteResponseContentType_0(ctx co
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.OverwriteResponseContentType(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1838,6 +1916,7 @@ func request_ABitOfEverythingService_CheckExternalPathEnum_0(ctx context.Context
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "value", err)
 	}
 	protoReq.Value = pathenum.PathEnum(e)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.CheckExternalPathEnum(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1858,6 +1937,7 @@ func local_request_ABitOfEverythingService_CheckExternalPathEnum_0(ctx context.C
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "value", err)
 	}
 	protoReq.Value = pathenum.PathEnum(e)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.CheckExternalPathEnum(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1881,6 +1961,7 @@ func request_ABitOfEverythingService_CheckExternalNestedPathEnum_0(ctx context.C
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "value", err)
 	}
 	protoReq.Value = pathenum.MessagePathEnum_NestedPathEnum(e)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.CheckExternalNestedPathEnum(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1901,6 +1982,7 @@ func local_request_ABitOfEverythingService_CheckExternalNestedPathEnum_0(ctx con
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "value", err)
 	}
 	protoReq.Value = pathenum.MessagePathEnum_NestedPathEnum(e)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.CheckExternalNestedPathEnum(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1913,6 +1995,7 @@ func request_ABitOfEverythingService_CheckStatus_0(ctx context.Context, marshale
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.CheckStatus(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1922,6 +2005,7 @@ func local_request_ABitOfEverythingService_CheckStatus_0(ctx context.Context, ma
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.CheckStatus(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -1948,9 +2032,10 @@ func request_ABitOfEverythingService_Exists_0(ctx context.Context, marshaler run
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_Exists_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_Exists_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Exists(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -1972,9 +2057,10 @@ func local_request_ABitOfEverythingService_Exists_0(ctx context.Context, marshal
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_Exists_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_Exists_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Exists(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -2001,9 +2087,10 @@ func request_ABitOfEverythingService_CustomOptionsRequest_0(ctx context.Context,
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CustomOptionsRequest_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CustomOptionsRequest_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.CustomOptionsRequest(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -2025,9 +2112,10 @@ func local_request_ABitOfEverythingService_CustomOptionsRequest_0(ctx context.Co
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_CustomOptionsRequest_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_CustomOptionsRequest_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.CustomOptionsRequest(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -2054,9 +2142,10 @@ func request_ABitOfEverythingService_TraceRequest_0(ctx context.Context, marshal
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_TraceRequest_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_TraceRequest_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.TraceRequest(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -2078,9 +2167,10 @@ func local_request_ABitOfEverythingService_TraceRequest_0(ctx context.Context, m
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_ABitOfEverythingService_TraceRequest_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_ABitOfEverythingService_TraceRequest_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.TraceRequest(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -2101,6 +2191,7 @@ func request_ABitOfEverythingService_PostOneofEnum_0(ctx context.Context, marsha
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.PostOneofEnum(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -2118,6 +2209,7 @@ func local_request_ABitOfEverythingService_PostOneofEnum_0(ctx context.Context,
 	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.One.(*oneofenum.OneofEnumMessage_ExampleEnum).ExampleEnum); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.PostOneofEnum(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -2133,6 +2225,7 @@ func request_ABitOfEverythingService_PostRequiredMessageType_0(ctx context.Conte
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.PostRequiredMessageType(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -2145,6 +2238,7 @@ func local_request_ABitOfEverythingService_PostRequiredMessageType_0(ctx context
 	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.PostRequiredMessageType(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -2157,6 +2251,7 @@ func request_CamelCaseServiceName_Empty_0(ctx context.Context, marshaler runtime
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Empty(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -2166,6 +2261,7 @@ func local_request_CamelCaseServiceName_Empty_0(ctx context.Context, marshaler r
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Empty(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -2207,6 +2303,7 @@ func request_SnakeEnumService_SnakeEnum_0(ctx context.Context, marshaler runtime
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "where", err)
 	}
 	protoReq.Where = pathenum.SnakeCaseForImport(e)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.SnakeEnum(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -2245,6 +2342,7 @@ func local_request_SnakeEnumService_SnakeEnum_0(ctx context.Context, marshaler r
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "where", err)
 	}
 	protoReq.Where = pathenum.SnakeCaseForImport(e)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.SnakeEnum(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -2255,7 +2353,7 @@ func local_request_SnakeEnumService_SnakeEnum_0(ctx context.Context, marshaler r
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterABitOfEverythingServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
 func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ABitOfEverythingServiceServer) error {
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_Create_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Create", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2269,13 +2367,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Create_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Create_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CreateBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_CreateBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2289,13 +2388,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_CreateBody_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CreateBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CreateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_CreateBook_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBook", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2309,13 +2409,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_CreateBook_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CreateBook_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPatch, pattern_ABitOfEverythingService_UpdateBook_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateBook", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2329,13 +2430,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_UpdateBook_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_UpdateBook_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Lookup_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_Lookup_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Lookup", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2349,13 +2451,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Lookup_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Lookup_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Custom_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_Custom_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Custom", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2369,13 +2472,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Custom_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Custom_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_DoubleColon_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_DoubleColon_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DoubleColon", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2389,13 +2493,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_DoubleColon_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_DoubleColon_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPut, pattern_ABitOfEverythingService_Update_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPut, pattern_ABitOfEverythingService_Update_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Update", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2409,13 +2514,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Update_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Update_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPut, pattern_ABitOfEverythingService_UpdateV2_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPut, pattern_ABitOfEverythingService_UpdateV2_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2429,13 +2535,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_UpdateV2_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_1, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2449,13 +2556,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_UpdateV2_1(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_2, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2469,13 +2577,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_UpdateV2_2(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodDelete, pattern_ABitOfEverythingService_Delete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodDelete, pattern_ABitOfEverythingService_Delete_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Delete", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2489,13 +2598,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Delete_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Delete_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_GetQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_GetQuery_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetQuery", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2509,13 +2619,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_GetQuery_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_GetQuery_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_GetRepeatedQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_GetRepeatedQuery_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetRepeatedQuery", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2529,13 +2640,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_GetRepeatedQuery_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_GetRepeatedQuery_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2549,13 +2661,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Echo_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Echo_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_Echo_1, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2569,13 +2682,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Echo_1(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Echo_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_Echo_2, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2589,13 +2703,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Echo_2(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Echo_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_DeepPathEcho_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_DeepPathEcho_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DeepPathEcho", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2609,13 +2724,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_DeepPathEcho_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_DeepPathEcho_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Timeout_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_Timeout_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Timeout", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2629,13 +2745,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Timeout_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Timeout_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_ErrorWithDetails_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_ErrorWithDetails_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/ErrorWithDetails", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2649,13 +2766,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_ErrorWithDetails_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_ErrorWithDetails_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_GetMessageWithBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_GetMessageWithBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetMessageWithBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2669,13 +2787,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_GetMessageWithBody_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_GetMessageWithBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostWithEmptyBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_PostWithEmptyBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostWithEmptyBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2689,13 +2808,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_PostWithEmptyBody_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_PostWithEmptyBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckGetQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckGetQueryParams", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2709,13 +2829,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_CheckGetQueryParams_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckGetQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckNestedEnumGetQueryParams", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2729,13 +2850,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CheckPostQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_CheckPostQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckPostQueryParams", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2749,13 +2871,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_CheckPostQueryParams_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckPostQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_OverwriteRequestContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_OverwriteRequestContentType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteRequestContentType", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2769,13 +2892,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_OverwriteRequestContentType_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_OverwriteRequestContentType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_OverwriteResponseContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_OverwriteResponseContentType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteResponseContentType", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2789,13 +2913,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_OverwriteResponseContentType_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_OverwriteResponseContentType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalPathEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalPathEnum", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2809,13 +2934,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_CheckExternalPathEnum_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckExternalPathEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalNestedPathEnum", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2829,13 +2955,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_CheckExternalNestedPathEnum_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckExternalNestedPathEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckStatus_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckStatus_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckStatus", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2849,13 +2976,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_CheckStatus_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckStatus_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodHead, pattern_ABitOfEverythingService_Exists_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodHead, pattern_ABitOfEverythingService_Exists_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Exists", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2869,13 +2997,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_Exists_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Exists_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodOptions, pattern_ABitOfEverythingService_CustomOptionsRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodOptions, pattern_ABitOfEverythingService_CustomOptionsRequest_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CustomOptionsRequest", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2889,13 +3018,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_CustomOptionsRequest_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CustomOptionsRequest_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodTrace, pattern_ABitOfEverythingService_TraceRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodTrace, pattern_ABitOfEverythingService_TraceRequest_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/TraceRequest", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2909,13 +3039,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_TraceRequest_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_TraceRequest_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostOneofEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_PostOneofEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostOneofEnum", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2929,13 +3060,14 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_PostOneofEnum_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_PostOneofEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostRequiredMessageType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_PostRequiredMessageType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostRequiredMessageType", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2949,6 +3081,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 		resp, md, err := local_request_ABitOfEverythingService_PostRequiredMessageType_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -2965,7 +3098,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterCamelCaseServiceNameHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
 func RegisterCamelCaseServiceNameHandlerServer(ctx context.Context, mux *runtime.ServeMux, server CamelCaseServiceNameServer) error {
-	mux.Handle(http.MethodGet, pattern_CamelCaseServiceName_Empty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_CamelCaseServiceName_Empty_0, "/grpc.gateway.examples.internal.proto.examplepb.CamelCaseServiceName/Empty", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2979,6 +3112,7 @@ func RegisterCamelCaseServiceNameHandlerServer(ctx context.Context, mux *runtime
 		resp, md, err := local_request_CamelCaseServiceName_Empty_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -2995,7 +3129,7 @@ func RegisterCamelCaseServiceNameHandlerServer(ctx context.Context, mux *runtime
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterSnakeEnumServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
 func RegisterSnakeEnumServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server SnakeEnumServiceServer) error {
-	mux.Handle(http.MethodGet, pattern_SnakeEnumService_SnakeEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_SnakeEnumService_SnakeEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.SnakeEnumService/SnakeEnum", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -3009,6 +3143,7 @@ func RegisterSnakeEnumServiceHandlerServer(ctx context.Context, mux *runtime.Ser
 		resp, md, err := local_request_SnakeEnumService_SnakeEnum_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -3043,10 +3178,35 @@ func RegisterABitOfEverythingServiceHandlerFromEndpoint(ctx context.Context, mux
 	return RegisterABitOfEverythingServiceHandler(ctx, mux, conn)
 }
 
+// RegisterABitOfEverythingServiceHandlerFromEndpoints is same as RegisterABitOfEverythingServiceHandlerFromEndpoint but
+// balances the calls between the grpc servers at "endpoints" with runtime.NewEndpointsClient.
+func RegisterABitOfEverythingServiceHandlerFromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+		}()
+	}()
+	return RegisterABitOfEverythingServiceHandler(ctx, mux, conn)
+}
+
 // RegisterABitOfEverythingServiceHandler registers the http handlers for service ABitOfEverythingService to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
+// The handlers forward requests to the grpc endpoint over "conn", which also serves the gRPC-Web requests
+// to the service if "mux" has runtime.WithGRPCWeb.
 func RegisterABitOfEverythingServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterABitOfEverythingServiceHandlerClient(ctx, mux, NewABitOfEverythingServiceClient(conn))
+	return RegisterABitOfEverythingServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{})
 }
 
 // RegisterABitOfEverythingServiceHandlerClient registers the http handlers for service ABitOfEverythingService
@@ -3055,7 +3215,182 @@ func RegisterABitOfEverythingServiceHandler(ctx context.Context, mux *runtime.Se
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "ABitOfEverythingServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
 func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return RegisterABitOfEverythingServiceHandlerClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})
+}
+
+// RegisterABitOfEverythingServiceHandlerWithOptions is same as RegisterABitOfEverythingServiceHandler
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterABitOfEverythingServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {
+	mux.RegisterServiceConn("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService", conn, opts)
+	return RegisterABitOfEverythingServiceHandlerClientWithOptions(ctx, mux, NewABitOfEverythingServiceClient(conn), opts)
+}
+
+// RegisterABitOfEverythingServiceHandlerClientWithOptions is same as RegisterABitOfEverythingServiceHandlerClient
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterABitOfEverythingServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient, opts runtime.RegisterOptions) error {
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.Create", "") {
+		if err := RegisterABitOfEverythingService_CreateHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.CreateBody", "") {
+		if err := RegisterABitOfEverythingService_CreateBodyHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.CreateBook", "") {
+		if err := RegisterABitOfEverythingService_CreateBookHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.UpdateBook", "") {
+		if err := RegisterABitOfEverythingService_UpdateBookHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.Lookup", "") {
+		if err := RegisterABitOfEverythingService_LookupHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.Custom", "") {
+		if err := RegisterABitOfEverythingService_CustomHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.DoubleColon", "") {
+		if err := RegisterABitOfEverythingService_DoubleColonHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.Update", "") {
+		if err := RegisterABitOfEverythingService_UpdateHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.UpdateV2", "") {
+		if err := RegisterABitOfEverythingService_UpdateV2HandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.Delete", "") {
+		if err := RegisterABitOfEverythingService_DeleteHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.GetQuery", "") {
+		if err := RegisterABitOfEverythingService_GetQueryHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.GetRepeatedQuery", "") {
+		if err := RegisterABitOfEverythingService_GetRepeatedQueryHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.Echo", "") {
+		if err := RegisterABitOfEverythingService_EchoHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.DeepPathEcho", "") {
+		if err := RegisterABitOfEverythingService_DeepPathEchoHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.Timeout", "") {
+		if err := RegisterABitOfEverythingService_TimeoutHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.ErrorWithDetails", "") {
+		if err := RegisterABitOfEverythingService_ErrorWithDetailsHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.GetMessageWithBody", "") {
+		if err := RegisterABitOfEverythingService_GetMessageWithBodyHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.PostWithEmptyBody", "") {
+		if err := RegisterABitOfEverythingService_PostWithEmptyBodyHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.CheckGetQueryParams", "") {
+		if err := RegisterABitOfEverythingService_CheckGetQueryParamsHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.CheckNestedEnumGetQueryParams", "") {
+		if err := RegisterABitOfEverythingService_CheckNestedEnumGetQueryParamsHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.CheckPostQueryParams", "") {
+		if err := RegisterABitOfEverythingService_CheckPostQueryParamsHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.OverwriteRequestContentType", "") {
+		if err := RegisterABitOfEverythingService_OverwriteRequestContentTypeHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.OverwriteResponseContentType", "") {
+		if err := RegisterABitOfEverythingService_OverwriteResponseContentTypeHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.CheckExternalPathEnum", "") {
+		if err := RegisterABitOfEverythingService_CheckExternalPathEnumHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.CheckExternalNestedPathEnum", "") {
+		if err := RegisterABitOfEverythingService_CheckExternalNestedPathEnumHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.CheckStatus", "") {
+		if err := RegisterABitOfEverythingService_CheckStatusHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.Exists", "") {
+		if err := RegisterABitOfEverythingService_ExistsHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.CustomOptionsRequest", "") {
+		if err := RegisterABitOfEverythingService_CustomOptionsRequestHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.TraceRequest", "") {
+		if err := RegisterABitOfEverythingService_TraceRequestHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.PostOneofEnum", "") {
+		if err := RegisterABitOfEverythingService_PostOneofEnumHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService.PostRequiredMessageType", "") {
+		if err := RegisterABitOfEverythingService_PostRequiredMessageTypeHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterABitOfEverythingService_CreateHandlerClient registers the http handlers for method Create
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CreateHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_Create_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Create", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3064,15 +3399,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Create_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Create_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Create_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CreateBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CreateBodyHandlerClient registers the http handlers for method CreateBody
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CreateBodyHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_CreateBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3081,15 +3426,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_CreateBody_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_CreateBody_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CreateBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CreateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CreateBookHandlerClient registers the http handlers for method CreateBook
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CreateBookHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_CreateBook_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBook", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3098,15 +3453,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_CreateBook_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_CreateBook_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CreateBook_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_UpdateBookHandlerClient registers the http handlers for method UpdateBook
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_UpdateBookHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPatch, pattern_ABitOfEverythingService_UpdateBook_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateBook", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3115,15 +3480,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_UpdateBook_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_UpdateBook_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_UpdateBook_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Lookup_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_LookupHandlerClient registers the http handlers for method Lookup
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_LookupHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_Lookup_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Lookup", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3132,15 +3507,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Lookup_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Lookup_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Lookup_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Custom_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CustomHandlerClient registers the http handlers for method Custom
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CustomHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_Custom_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Custom", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3149,15 +3534,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Custom_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Custom_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Custom_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_DoubleColon_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_DoubleColonHandlerClient registers the http handlers for method DoubleColon
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_DoubleColonHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_DoubleColon_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DoubleColon", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3166,15 +3561,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_DoubleColon_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_DoubleColon_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_DoubleColon_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPut, pattern_ABitOfEverythingService_Update_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_UpdateHandlerClient registers the http handlers for method Update
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_UpdateHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPut, pattern_ABitOfEverythingService_Update_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Update", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3183,15 +3588,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Update_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Update_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Update_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPut, pattern_ABitOfEverythingService_UpdateV2_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_UpdateV2HandlerClient registers the http handlers for method UpdateV2
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_UpdateV2HandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPut, pattern_ABitOfEverythingService_UpdateV2_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3200,15 +3615,18 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_UpdateV2_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_UpdateV2_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_1, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3217,15 +3635,18 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_UpdateV2_1(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_UpdateV2_1(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_2, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3234,15 +3655,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_UpdateV2_2(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_UpdateV2_2(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodDelete, pattern_ABitOfEverythingService_Delete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_DeleteHandlerClient registers the http handlers for method Delete
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_DeleteHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodDelete, pattern_ABitOfEverythingService_Delete_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Delete", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3251,15 +3682,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Delete_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Delete_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Delete_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_GetQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_GetQueryHandlerClient registers the http handlers for method GetQuery
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_GetQueryHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_GetQuery_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetQuery", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3268,15 +3709,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_GetQuery_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_GetQuery_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_GetQuery_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_GetRepeatedQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_GetRepeatedQueryHandlerClient registers the http handlers for method GetRepeatedQuery
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_GetRepeatedQueryHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_GetRepeatedQuery_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetRepeatedQuery", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3285,15 +3736,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_GetRepeatedQuery_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_GetRepeatedQuery_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_GetRepeatedQuery_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_EchoHandlerClient registers the http handlers for method Echo
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_EchoHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3302,15 +3763,18 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Echo_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Echo_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Echo_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_Echo_1, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3319,15 +3783,18 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Echo_1(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Echo_1(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Echo_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_Echo_2, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3336,15 +3803,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Echo_2(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Echo_2(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Echo_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_DeepPathEcho_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_DeepPathEchoHandlerClient registers the http handlers for method DeepPathEcho
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_DeepPathEchoHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_DeepPathEcho_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DeepPathEcho", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3353,15 +3830,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_DeepPathEcho_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_DeepPathEcho_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_DeepPathEcho_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Timeout_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_TimeoutHandlerClient registers the http handlers for method Timeout
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_TimeoutHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_Timeout_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Timeout", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3370,15 +3857,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Timeout_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Timeout_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Timeout_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_ErrorWithDetails_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_ErrorWithDetailsHandlerClient registers the http handlers for method ErrorWithDetails
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_ErrorWithDetailsHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_ErrorWithDetails_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/ErrorWithDetails", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3387,15 +3884,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_ErrorWithDetails_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_ErrorWithDetails_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_ErrorWithDetails_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_GetMessageWithBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_GetMessageWithBodyHandlerClient registers the http handlers for method GetMessageWithBody
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_GetMessageWithBodyHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_GetMessageWithBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetMessageWithBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3404,15 +3911,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_GetMessageWithBody_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_GetMessageWithBody_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_GetMessageWithBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostWithEmptyBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_PostWithEmptyBodyHandlerClient registers the http handlers for method PostWithEmptyBody
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_PostWithEmptyBodyHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_PostWithEmptyBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostWithEmptyBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3421,15 +3938,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_PostWithEmptyBody_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_PostWithEmptyBody_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_PostWithEmptyBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CheckGetQueryParamsHandlerClient registers the http handlers for method CheckGetQueryParams
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CheckGetQueryParamsHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckGetQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckGetQueryParams", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3438,15 +3965,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_CheckGetQueryParams_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_CheckGetQueryParams_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckGetQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CheckNestedEnumGetQueryParamsHandlerClient registers the http handlers for method CheckNestedEnumGetQueryParams
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CheckNestedEnumGetQueryParamsHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckNestedEnumGetQueryParams", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3455,15 +3992,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CheckPostQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CheckPostQueryParamsHandlerClient registers the http handlers for method CheckPostQueryParams
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CheckPostQueryParamsHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_CheckPostQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckPostQueryParams", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3472,15 +4019,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_CheckPostQueryParams_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_CheckPostQueryParams_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckPostQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_OverwriteRequestContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_OverwriteRequestContentTypeHandlerClient registers the http handlers for method OverwriteRequestContentType
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_OverwriteRequestContentTypeHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_OverwriteRequestContentType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteRequestContentType", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3489,15 +4046,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_OverwriteRequestContentType_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_OverwriteRequestContentType_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_OverwriteRequestContentType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_OverwriteResponseContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_OverwriteResponseContentTypeHandlerClient registers the http handlers for method OverwriteResponseContentType
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_OverwriteResponseContentTypeHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_OverwriteResponseContentType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteResponseContentType", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3506,15 +4073,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_OverwriteResponseContentType_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_OverwriteResponseContentType_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_OverwriteResponseContentType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CheckExternalPathEnumHandlerClient registers the http handlers for method CheckExternalPathEnum
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CheckExternalPathEnumHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalPathEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalPathEnum", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3523,15 +4100,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_CheckExternalPathEnum_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_CheckExternalPathEnum_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckExternalPathEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CheckExternalNestedPathEnumHandlerClient registers the http handlers for method CheckExternalNestedPathEnum
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CheckExternalNestedPathEnumHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalNestedPathEnum", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3540,15 +4127,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_CheckExternalNestedPathEnum_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_CheckExternalNestedPathEnum_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckExternalNestedPathEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckStatus_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CheckStatusHandlerClient registers the http handlers for method CheckStatus
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CheckStatusHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_ABitOfEverythingService_CheckStatus_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckStatus", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3557,15 +4154,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_CheckStatus_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_CheckStatus_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CheckStatus_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodHead, pattern_ABitOfEverythingService_Exists_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_ExistsHandlerClient registers the http handlers for method Exists
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_ExistsHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodHead, pattern_ABitOfEverythingService_Exists_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Exists", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3574,15 +4181,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_Exists_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_Exists_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_Exists_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodOptions, pattern_ABitOfEverythingService_CustomOptionsRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_CustomOptionsRequestHandlerClient registers the http handlers for method CustomOptionsRequest
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_CustomOptionsRequestHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodOptions, pattern_ABitOfEverythingService_CustomOptionsRequest_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CustomOptionsRequest", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3591,15 +4208,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_CustomOptionsRequest_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_CustomOptionsRequest_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_CustomOptionsRequest_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodTrace, pattern_ABitOfEverythingService_TraceRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_TraceRequestHandlerClient registers the http handlers for method TraceRequest
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_TraceRequestHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodTrace, pattern_ABitOfEverythingService_TraceRequest_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/TraceRequest", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3608,15 +4235,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_TraceRequest_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_TraceRequest_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_TraceRequest_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostOneofEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_PostOneofEnumHandlerClient registers the http handlers for method PostOneofEnum
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_PostOneofEnumHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_PostOneofEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostOneofEnum", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3625,15 +4262,25 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_PostOneofEnum_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_PostOneofEnum_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ABitOfEverythingService_PostOneofEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostRequiredMessageType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterABitOfEverythingService_PostRequiredMessageTypeHandlerClient registers the http handlers for method PostRequiredMessageType
+// of service ABitOfEverythingService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ABitOfEverythingServiceClient".
+func RegisterABitOfEverythingService_PostRequiredMessageTypeHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ABitOfEverythingService_PostRequiredMessageType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostRequiredMessageType", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3642,8 +4289,11 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ABitOfEverythingService_PostRequiredMessageType_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ABitOfEverythingService_PostRequiredMessageType_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -3753,10 +4403,35 @@ func RegisterCamelCaseServiceNameHandlerFromEndpoint(ctx context.Context, mux *r
 	return RegisterCamelCaseServiceNameHandler(ctx, mux, conn)
 }
 
+// RegisterCamelCaseServiceNameHandlerFromEndpoints is same as RegisterCamelCaseServiceNameHandlerFromEndpoint but
+// balances the calls between the grpc servers at "endpoints" with runtime.NewEndpointsClient.
+func RegisterCamelCaseServiceNameHandlerFromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+		}()
+	}()
+	return RegisterCamelCaseServiceNameHandler(ctx, mux, conn)
+}
+
 // RegisterCamelCaseServiceNameHandler registers the http handlers for service CamelCaseServiceName to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
+// The handlers forward requests to the grpc endpoint over "conn", which also serves the gRPC-Web requests
+// to the service if "mux" has runtime.WithGRPCWeb.
 func RegisterCamelCaseServiceNameHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterCamelCaseServiceNameHandlerClient(ctx, mux, NewCamelCaseServiceNameClient(conn))
+	return RegisterCamelCaseServiceNameHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{})
 }
 
 // RegisterCamelCaseServiceNameHandlerClient registers the http handlers for service CamelCaseServiceName
@@ -3765,7 +4440,32 @@ func RegisterCamelCaseServiceNameHandler(ctx context.Context, mux *runtime.Serve
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "CamelCaseServiceNameClient" to call the correct interceptors. This client ignores the HTTP middlewares.
 func RegisterCamelCaseServiceNameHandlerClient(ctx context.Context, mux *runtime.ServeMux, client CamelCaseServiceNameClient) error {
-	mux.Handle(http.MethodGet, pattern_CamelCaseServiceName_Empty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return RegisterCamelCaseServiceNameHandlerClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})
+}
+
+// RegisterCamelCaseServiceNameHandlerWithOptions is same as RegisterCamelCaseServiceNameHandler
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterCamelCaseServiceNameHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {
+	mux.RegisterServiceConn("grpc.gateway.examples.internal.proto.examplepb.CamelCaseServiceName", conn, opts)
+	return RegisterCamelCaseServiceNameHandlerClientWithOptions(ctx, mux, NewCamelCaseServiceNameClient(conn), opts)
+}
+
+// RegisterCamelCaseServiceNameHandlerClientWithOptions is same as RegisterCamelCaseServiceNameHandlerClient
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterCamelCaseServiceNameHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client CamelCaseServiceNameClient, opts runtime.RegisterOptions) error {
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.CamelCaseServiceName.Empty", "") {
+		if err := RegisterCamelCaseServiceName_EmptyHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterCamelCaseServiceName_EmptyHandlerClient registers the http handlers for method Empty
+// of service CamelCaseServiceName to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "CamelCaseServiceNameClient".
+func RegisterCamelCaseServiceName_EmptyHandlerClient(ctx context.Context, mux *runtime.ServeMux, client CamelCaseServiceNameClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_CamelCaseServiceName_Empty_0, "/grpc.gateway.examples.internal.proto.examplepb.CamelCaseServiceName/Empty", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3774,8 +4474,11 @@ func RegisterCamelCaseServiceNameHandlerClient(ctx context.Context, mux *runtime
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_CamelCaseServiceName_Empty_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_CamelCaseServiceName_Empty_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -3817,10 +4520,35 @@ func RegisterSnakeEnumServiceHandlerFromEndpoint(ctx context.Context, mux *runti
 	return RegisterSnakeEnumServiceHandler(ctx, mux, conn)
 }
 
+// RegisterSnakeEnumServiceHandlerFromEndpoints is same as RegisterSnakeEnumServiceHandlerFromEndpoint but
+// balances the calls between the grpc servers at "endpoints" with runtime.NewEndpointsClient.
+func RegisterSnakeEnumServiceHandlerFromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+		}()
+	}()
+	return RegisterSnakeEnumServiceHandler(ctx, mux, conn)
+}
+
 // RegisterSnakeEnumServiceHandler registers the http handlers for service SnakeEnumService to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
+// The handlers forward requests to the grpc endpoint over "conn", which also serves the gRPC-Web requests
+// to the service if "mux" has runtime.WithGRPCWeb.
 func RegisterSnakeEnumServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterSnakeEnumServiceHandlerClient(ctx, mux, NewSnakeEnumServiceClient(conn))
+	return RegisterSnakeEnumServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{})
 }
 
 // RegisterSnakeEnumServiceHandlerClient registers the http handlers for service SnakeEnumService
@@ -3829,7 +4557,32 @@ func RegisterSnakeEnumServiceHandler(ctx context.Context, mux *runtime.ServeMux,
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "SnakeEnumServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
 func RegisterSnakeEnumServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client SnakeEnumServiceClient) error {
-	mux.Handle(http.MethodGet, pattern_SnakeEnumService_SnakeEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return RegisterSnakeEnumServiceHandlerClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})
+}
+
+// RegisterSnakeEnumServiceHandlerWithOptions is same as RegisterSnakeEnumServiceHandler
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterSnakeEnumServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {
+	mux.RegisterServiceConn("grpc.gateway.examples.internal.proto.examplepb.SnakeEnumService", conn, opts)
+	return RegisterSnakeEnumServiceHandlerClientWithOptions(ctx, mux, NewSnakeEnumServiceClient(conn), opts)
+}
+
+// RegisterSnakeEnumServiceHandlerClientWithOptions is same as RegisterSnakeEnumServiceHandlerClient
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterSnakeEnumServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client SnakeEnumServiceClient, opts runtime.RegisterOptions) error {
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.SnakeEnumService.SnakeEnum", "") {
+		if err := RegisterSnakeEnumService_SnakeEnumHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterSnakeEnumService_SnakeEnumHandlerClient registers the http handlers for method SnakeEnum
+// of service SnakeEnumService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "SnakeEnumServiceClient".
+func RegisterSnakeEnumService_SnakeEnumHandlerClient(ctx context.Context, mux *runtime.ServeMux, client SnakeEnumServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_SnakeEnumService_SnakeEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.SnakeEnumService/SnakeEnum", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3838,8 +4591,11 @@ func RegisterSnakeEnumServiceHandlerClient(ctx context.Context, mux *runtime.Ser
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_SnakeEnumService_SnakeEnum_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_SnakeEnumService_SnakeEnum_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
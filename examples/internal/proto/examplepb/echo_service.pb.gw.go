@@ -57,9 +57,10 @@ func request_EchoService_Echo_0(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -81,9 +82,10 @@ func local_request_EchoService_Echo_0(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -118,9 +120,10 @@ func request_EchoService_Echo_1(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_1); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_1); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -150,9 +153,10 @@ func local_request_EchoService_Echo_1(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_1); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_1); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -200,9 +204,10 @@ func request_EchoService_Echo_2(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_2); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_2); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -245,9 +250,10 @@ func local_request_EchoService_Echo_2(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_2); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_2); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -295,9 +301,10 @@ func request_EchoService_Echo_3(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_3); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_3); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -340,9 +347,10 @@ func local_request_EchoService_Echo_3(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_3); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_3); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -369,9 +377,10 @@ func request_EchoService_Echo_4(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_4); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_4); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -393,9 +402,10 @@ func local_request_EchoService_Echo_4(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_4); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_4); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -422,9 +432,10 @@ func request_EchoService_Echo_5(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_5); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_5); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -446,9 +457,10 @@ func local_request_EchoService_Echo_5(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_5); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_5); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -475,9 +487,10 @@ func request_EchoService_Echo_6(ctx context.Context, marshaler runtime.Marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_6); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_6); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -499,9 +512,10 @@ func local_request_EchoService_Echo_6(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_Echo_6); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_Echo_6); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -517,6 +531,7 @@ func request_EchoService_EchoBody_0(ctx context.Context, marshaler runtime.Marsh
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.EchoBody(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -529,6 +544,7 @@ func local_request_EchoService_EchoBody_0(ctx context.Context, marshaler runtime
 	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.EchoBody(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -563,9 +579,10 @@ func request_EchoService_EchoBody_1(ctx context.Context, marshaler runtime.Marsh
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_EchoBody_1); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_EchoBody_1); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.EchoBody(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -595,9 +612,10 @@ func local_request_EchoService_EchoBody_1(ctx context.Context, marshaler runtime
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_EchoBody_1); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_EchoBody_1); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.EchoBody(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -615,9 +633,10 @@ func request_EchoService_EchoDelete_0(ctx context.Context, marshaler runtime.Mar
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_EchoDelete_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_EchoDelete_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.EchoDelete(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -630,9 +649,10 @@ func local_request_EchoService_EchoDelete_0(ctx context.Context, marshaler runti
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_EchoDelete_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_EchoDelete_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.EchoDelete(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -648,7 +668,13 @@ func request_EchoService_EchoPatch_0(ctx context.Context, marshaler runtime.Mars
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Body); err != nil && !errors.Is(err, io.EOF) {
+	if runtime.IsJSONPatch(req) {
+		fieldMask, err := runtime.DecodeJSONPatch(newReader(), &protoReq.Body)
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.UpdateMask = fieldMask
+	} else if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Body); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -664,9 +690,10 @@ func request_EchoService_EchoPatch_0(ctx context.Context, marshaler runtime.Mars
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_EchoPatch_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_EchoPatch_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.EchoPatch(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -680,7 +707,13 @@ func local_request_EchoService_EchoPatch_0(ctx context.Context, marshaler runtim
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Body); err != nil && !errors.Is(err, io.EOF) {
+	if runtime.IsJSONPatch(req) {
+		fieldMask, err := runtime.DecodeJSONPatch(newReader(), &protoReq.Body)
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.UpdateMask = fieldMask
+	} else if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Body); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if protoReq.UpdateMask == nil || len(protoReq.UpdateMask.GetPaths()) == 0 {
@@ -693,9 +726,10 @@ func local_request_EchoService_EchoPatch_0(ctx context.Context, marshaler runtim
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_EchoPatch_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_EchoPatch_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.EchoPatch(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -713,9 +747,10 @@ func request_EchoService_EchoUnauthorized_0(ctx context.Context, marshaler runti
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_EchoUnauthorized_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_EchoUnauthorized_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.EchoUnauthorized(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -728,9 +763,10 @@ func local_request_EchoService_EchoUnauthorized_0(ctx context.Context, marshaler
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_EchoService_EchoUnauthorized_0); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_EchoService_EchoUnauthorized_0); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.EchoUnauthorized(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -741,7 +777,7 @@ func local_request_EchoService_EchoUnauthorized_0(ctx context.Context, marshaler
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterEchoServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
 func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server EchoServiceServer) error {
-	mux.Handle(http.MethodPost, pattern_EchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_EchoService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -755,13 +791,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_Echo_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_1, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -775,13 +812,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_Echo_1(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_2, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -795,13 +833,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_Echo_2(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_3, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -815,13 +854,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_Echo_3(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_3(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_4, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -835,13 +875,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_Echo_4(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_4(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_5, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -855,13 +896,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_Echo_5(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_5(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_6, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -875,13 +917,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_Echo_6(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_6(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_EchoService_EchoBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_EchoService_EchoBody_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -895,13 +938,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_EchoBody_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_EchoBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPut, pattern_EchoService_EchoBody_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPut, pattern_EchoService_EchoBody_1, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -915,13 +959,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_EchoBody_1(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_EchoBody_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodDelete, pattern_EchoService_EchoDelete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodDelete, pattern_EchoService_EchoDelete_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoDelete", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -935,13 +980,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_EchoDelete_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_EchoDelete_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPatch, pattern_EchoService_EchoPatch_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPatch, pattern_EchoService_EchoPatch_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoPatch", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -955,13 +1001,14 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_EchoPatch_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_EchoPatch_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_EchoUnauthorized_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_EchoUnauthorized_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoUnauthorized", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -975,6 +1022,7 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_EchoService_EchoUnauthorized_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -1009,10 +1057,35 @@ func RegisterEchoServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.Se
 	return RegisterEchoServiceHandler(ctx, mux, conn)
 }
 
+// RegisterEchoServiceHandlerFromEndpoints is same as RegisterEchoServiceHandlerFromEndpoint but
+// balances the calls between the grpc servers at "endpoints" with runtime.NewEndpointsClient.
+func RegisterEchoServiceHandlerFromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+		}()
+	}()
+	return RegisterEchoServiceHandler(ctx, mux, conn)
+}
+
 // RegisterEchoServiceHandler registers the http handlers for service EchoService to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
+// The handlers forward requests to the grpc endpoint over "conn", which also serves the gRPC-Web requests
+// to the service if "mux" has runtime.WithGRPCWeb.
 func RegisterEchoServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterEchoServiceHandlerClient(ctx, mux, NewEchoServiceClient(conn))
+	return RegisterEchoServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{})
 }
 
 // RegisterEchoServiceHandlerClient registers the http handlers for service EchoService
@@ -1021,7 +1094,52 @@ func RegisterEchoServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "EchoServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
 func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EchoServiceClient) error {
-	mux.Handle(http.MethodPost, pattern_EchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return RegisterEchoServiceHandlerClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})
+}
+
+// RegisterEchoServiceHandlerWithOptions is same as RegisterEchoServiceHandler
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterEchoServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {
+	mux.RegisterServiceConn("grpc.gateway.examples.internal.proto.examplepb.EchoService", conn, opts)
+	return RegisterEchoServiceHandlerClientWithOptions(ctx, mux, NewEchoServiceClient(conn), opts)
+}
+
+// RegisterEchoServiceHandlerClientWithOptions is same as RegisterEchoServiceHandlerClient
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterEchoServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client EchoServiceClient, opts runtime.RegisterOptions) error {
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.EchoService.Echo", "") {
+		if err := RegisterEchoService_EchoHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.EchoService.EchoBody", "") {
+		if err := RegisterEchoService_EchoBodyHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.EchoService.EchoDelete", "") {
+		if err := RegisterEchoService_EchoDeleteHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.EchoService.EchoPatch", "") {
+		if err := RegisterEchoService_EchoPatchHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.EchoService.EchoUnauthorized", "") {
+		if err := RegisterEchoService_EchoUnauthorizedHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterEchoService_EchoHandlerClient registers the http handlers for method Echo
+// of service EchoService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "EchoServiceClient".
+func RegisterEchoService_EchoHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EchoServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_EchoService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1030,15 +1148,18 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_Echo_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_Echo_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_1, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1047,15 +1168,18 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_Echo_1(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_Echo_1(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_2, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1064,15 +1188,18 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_Echo_2(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_Echo_2(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_3, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1081,15 +1208,18 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_Echo_3(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_Echo_3(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_3(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_4, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1098,15 +1228,18 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_Echo_4(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_Echo_4(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_4(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_5, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1115,15 +1248,18 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_Echo_5(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_Echo_5(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_5(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_Echo_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_Echo_6, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1132,15 +1268,25 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_Echo_6(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_Echo_6(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_Echo_6(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_EchoService_EchoBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterEchoService_EchoBodyHandlerClient registers the http handlers for method EchoBody
+// of service EchoService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "EchoServiceClient".
+func RegisterEchoService_EchoBodyHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EchoServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_EchoService_EchoBody_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1149,15 +1295,18 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_EchoBody_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_EchoBody_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_EchoBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPut, pattern_EchoService_EchoBody_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPut, pattern_EchoService_EchoBody_1, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoBody", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1166,15 +1315,25 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_EchoBody_1(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_EchoBody_1(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_EchoBody_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodDelete, pattern_EchoService_EchoDelete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterEchoService_EchoDeleteHandlerClient registers the http handlers for method EchoDelete
+// of service EchoService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "EchoServiceClient".
+func RegisterEchoService_EchoDeleteHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EchoServiceClient) error {
+	mux.HandleRPC(http.MethodDelete, pattern_EchoService_EchoDelete_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoDelete", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1183,15 +1342,25 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_EchoDelete_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_EchoDelete_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_EchoDelete_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPatch, pattern_EchoService_EchoPatch_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterEchoService_EchoPatchHandlerClient registers the http handlers for method EchoPatch
+// of service EchoService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "EchoServiceClient".
+func RegisterEchoService_EchoPatchHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EchoServiceClient) error {
+	mux.HandleRPC(http.MethodPatch, pattern_EchoService_EchoPatch_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoPatch", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1200,15 +1369,25 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_EchoPatch_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_EchoPatch_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_EchoService_EchoPatch_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodGet, pattern_EchoService_EchoUnauthorized_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterEchoService_EchoUnauthorizedHandlerClient registers the http handlers for method EchoUnauthorized
+// of service EchoService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "EchoServiceClient".
+func RegisterEchoService_EchoUnauthorizedHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EchoServiceClient) error {
+	mux.HandleRPC(http.MethodGet, pattern_EchoService_EchoUnauthorized_0, "/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoUnauthorized", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -1217,8 +1396,11 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EchoService_EchoUnauthorized_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EchoService_EchoUnauthorized_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
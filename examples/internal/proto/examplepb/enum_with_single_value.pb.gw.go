@@ -46,6 +46,7 @@ func request_EnumWithSingleValueService_Echo_0(ctx context.Context, marshaler ru
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Echo(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -58,6 +59,7 @@ func local_request_EnumWithSingleValueService_Echo_0(ctx context.Context, marsha
 	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Echo(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -68,7 +70,7 @@ func local_request_EnumWithSingleValueService_Echo_0(ctx context.Context, marsha
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterEnumWithSingleValueServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
 func RegisterEnumWithSingleValueServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server EnumWithSingleValueServiceServer) error {
-	mux.Handle(http.MethodPost, pattern_EnumWithSingleValueService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_EnumWithSingleValueService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.EnumWithSingleValueService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -82,6 +84,7 @@ func RegisterEnumWithSingleValueServiceHandlerServer(ctx context.Context, mux *r
 		resp, md, err := local_request_EnumWithSingleValueService_Echo_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -116,10 +119,35 @@ func RegisterEnumWithSingleValueServiceHandlerFromEndpoint(ctx context.Context,
 	return RegisterEnumWithSingleValueServiceHandler(ctx, mux, conn)
 }
 
+// RegisterEnumWithSingleValueServiceHandlerFromEndpoints is same as RegisterEnumWithSingleValueServiceHandlerFromEndpoint but
+// balances the calls between the grpc servers at "endpoints" with runtime.NewEndpointsClient.
+func RegisterEnumWithSingleValueServiceHandlerFromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+		}()
+	}()
+	return RegisterEnumWithSingleValueServiceHandler(ctx, mux, conn)
+}
+
 // RegisterEnumWithSingleValueServiceHandler registers the http handlers for service EnumWithSingleValueService to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
+// The handlers forward requests to the grpc endpoint over "conn", which also serves the gRPC-Web requests
+// to the service if "mux" has runtime.WithGRPCWeb.
 func RegisterEnumWithSingleValueServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterEnumWithSingleValueServiceHandlerClient(ctx, mux, NewEnumWithSingleValueServiceClient(conn))
+	return RegisterEnumWithSingleValueServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{})
 }
 
 // RegisterEnumWithSingleValueServiceHandlerClient registers the http handlers for service EnumWithSingleValueService
@@ -128,7 +156,32 @@ func RegisterEnumWithSingleValueServiceHandler(ctx context.Context, mux *runtime
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "EnumWithSingleValueServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
 func RegisterEnumWithSingleValueServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EnumWithSingleValueServiceClient) error {
-	mux.Handle(http.MethodPost, pattern_EnumWithSingleValueService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return RegisterEnumWithSingleValueServiceHandlerClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})
+}
+
+// RegisterEnumWithSingleValueServiceHandlerWithOptions is same as RegisterEnumWithSingleValueServiceHandler
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterEnumWithSingleValueServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {
+	mux.RegisterServiceConn("grpc.gateway.examples.internal.proto.examplepb.EnumWithSingleValueService", conn, opts)
+	return RegisterEnumWithSingleValueServiceHandlerClientWithOptions(ctx, mux, NewEnumWithSingleValueServiceClient(conn), opts)
+}
+
+// RegisterEnumWithSingleValueServiceHandlerClientWithOptions is same as RegisterEnumWithSingleValueServiceHandlerClient
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterEnumWithSingleValueServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client EnumWithSingleValueServiceClient, opts runtime.RegisterOptions) error {
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.EnumWithSingleValueService.Echo", "") {
+		if err := RegisterEnumWithSingleValueService_EchoHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterEnumWithSingleValueService_EchoHandlerClient registers the http handlers for method Echo
+// of service EnumWithSingleValueService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "EnumWithSingleValueServiceClient".
+func RegisterEnumWithSingleValueService_EchoHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EnumWithSingleValueServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_EnumWithSingleValueService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.EnumWithSingleValueService/Echo", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -137,8 +190,11 @@ func RegisterEnumWithSingleValueServiceHandlerClient(ctx context.Context, mux *r
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_EnumWithSingleValueService_Echo_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_EnumWithSingleValueService_Echo_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -44,6 +44,7 @@ func request_ExcessBodyService_NoBodyRpc_0(ctx context.Context, marshaler runtim
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.NoBodyRpc(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -53,6 +54,7 @@ func local_request_ExcessBodyService_NoBodyRpc_0(ctx context.Context, marshaler
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.NoBodyRpc(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -65,6 +67,7 @@ func request_ExcessBodyService_NoBodyServerStream_0(ctx context.Context, marshal
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	stream, err := client.NoBodyServerStream(ctx, &protoReq)
 	if err != nil {
 		return nil, metadata, err
@@ -88,6 +91,7 @@ func request_ExcessBodyService_WithBodyRpc_0(ctx context.Context, marshaler runt
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.WithBodyRpc(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -100,6 +104,7 @@ func local_request_ExcessBodyService_WithBodyRpc_0(ctx context.Context, marshale
 	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.WithBodyRpc(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -115,6 +120,7 @@ func request_ExcessBodyService_WithBodyServerStream_0(ctx context.Context, marsh
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	stream, err := client.WithBodyServerStream(ctx, &protoReq)
 	if err != nil {
 		return nil, metadata, err
@@ -133,7 +139,7 @@ func request_ExcessBodyService_WithBodyServerStream_0(ctx context.Context, marsh
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterExcessBodyServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
 func RegisterExcessBodyServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ExcessBodyServiceServer) error {
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_NoBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ExcessBodyService_NoBodyRpc_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyRpc", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -147,6 +153,7 @@ func RegisterExcessBodyServiceHandlerServer(ctx context.Context, mux *runtime.Se
 		resp, md, err := local_request_ExcessBodyService_NoBodyRpc_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -154,13 +161,13 @@ func RegisterExcessBodyServiceHandlerServer(ctx context.Context, mux *runtime.Se
 		forward_ExcessBodyService_NoBodyRpc_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
 
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_NoBodyServerStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ExcessBodyService_NoBodyServerStream_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyServerStream", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
 		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 		return
 	})
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_WithBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ExcessBodyService_WithBodyRpc_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyRpc", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -174,6 +181,7 @@ func RegisterExcessBodyServiceHandlerServer(ctx context.Context, mux *runtime.Se
 		resp, md, err := local_request_ExcessBodyService_WithBodyRpc_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -181,7 +189,7 @@ func RegisterExcessBodyServiceHandlerServer(ctx context.Context, mux *runtime.Se
 		forward_ExcessBodyService_WithBodyRpc_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
 
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_WithBodyServerStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_ExcessBodyService_WithBodyServerStream_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyServerStream", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
 		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
@@ -215,10 +223,35 @@ func RegisterExcessBodyServiceHandlerFromEndpoint(ctx context.Context, mux *runt
 	return RegisterExcessBodyServiceHandler(ctx, mux, conn)
 }
 
+// RegisterExcessBodyServiceHandlerFromEndpoints is same as RegisterExcessBodyServiceHandlerFromEndpoint but
+// balances the calls between the grpc servers at "endpoints" with runtime.NewEndpointsClient.
+func RegisterExcessBodyServiceHandlerFromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+		}()
+	}()
+	return RegisterExcessBodyServiceHandler(ctx, mux, conn)
+}
+
 // RegisterExcessBodyServiceHandler registers the http handlers for service ExcessBodyService to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
+// The handlers forward requests to the grpc endpoint over "conn", which also serves the gRPC-Web requests
+// to the service if "mux" has runtime.WithGRPCWeb.
 func RegisterExcessBodyServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterExcessBodyServiceHandlerClient(ctx, mux, NewExcessBodyServiceClient(conn))
+	return RegisterExcessBodyServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{})
 }
 
 // RegisterExcessBodyServiceHandlerClient registers the http handlers for service ExcessBodyService
@@ -227,7 +260,47 @@ func RegisterExcessBodyServiceHandler(ctx context.Context, mux *runtime.ServeMux
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "ExcessBodyServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
 func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExcessBodyServiceClient) error {
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_NoBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return RegisterExcessBodyServiceHandlerClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})
+}
+
+// RegisterExcessBodyServiceHandlerWithOptions is same as RegisterExcessBodyServiceHandler
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterExcessBodyServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {
+	mux.RegisterServiceConn("grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService", conn, opts)
+	return RegisterExcessBodyServiceHandlerClientWithOptions(ctx, mux, NewExcessBodyServiceClient(conn), opts)
+}
+
+// RegisterExcessBodyServiceHandlerClientWithOptions is same as RegisterExcessBodyServiceHandlerClient
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterExcessBodyServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client ExcessBodyServiceClient, opts runtime.RegisterOptions) error {
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService.NoBodyRpc", "") {
+		if err := RegisterExcessBodyService_NoBodyRpcHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService.NoBodyServerStream", "") {
+		if err := RegisterExcessBodyService_NoBodyServerStreamHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService.WithBodyRpc", "") {
+		if err := RegisterExcessBodyService_WithBodyRpcHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService.WithBodyServerStream", "") {
+		if err := RegisterExcessBodyService_WithBodyServerStreamHandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterExcessBodyService_NoBodyRpcHandlerClient registers the http handlers for method NoBodyRpc
+// of service ExcessBodyService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ExcessBodyServiceClient".
+func RegisterExcessBodyService_NoBodyRpcHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExcessBodyServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ExcessBodyService_NoBodyRpc_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyRpc", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -236,15 +309,25 @@ func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.Se
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ExcessBodyService_NoBodyRpc_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ExcessBodyService_NoBodyRpc_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ExcessBodyService_NoBodyRpc_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_NoBodyServerStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterExcessBodyService_NoBodyServerStreamHandlerClient registers the http handlers for method NoBodyServerStream
+// of service ExcessBodyService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ExcessBodyServiceClient".
+func RegisterExcessBodyService_NoBodyServerStreamHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExcessBodyServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ExcessBodyService_NoBodyServerStream_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyServerStream", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -255,13 +338,21 @@ func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.Se
 		}
 		resp, md, err := request_ExcessBodyService_NoBodyServerStream_0(annotatedContext, inboundMarshaler, client, req, pathParams)
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ExcessBodyService_NoBodyServerStream_0(annotatedContext, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_WithBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterExcessBodyService_WithBodyRpcHandlerClient registers the http handlers for method WithBodyRpc
+// of service ExcessBodyService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ExcessBodyServiceClient".
+func RegisterExcessBodyService_WithBodyRpcHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExcessBodyServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ExcessBodyService_WithBodyRpc_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyRpc", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -270,15 +361,25 @@ func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.Se
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_ExcessBodyService_WithBodyRpc_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ExcessBodyService_WithBodyRpc_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
 		}
 		forward_ExcessBodyService_WithBodyRpc_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_WithBodyServerStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return nil
+}
+
+// RegisterExcessBodyService_WithBodyServerStreamHandlerClient registers the http handlers for method WithBodyServerStream
+// of service ExcessBodyService to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "ExcessBodyServiceClient".
+func RegisterExcessBodyService_WithBodyServerStreamHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExcessBodyServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_ExcessBodyService_WithBodyServerStream_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyServerStream", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -289,6 +390,7 @@ func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.Se
 		}
 		resp, md, err := request_ExcessBodyService_WithBodyServerStream_0(annotatedContext, inboundMarshaler, client, req, pathParams)
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -46,6 +46,7 @@ func request_Foo2Service_Foo2_0(ctx context.Context, marshaler runtime.Marshaler
 	if req.Body != nil {
 		_, _ = io.Copy(io.Discard, req.Body)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.Foo2(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 }
@@ -58,6 +59,7 @@ func local_request_Foo2Service_Foo2_0(ctx context.Context, marshaler runtime.Mar
 	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.Foo2(ctx, &protoReq)
 	return msg, metadata, err
 }
@@ -68,7 +70,7 @@ func local_request_Foo2Service_Foo2_0(ctx context.Context, marshaler runtime.Mar
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterFoo2ServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
 func RegisterFoo2ServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server Foo2ServiceServer) error {
-	mux.Handle(http.MethodPost, pattern_Foo2Service_Foo2_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC(http.MethodPost, pattern_Foo2Service_Foo2_0, "/grpc.gateway.examples.internal.proto.examplepb.Foo2Service/Foo2", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -82,6 +84,7 @@ func RegisterFoo2ServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 		resp, md, err := local_request_Foo2Service_Foo2_0(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -116,10 +119,35 @@ func RegisterFoo2ServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.Se
 	return RegisterFoo2ServiceHandler(ctx, mux, conn)
 }
 
+// RegisterFoo2ServiceHandlerFromEndpoints is same as RegisterFoo2ServiceHandlerFromEndpoint but
+// balances the calls between the grpc servers at "endpoints" with runtime.NewEndpointsClient.
+func RegisterFoo2ServiceHandlerFromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+		}()
+	}()
+	return RegisterFoo2ServiceHandler(ctx, mux, conn)
+}
+
 // RegisterFoo2ServiceHandler registers the http handlers for service Foo2Service to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
+// The handlers forward requests to the grpc endpoint over "conn", which also serves the gRPC-Web requests
+// to the service if "mux" has runtime.WithGRPCWeb.
 func RegisterFoo2ServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterFoo2ServiceHandlerClient(ctx, mux, NewFoo2ServiceClient(conn))
+	return RegisterFoo2ServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{})
 }
 
 // RegisterFoo2ServiceHandlerClient registers the http handlers for service Foo2Service
@@ -128,7 +156,32 @@ func RegisterFoo2ServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "Foo2ServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
 func RegisterFoo2ServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client Foo2ServiceClient) error {
-	mux.Handle(http.MethodPost, pattern_Foo2Service_Foo2_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	return RegisterFoo2ServiceHandlerClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})
+}
+
+// RegisterFoo2ServiceHandlerWithOptions is same as RegisterFoo2ServiceHandler
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterFoo2ServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {
+	mux.RegisterServiceConn("grpc.gateway.examples.internal.proto.examplepb.Foo2Service", conn, opts)
+	return RegisterFoo2ServiceHandlerClientWithOptions(ctx, mux, NewFoo2ServiceClient(conn), opts)
+}
+
+// RegisterFoo2ServiceHandlerClientWithOptions is same as RegisterFoo2ServiceHandlerClient
+// but only registers the http handlers of the methods selected by "opts".
+func RegisterFoo2ServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client Foo2ServiceClient, opts runtime.RegisterOptions) error {
+	if opts.Includes("grpc.gateway.examples.internal.proto.examplepb.Foo2Service.Foo2", "") {
+		if err := RegisterFoo2Service_Foo2HandlerClient(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterFoo2Service_Foo2HandlerClient registers the http handlers for method Foo2
+// of service Foo2Service to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "Foo2ServiceClient".
+func RegisterFoo2Service_Foo2HandlerClient(ctx context.Context, mux *runtime.ServeMux, client Foo2ServiceClient) error {
+	mux.HandleRPC(http.MethodPost, pattern_Foo2Service_Foo2_0, "/grpc.gateway.examples.internal.proto.examplepb.Foo2Service/Foo2", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -137,8 +190,11 @@ func RegisterFoo2ServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := request_Foo2Service_Foo2_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_Foo2Service_Foo2_0(ctx, inboundMarshaler, client, req, pathParams)
+		})
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -0,0 +1,34 @@
+package apiconfig
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFromYAML parses a gRPC API Configuration in YAML. It is shared by the
+// generators and the dynamic gateway of runtime/dynamic.
+func LoadFromYAML(yamlFileContents []byte) (*GrpcAPIService, error) {
+	var yamlContents interface{}
+	if err := yaml.Unmarshal(yamlFileContents, &yamlContents); err != nil {
+		return nil, err
+	}
+
+	jsonContents, err := json.Marshal(yamlContents)
+	if err != nil {
+		return nil, err
+	}
+
+	// As our GrpcAPIService is incomplete, accept unknown fields.
+	unmarshaler := protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	}
+
+	serviceConfiguration := GrpcAPIService{}
+	if err := unmarshaler.Unmarshal(jsonContents, &serviceConfiguration); err != nil {
+		return nil, err
+	}
+
+	return &serviceConfiguration, nil
+}
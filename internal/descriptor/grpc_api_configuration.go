@@ -1,38 +1,19 @@
 package descriptor
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor/apiconfig"
-	"google.golang.org/protobuf/encoding/protojson"
-	"gopkg.in/yaml.v3"
 )
 
 func loadGrpcAPIServiceFromYAML(yamlFileContents []byte, yamlSourceLogName string) (*apiconfig.GrpcAPIService, error) {
-	var yamlContents interface{}
-	if err := yaml.Unmarshal(yamlFileContents, &yamlContents); err != nil {
-		return nil, fmt.Errorf("failed to parse gRPC API Configuration from YAML in %q: %w", yamlSourceLogName, err)
-	}
-
-	jsonContents, err := json.Marshal(yamlContents)
+	service, err := apiconfig.LoadFromYAML(yamlFileContents)
 	if err != nil {
-		return nil, err
-	}
-
-	// As our GrpcAPIService is incomplete, accept unknown fields.
-	unmarshaler := protojson.UnmarshalOptions{
-		DiscardUnknown: true,
-	}
-
-	serviceConfiguration := apiconfig.GrpcAPIService{}
-	if err := unmarshaler.Unmarshal(jsonContents, &serviceConfiguration); err != nil {
 		return nil, fmt.Errorf("failed to parse gRPC API Configuration from YAML in %q: %w", yamlSourceLogName, err)
 	}
-
-	return &serviceConfiguration, nil
+	return service, nil
 }
 
 func registerHTTPRulesFromGrpcAPIService(registry *Registry, service *apiconfig.GrpcAPIService, sourceLogName string) error {
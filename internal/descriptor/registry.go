@@ -139,6 +139,10 @@ type Registry struct {
 	// omitPackageDoc, if false, causes a package comment to be included in the generated code.
 	omitPackageDoc bool
 
+	// enforceFieldBehavior, if true, causes the generated handlers to enforce the
+	// google.api.field_behavior annotations of the request messages.
+	enforceFieldBehavior bool
+
 	// recursiveDepth sets the maximum depth of a field parameter
 	recursiveDepth int
 
@@ -181,6 +185,10 @@ type Registry struct {
 
 	// generateXGoType is a global generator option for generating x-go-type annotations
 	generateXGoType bool
+
+	// repeatedMessageQueryParams, if true, documents repeated message fields as query parameters
+	// using the indexed and JSON syntaxes supported by runtime.DefaultQueryParser.
+	repeatedMessageQueryParams bool
 }
 
 type repeatedFieldSeparator struct {
@@ -733,6 +741,18 @@ func (r *Registry) GetOmitPackageDoc() bool {
 	return r.omitPackageDoc
 }
 
+// SetEnforceFieldBehavior controls whether the generated handlers enforce the
+// google.api.field_behavior annotations of the request messages
+func (r *Registry) SetEnforceFieldBehavior(enforce bool) {
+	r.enforceFieldBehavior = enforce
+}
+
+// GetEnforceFieldBehavior returns whether the generated handlers enforce the
+// google.api.field_behavior annotations of the request messages
+func (r *Registry) GetEnforceFieldBehavior() bool {
+	return r.enforceFieldBehavior
+}
+
 // SetProto3OptionalNullable set proto3OptionalNullable
 func (r *Registry) SetProto3OptionalNullable(proto3OptionalNullable bool) {
 	r.proto3OptionalNullable = proto3OptionalNullable
@@ -930,3 +950,13 @@ func (r *Registry) SetGenerateXGoType(generateXGoType bool) {
 func (r *Registry) GetGenerateXGoType() bool {
 	return r.generateXGoType
 }
+
+// SetRepeatedMessageQueryParams sets repeatedMessageQueryParams
+func (r *Registry) SetRepeatedMessageQueryParams(enable bool) {
+	r.repeatedMessageQueryParams = enable
+}
+
+// GetRepeatedMessageQueryParams returns repeatedMessageQueryParams
+func (r *Registry) GetRepeatedMessageQueryParams() bool {
+	return r.repeatedMessageQueryParams
+}
@@ -135,6 +135,7 @@ func (g *generator) generate(file *descriptor.File) (string, error) {
 	}
 	if g.reg != nil {
 		params.OmitPackageDoc = g.reg.GetOmitPackageDoc()
+		params.EnforceFieldBehavior = g.reg.GetEnforceFieldBehavior()
 	}
 	return applyTemplate(params, g.reg)
 }
@@ -5,28 +5,33 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/casing"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/visibility"
 	"google.golang.org/grpc/grpclog"
+	"google.golang.org/protobuf/proto"
 )
 
 type param struct {
 	*descriptor.File
-	Imports            []descriptor.GoPackage
-	UseRequestContext  bool
-	RegisterFuncSuffix string
-	AllowPatchFeature  bool
-	OmitPackageDoc     bool
+	Imports              []descriptor.GoPackage
+	UseRequestContext    bool
+	RegisterFuncSuffix   string
+	AllowPatchFeature    bool
+	OmitPackageDoc       bool
+	EnforceFieldBehavior bool
 }
 
 type binding struct {
 	*descriptor.Binding
-	Registry          *descriptor.Registry
-	AllowPatchFeature bool
+	Registry             *descriptor.Registry
+	AllowPatchFeature    bool
+	EnforceFieldBehavior bool
 }
 
 // GetBodyFieldPath returns the binding body's field path.
@@ -143,6 +148,18 @@ func (f queryParamFilter) String() string {
 	return fmt.Sprintf("&utilities.DoubleArray{Encoding: map[string]int{%s}, Base: %#v, Check: %#v}", e, f.Base, f.Check)
 }
 
+// visibilityRestrictions returns the quoted google.api.VisibilityRule
+// restrictions of the method m, to be given to runtime.RegisterOptions.Includes:
+// the restriction of its service, if it has one, and its own.
+func visibilityRestrictions(svc *descriptor.Service, m *descriptor.Method) string {
+	methodRule, _ := proto.GetExtension(m.GetOptions(), visibility.E_MethodVisibility).(*visibility.VisibilityRule)
+	restriction := strconv.Quote(methodRule.GetRestriction())
+	if serviceRule, _ := proto.GetExtension(svc.GetOptions(), visibility.E_ApiVisibility).(*visibility.VisibilityRule); serviceRule.GetRestriction() != "" {
+		return strconv.Quote(serviceRule.GetRestriction()) + ", " + restriction
+	}
+	return restriction
+}
+
 type trailerParams struct {
 	Services           []*descriptor.Service
 	UseRequestContext  bool
@@ -179,18 +196,20 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 
 				methodWithBindingsSeen = true
 				if err := handlerTemplate.Execute(w, binding{
-					Binding:           b,
-					Registry:          reg,
-					AllowPatchFeature: p.AllowPatchFeature,
+					Binding:              b,
+					Registry:             reg,
+					AllowPatchFeature:    p.AllowPatchFeature,
+					EnforceFieldBehavior: p.EnforceFieldBehavior,
 				}); err != nil {
 					return "", err
 				}
 
 				// Local
 				if err := localHandlerTemplate.Execute(w, binding{
-					Binding:           b,
-					Registry:          reg,
-					AllowPatchFeature: p.AllowPatchFeature,
+					Binding:              b,
+					Registry:             reg,
+					AllowPatchFeature:    p.AllowPatchFeature,
+					EnforceFieldBehavior: p.EnforceFieldBehavior,
 				}); err != nil {
 					return "", err
 				}
@@ -282,6 +301,7 @@ func request_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}(ct
 	_ = template.Must(handlerTemplate.New("client-streaming-request-func").Parse(`
 {{ template "request-func-signature" . }} {
 	var metadata runtime.ServerMetadata
+	runtime.ReportUpstreamBegin(ctx)
 	stream, err := client.{{ .Method.GetName }}(ctx)
 	if err != nil {
 		grpclog.Errorf("Failed to start streaming: %v", err)
@@ -331,6 +351,7 @@ func request_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}(ct
 		"toHTTPMethod": func(method string) string {
 			return httpMethods[method]
 		},
+		"visibilityRestrictions": visibilityRestrictions,
 	}
 
 	_ = template.Must(handlerTemplate.New("client-rpc-request-func").Funcs(funcMap).Parse(`
@@ -373,7 +394,13 @@ var filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }} = {{
 	}
 	{{- end }}
 	{{- if $isFieldMask }}
-	if err := marshaler.NewDecoder(newReader()).Decode(&{{ .Body.AssignableExpr "protoReq" .Method.Service.File.GoPkg.Path }}); err != nil && !errors.Is(err, io.EOF) {
+	if runtime.IsJSONPatch(req) {
+		fieldMask, err := runtime.DecodeJSONPatch(newReader(), &{{ .Body.AssignableExpr "protoReq" .Method.Service.File.GoPkg.Path }})
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.{{ .FieldMaskField }} = fieldMask
+	} else if err := marshaler.NewDecoder(newReader()).Decode(&{{ .Body.AssignableExpr "protoReq" .Method.Service.File.GoPkg.Path }}); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -441,11 +468,17 @@ var filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }} = {{
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 {{- end }}
+{{- if .EnforceFieldBehavior }}
+	if err := runtime.EnforceFieldBehavior(ctx, req, &protoReq, {{ if .Body }}{{ .GetBodyFieldPath | printf "%q" }}{{ else }}""{{ end }}); err != nil {
+		return nil, metadata, err
+	}
+{{- end }}
 {{- if .Method.GetServerStreaming }}
+	runtime.ReportUpstreamBegin(ctx)
 	stream, err := client.{{ .Method.GetName }}(ctx, &protoReq)
 	if err != nil {
 		return nil, metadata, err
@@ -457,6 +490,7 @@ var filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }} = {{
 	metadata.HeaderMD = header
 	return stream, metadata, nil
 {{- else }}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := client.{{ .Method.GetName }}(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return msg, metadata, err
 {{- end }}
@@ -465,6 +499,7 @@ var filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }} = {{
 	_ = template.Must(handlerTemplate.New("bidi-streaming-request-func").Parse(`
 {{ template "request-func-signature" . }} {
 	var metadata runtime.ServerMetadata
+	runtime.ReportUpstreamBegin(ctx)
 	stream, err := client.{{ .Method.GetName }}(ctx)
 	if err != nil {
 		grpclog.Errorf("Failed to start streaming: %v", err)
@@ -556,7 +591,13 @@ func local_request_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index
 	}
 	{{- end }}
 	{{- if $isFieldMask }}
-	if err := marshaler.NewDecoder(newReader()).Decode(&{{ .Body.AssignableExpr "protoReq" .Method.Service.File.GoPkg.Path }}); err != nil && !errors.Is(err, io.EOF)  {
+	if runtime.IsJSONPatch(req) {
+		fieldMask, err := runtime.DecodeJSONPatch(newReader(), &{{ .Body.AssignableExpr "protoReq" .Method.Service.File.GoPkg.Path }})
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.{{ .FieldMaskField }} = fieldMask
+	} else if err := marshaler.NewDecoder(newReader()).Decode(&{{ .Body.AssignableExpr "protoReq" .Method.Service.File.GoPkg.Path }}); err != nil && !errors.Is(err, io.EOF)  {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if protoReq.{{ .FieldMaskField }} == nil || len(protoReq.{{ .FieldMaskField }}.GetPaths()) == 0 {
@@ -617,13 +658,19 @@ func local_request_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}); err != nil {
+	if err := runtime.PopulateQueryParametersContext(req.Context(), &protoReq, req.Form, filter_{{ .Method.Service.GetName }}_{{ .Method.GetName }}_{{ .Index }}); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 {{- end}}
+{{- if .EnforceFieldBehavior }}
+	if err := runtime.EnforceFieldBehavior(ctx, req, &protoReq, {{ if .Body }}{{ .GetBodyFieldPath | printf "%q" }}{{ else }}""{{ end }}); err != nil {
+		return nil, metadata, err
+	}
+{{- end }}
 {{- if .Method.GetServerStreaming }}
 	// TODO
 {{- else}}
+	runtime.ReportUpstreamBegin(ctx)
 	msg, err := server.{{ .Method.GetName }}(ctx, &protoReq)
 	return msg, metadata, err
 {{- end}}
@@ -641,14 +688,14 @@ func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}Server(ctx context.Cont
 	{{- range $m := $svc.Methods }}
 	{{- range $b := $m.Bindings }}
 	{{- if or $m.GetClientStreaming $m.GetServerStreaming }}
-	mux.Handle({{ $b.HTTPMethod | toHTTPMethod }}, pattern_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC({{ $b.HTTPMethod | toHTTPMethod }}, pattern_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}, "/{{ $svc.File.GetPackage }}.{{ $svc.GetName }}/{{ $m.GetName }}", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
 		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 		return
 	})
 	{{- else -}}
-	mux.Handle({{ $b.HTTPMethod | toHTTPMethod}}, pattern_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC({{ $b.HTTPMethod | toHTTPMethod}}, pattern_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}, "/{{ $svc.File.GetPackage }}.{{ $svc.GetName }}/{{ $m.GetName }}", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 	{{- if $UseRequestContext }}
 		ctx, cancel := context.WithCancel(req.Context())
 	{{- else -}}
@@ -670,6 +717,7 @@ func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}Server(ctx context.Cont
 		resp, md, err := local_request_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}(annotatedContext, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -714,10 +762,35 @@ func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}FromEndpoint(ctx contex
 	return Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}(ctx, mux, conn)
 }
 
+// Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}FromEndpoints is same as Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}FromEndpoint but
+// balances the calls between the grpc servers at "endpoints" with runtime.NewEndpointsClient.
+func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}FromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Errorf("Failed to close conn to %v: %v", endpoints, cerr)
+			}
+		}()
+	}()
+	return Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}(ctx, mux, conn)
+}
+
 // Register{{ $svc.GetName}}{{ $.RegisterFuncSuffix}} registers the http handlers for service {{ $svc.GetName }} to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
+// The handlers forward requests to the grpc endpoint over "conn", which also serves the gRPC-Web requests
+// to the service if "mux" has runtime.WithGRPCWeb.
 func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}Client(ctx, mux, {{ $svc.ClientConstructorName }}(conn))
+	return Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}WithOptions(ctx, mux, conn, runtime.RegisterOptions{})
 }
 
 // Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}Client registers the http handlers for service {{ $svc.GetName }}
@@ -726,9 +799,38 @@ func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}(ctx context.Context, m
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "{{ $svc.InstanceName }}Client" to call the correct interceptors. This client ignores the HTTP middlewares.
 func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}Client(ctx context.Context, mux *runtime.ServeMux, client {{ $svc.InstanceName }}Client) error {
+	return Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}ClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})
+}
+
+// Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}WithOptions is same as Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}
+// but only registers the http handlers of the methods selected by "opts".
+func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}WithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {
+	mux.RegisterServiceConn("{{ $svc.File.GetPackage }}.{{ $svc.GetName }}", conn, opts)
+	return Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}ClientWithOptions(ctx, mux, {{ $svc.ClientConstructorName }}(conn), opts)
+}
+
+// Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}ClientWithOptions is same as Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}Client
+// but only registers the http handlers of the methods selected by "opts".
+func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}ClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client {{ $svc.InstanceName }}Client, opts runtime.RegisterOptions) error {
 	{{- range $m := $svc.Methods }}
+	{{- if $m.Bindings }}
+	if opts.Includes("{{ $svc.File.GetPackage }}.{{ $svc.GetName }}.{{ $m.GetName }}", {{ visibilityRestrictions $svc $m }}) {
+		if err := Register{{ $svc.GetName }}_{{ $m.GetName }}{{ $.RegisterFuncSuffix }}Client(ctx, mux, client); err != nil {
+			return err
+		}
+	}
+	{{- end }}
+	{{- end }}
+	return nil
+}
+{{ range $m := $svc.Methods }}
+{{- if $m.Bindings }}
+// Register{{ $svc.GetName }}_{{ $m.GetName }}{{ $.RegisterFuncSuffix }}Client registers the http handlers for method {{ $m.GetName }}
+// of service {{ $svc.GetName }} to "mux". The handlers forward requests to the grpc endpoint over the given implementation
+// of "{{ $svc.InstanceName }}Client".
+func Register{{ $svc.GetName }}_{{ $m.GetName }}{{ $.RegisterFuncSuffix }}Client(ctx context.Context, mux *runtime.ServeMux, client {{ $svc.InstanceName }}Client) error {
 	{{- range $b := $m.Bindings }}
-	mux.Handle({{ $b.HTTPMethod | toHTTPMethod }}, pattern_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleRPC({{ $b.HTTPMethod | toHTTPMethod }}, pattern_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}, "/{{ $svc.File.GetPackage }}.{{ $svc.GetName }}/{{ $m.GetName }}", func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 	{{- if $UseRequestContext }}
 		ctx, cancel := context.WithCancel(req.Context())
 	{{- else -}}
@@ -745,8 +847,15 @@ func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}Client(ctx context.Cont
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
+		{{- if or $m.GetClientStreaming $m.GetServerStreaming }}
 		resp, md, err := request_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}(annotatedContext, inboundMarshaler, client, req, pathParams)
+		{{- else }}
+		resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_{{ $svc.GetName }}_{{ $m.GetName }}_{{ $b.Index }}(ctx, inboundMarshaler, client, req, pathParams)
+		})
+		{{- end }}
 		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
 		if err != nil {
 			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
 			return
@@ -769,9 +878,10 @@ func Register{{ $svc.GetName }}{{ $.RegisterFuncSuffix }}Client(ctx context.Cont
 		{{- end }}
 	})
 	{{- end }}
-	{{- end }}
 	return nil
 }
+{{ end }}
+{{- end }}
 
 {{range $m := $svc.Methods}}
 {{range $b := $m.Bindings}}
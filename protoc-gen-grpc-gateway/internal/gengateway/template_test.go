@@ -6,6 +6,7 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/httprule"
+	"google.golang.org/genproto/googleapis/api/visibility"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
@@ -96,7 +97,7 @@ func TestApplyTemplateHeader(t *testing.T) {
 	if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
-	if want := `mux.Handle(http.MethodGet,`; !strings.Contains(got, want) {
+	if want := `mux.HandleRPC(http.MethodGet, pattern_ExampleService_Example_0, "/example.ExampleService/Example",`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
 }
@@ -257,16 +258,30 @@ func TestApplyTemplateRequestWithoutClientStreaming(t *testing.T) {
 		if want := `func RegisterExampleServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
+		if want := `func RegisterExampleServiceHandlerFromEndpoints(ctx context.Context, mux *runtime.ServeMux, endpoints []string, opts []runtime.EndpointsOption) (err error) {`; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
 		if want := `pattern_ExampleService_Echo_0 = runtime.MustPattern(runtime.NewPattern(1, []int{0, 0}, []string(nil), ""))`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 		if want := `annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/example.ExampleService/Echo", runtime.WithHTTPPathPattern("/v1"))`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
+		if want := `runtime.ReportUpstreamBegin(ctx)`; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+		if want := `runtime.ReportUpstreamEnd(annotatedContext, err)`; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+		if want := `resp, md, err := runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			return request_ExampleService_Echo_0(ctx, inboundMarshaler, client, req, pathParams)
+		})`; strings.Contains(got, want) == spec.serverStreaming {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s only for unary methods", file, got, want)
+		}
 		if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `mux.Handle(http.MethodPost,`; !strings.Contains(got, want) {
+		if want := `mux.HandleRPC(http.MethodPost,`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 	}
@@ -424,7 +439,7 @@ func TestApplyTemplateRequestWithClientStreaming(t *testing.T) {
 		if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `mux.Handle(http.MethodPost,`; !strings.Contains(got, want) {
+		if want := `mux.HandleRPC(http.MethodPost,`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 	}
@@ -606,7 +621,7 @@ func TestApplyTemplateInProcess(t *testing.T) {
 		if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `mux.Handle(http.MethodPost,`; !strings.Contains(got, want) {
+		if want := `mux.HandleRPC(http.MethodPost,`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 	}
@@ -697,12 +712,99 @@ func TestAllowPatchFeature(t *testing.T) {
 		if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `mux.Handle(http.MethodPatch,`; !strings.Contains(got, want) {
+		if want := `mux.HandleRPC(http.MethodPatch,`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 	}
 }
 
+func TestEnforceFieldBehavior(t *testing.T) {
+	fieldDesc := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("abe"),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(".example.ExampleMessage"),
+		Number:   proto.Int32(1),
+	}
+	msgdesc := &descriptorpb.DescriptorProto{
+		Name:  proto.String("ExampleMessage"),
+		Field: []*descriptorpb.FieldDescriptorProto{fieldDesc},
+	}
+	meth := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String("Example"),
+		InputType:  proto.String("ExampleMessage"),
+		OutputType: proto.String("ExampleMessage"),
+	}
+	svc := &descriptorpb.ServiceDescriptorProto{
+		Name:   proto.String("ExampleService"),
+		Method: []*descriptorpb.MethodDescriptorProto{meth},
+	}
+	msg := &descriptor.Message{
+		DescriptorProto: msgdesc,
+	}
+	field := &descriptor.Field{
+		Message:              msg,
+		FieldDescriptorProto: fieldDesc,
+	}
+	msg.Fields = append(msg.Fields, field)
+	file := descriptor.File{
+		FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+			Name:        proto.String("example.proto"),
+			Package:     proto.String("example"),
+			MessageType: []*descriptorpb.DescriptorProto{msgdesc},
+			Service:     []*descriptorpb.ServiceDescriptorProto{svc},
+		},
+		GoPkg: descriptor.GoPackage{
+			Path: "example.com/path/to/example/example.pb",
+			Name: "example_pb",
+		},
+		Messages: []*descriptor.Message{msg},
+		Services: []*descriptor.Service{
+			{
+				ServiceDescriptorProto: svc,
+				Methods: []*descriptor.Method{
+					{
+						MethodDescriptorProto: meth,
+						RequestType:           msg,
+						ResponseType:          msg,
+						Bindings: []*descriptor.Binding{
+							{
+								HTTPMethod: "POST",
+								Body: &descriptor.Body{FieldPath: descriptor.FieldPath{descriptor.FieldPathComponent{
+									Name:   "abe",
+									Target: msg.Fields[0],
+								}}},
+							},
+							{
+								HTTPMethod: "GET",
+								Index:      1,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, enforceFieldBehavior := range []bool{true, false} {
+		got, err := applyTemplate(param{File: crossLinkFixture(&file), RegisterFuncSuffix: "Handler", EnforceFieldBehavior: enforceFieldBehavior}, descriptor.NewRegistry())
+		if err != nil {
+			t.Errorf("applyTemplate(%#v) failed with %v; want success", file, err)
+			return
+		}
+		for want, count := range map[string]int{
+			`if err := runtime.EnforceFieldBehavior(ctx, req, &protoReq, "abe"); err != nil {`: 2,
+			`if err := runtime.EnforceFieldBehavior(ctx, req, &protoReq, ""); err != nil {`:    2,
+		} {
+			if !enforceFieldBehavior {
+				count = 0
+			}
+			if got := strings.Count(got, want); got != count {
+				t.Errorf("applyTemplate(%#v) contains %d times %s; want %d", file, got, want, count)
+			}
+		}
+	}
+}
+
 func TestIdentifierCapitalization(t *testing.T) {
 	msgdesc1 := &descriptorpb.DescriptorProto{
 		Name: proto.String("Exam_pleRequest"),
@@ -801,10 +903,10 @@ func TestIdentifierCapitalization(t *testing.T) {
 	if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
-	if want := `mux.Handle(http.MethodGet,`; !strings.Contains(got, want) {
+	if want := `mux.HandleRPC(http.MethodGet,`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
-	if want := `mux.Handle(http.MethodPost,`; !strings.Contains(got, want) {
+	if want := `mux.HandleRPC(http.MethodPost,`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
 }
@@ -969,3 +1071,127 @@ func TestDuplicatePathsInDifferentService(t *testing.T) {
 		return
 	}
 }
+
+func TestApplyTemplateRegisterWithOptions(t *testing.T) {
+	msgdesc := &descriptorpb.DescriptorProto{
+		Name: proto.String("ExampleMessage"),
+	}
+	publicMeth := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String("Public"),
+		InputType:  proto.String("ExampleMessage"),
+		OutputType: proto.String("ExampleMessage"),
+	}
+	internalMeth := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String("Internal"),
+		InputType:  proto.String("ExampleMessage"),
+		OutputType: proto.String("ExampleMessage"),
+		Options:    &descriptorpb.MethodOptions{},
+	}
+	proto.SetExtension(internalMeth.Options, visibility.E_MethodVisibility, &visibility.VisibilityRule{Restriction: "INTERNAL,PREVIEW"})
+	unboundMeth := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String("Unbound"),
+		InputType:  proto.String("ExampleMessage"),
+		OutputType: proto.String("ExampleMessage"),
+	}
+	svc := &descriptorpb.ServiceDescriptorProto{
+		Name:   proto.String("ExampleService"),
+		Method: []*descriptorpb.MethodDescriptorProto{publicMeth, internalMeth, unboundMeth},
+	}
+	msg := &descriptor.Message{
+		DescriptorProto: msgdesc,
+	}
+	file := descriptor.File{
+		FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+			Name:        proto.String("example.proto"),
+			Package:     proto.String("example"),
+			MessageType: []*descriptorpb.DescriptorProto{msgdesc},
+			Service:     []*descriptorpb.ServiceDescriptorProto{svc},
+		},
+		GoPkg: descriptor.GoPackage{
+			Path: "example.com/path/to/example/example.pb",
+			Name: "example_pb",
+		},
+		Messages: []*descriptor.Message{msg},
+		Services: []*descriptor.Service{
+			{
+				ServiceDescriptorProto: svc,
+				Methods: []*descriptor.Method{
+					{
+						MethodDescriptorProto: publicMeth,
+						RequestType:           msg,
+						ResponseType:          msg,
+						Bindings: []*descriptor.Binding{
+							{
+								HTTPMethod: "GET",
+								PathTmpl: httprule.Template{
+									Version:  1,
+									OpCodes:  []int{0, 0},
+									Template: "/v1/public",
+								},
+							},
+						},
+					},
+					{
+						MethodDescriptorProto: internalMeth,
+						RequestType:           msg,
+						ResponseType:          msg,
+						Bindings: []*descriptor.Binding{
+							{
+								HTTPMethod: "GET",
+								PathTmpl: httprule.Template{
+									Version:  1,
+									OpCodes:  []int{0, 0},
+									Template: "/v1/internal",
+								},
+							},
+						},
+					},
+					{
+						MethodDescriptorProto: unboundMeth,
+						RequestType:           msg,
+						ResponseType:          msg,
+					},
+				},
+			},
+		},
+	}
+	got, err := applyTemplate(param{File: crossLinkFixture(&file), RegisterFuncSuffix: "Handler"}, descriptor.NewRegistry())
+	if err != nil {
+		t.Errorf("applyTemplate(%#v) failed with %v; want success", file, err)
+		return
+	}
+	for _, want := range []string{
+		`return RegisterExampleServiceHandlerClientWithOptions(ctx, mux, client, runtime.RegisterOptions{})`,
+		`func RegisterExampleServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts runtime.RegisterOptions) error {`,
+		`return RegisterExampleServiceHandlerWithOptions(ctx, mux, conn, runtime.RegisterOptions{})`,
+		`mux.RegisterServiceConn("example.ExampleService", conn, opts)`,
+		`if opts.Includes("example.ExampleService.Public", "") {`,
+		`if opts.Includes("example.ExampleService.Internal", "INTERNAL,PREVIEW") {`,
+		`func RegisterExampleService_PublicHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExampleServiceClient) error {`,
+		`func RegisterExampleService_InternalHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExampleServiceClient) error {`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+	}
+	if notWant := `RegisterExampleService_UnboundHandlerClient`; strings.Contains(got, notWant) {
+		t.Errorf("applyTemplate(%#v) = %s; want to _not_ contain %s", file, got, notWant)
+	}
+
+	// The restriction of the service applies to its methods too.
+	svc.Options = &descriptorpb.ServiceOptions{}
+	proto.SetExtension(svc.Options, visibility.E_ApiVisibility, &visibility.VisibilityRule{Restriction: "INTERNAL"})
+	got, err = applyTemplate(param{File: crossLinkFixture(&file), RegisterFuncSuffix: "Handler"}, descriptor.NewRegistry())
+	if err != nil {
+		t.Errorf("applyTemplate(%#v) failed with %v; want success", file, err)
+		return
+	}
+	for _, want := range []string{
+		`if opts.Includes("example.ExampleService.Public", "INTERNAL", "") {`,
+		`if opts.Includes("example.ExampleService.Internal", "INTERNAL", "INTERNAL,PREVIEW") {`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+	}
+}
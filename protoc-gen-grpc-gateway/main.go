@@ -36,6 +36,7 @@ var (
 	versionFlag                = flag.Bool("version", false, "print the current version")
 	warnOnUnboundMethods       = flag.Bool("warn_on_unbound_methods", false, "emit a warning message if an RPC method has no HttpRule annotation")
 	generateUnboundMethods     = flag.Bool("generate_unbound_methods", false, "generate proxy methods even for RPC methods that have no HttpRule annotation")
+	enforceFieldBehavior       = flag.Bool("enforce_field_behavior", false, "if true, the generated handlers enforce the google.api.field_behavior annotations of the request messages when the ServeMux is given runtime.WithFieldBehavior")
 
 	_ = flag.Bool("logtostderr", false, "Legacy glog compatibility. This flag is a no-op, you can safely remove it")
 )
@@ -143,6 +144,7 @@ func applyFlags(reg *descriptor.Registry) error {
 	})
 
 	reg.SetOmitPackageDoc(*omitPackageDoc)
+	reg.SetEnforceFieldBehavior(*enforceFieldBehavior)
 	reg.SetWarnOnUnboundMethods(*warnOnUnboundMethods)
 	reg.SetGenerateUnboundMethods(*generateUnboundMethods)
 	return reg.SetRepeatedPathParamSeparator(*repeatedPathParamSeparator)
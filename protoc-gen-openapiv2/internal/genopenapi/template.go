@@ -322,7 +322,10 @@ func nestedQueryParams(message *descriptor.Message, field *descriptor.Field, pre
 			}
 		}
 		if items != nil && (items.Type == "" || items.Type == "object") && !isEnum {
-			return nil, nil // TODO: currently, mapping object in query parameter is not supported
+			if !reg.GetRepeatedMessageQueryParams() {
+				return nil, nil // TODO: currently, mapping object in query parameter is not supported
+			}
+			return []openapiParameterObject{repeatedMessageQueryParam(prefix+reg.FieldName(field), mergeDescription(schema))}, nil
 		}
 		desc := mergeDescription(schema)
 
@@ -429,6 +432,29 @@ func nestedQueryParams(message *descriptor.Message, field *descriptor.Field, pre
 	return params, nil
 }
 
+// repeatedMessageQueryParam documents a repeated message field bound from the query string.
+// A query parameter cannot carry an object, so the parameter is described as an array of strings
+// and the description explains the syntaxes accepted by runtime.DefaultQueryParser.
+func repeatedMessageQueryParam(name, desc string) openapiParameterObject {
+	syntax := fmt.Sprintf("Each value is a JSON-encoded element, as in `%[1]s={\"field\":\"value\"}`. "+
+		"Fields of an element can also be set with indexed keys, as in `%[1]s[0].field=value` or `%[1]s[0][field]=value`.", name)
+	if desc != "" {
+		desc += "\n\n"
+	}
+	return openapiParameterObject{
+		Name:        name,
+		Description: desc + syntax,
+		In:          "query",
+		Type:        "array",
+		Items: &openapiItemsObject{
+			schemaCore: schemaCore{
+				Type: "string",
+			},
+		},
+		CollectionFormat: "multi",
+	}
+}
+
 func getMapParamKey(t descriptorpb.FieldDescriptorProto_Type) (string, error) {
 	tType, f, ok := primitiveSchema(t)
 	if !ok || f == "byte" || f == "float" || f == "double" {
@@ -672,6 +672,126 @@ func TestMessageToQueryParameters(t *testing.T) {
 	}
 }
 
+func TestMessageToQueryParametersRepeatedMessage(t *testing.T) {
+	type test struct {
+		RepeatedMessageQueryParams bool
+		Params                     []openapiParameterObject
+	}
+
+	msgDescs := []*descriptorpb.DescriptorProto{
+		{
+			Name: proto.String("Book"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{
+					Name:   proto.String("title"),
+					Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+					Number: proto.Int32(1),
+				},
+			},
+		},
+		{
+			Name: proto.String("ExampleMessage"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{
+					Name:   proto.String("a"),
+					Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+					Number: proto.Int32(1),
+				},
+				{
+					Name:     proto.String("books"),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+					TypeName: proto.String(".example.Book"),
+					Number:   proto.Int32(2),
+				},
+			},
+		},
+	}
+
+	tests := []test{
+		{
+			RepeatedMessageQueryParams: false,
+			Params: []openapiParameterObject{
+				{
+					Name:     "a",
+					In:       "query",
+					Required: false,
+					Type:     "string",
+				},
+			},
+		},
+		{
+			RepeatedMessageQueryParams: true,
+			Params: []openapiParameterObject{
+				{
+					Name:     "a",
+					In:       "query",
+					Required: false,
+					Type:     "string",
+				},
+				{
+					Name: "books",
+					Description: "Each value is a JSON-encoded element, as in `books={\"field\":\"value\"}`. " +
+						"Fields of an element can also be set with indexed keys, as in `books[0].field=value` or `books[0][field]=value`.",
+					In:   "query",
+					Type: "array",
+					Items: &openapiItemsObject{
+						schemaCore: schemaCore{
+							Type: "string",
+						},
+					},
+					CollectionFormat: "multi",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		reg := descriptor.NewRegistry()
+		reg.SetRepeatedMessageQueryParams(test.RepeatedMessageQueryParams)
+		msgs := []*descriptor.Message{}
+		for _, msgdesc := range msgDescs {
+			msgs = append(msgs, &descriptor.Message{DescriptorProto: msgdesc})
+		}
+		file := descriptor.File{
+			FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+				SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+				Name:           proto.String("example.proto"),
+				Package:        proto.String("example"),
+				Dependency:     []string{},
+				MessageType:    msgDescs,
+				Service:        []*descriptorpb.ServiceDescriptorProto{},
+				Options: &descriptorpb.FileOptions{
+					GoPackage: proto.String("github.com/grpc-ecosystem/grpc-gateway/runtime/internal/examplepb;example"),
+				},
+			},
+			GoPkg: descriptor.GoPackage{
+				Path: "example.com/path/to/example/example.pb",
+				Name: "example_pb",
+			},
+			Messages: msgs,
+		}
+		err := reg.Load(&pluginpb.CodeGeneratorRequest{
+			ProtoFile: []*descriptorpb.FileDescriptorProto{file.FileDescriptorProto},
+		})
+		if err != nil {
+			t.Fatalf("failed to load code generator request: %v", err)
+		}
+
+		message, err := reg.LookupMsg("", ".example.ExampleMessage")
+		if err != nil {
+			t.Fatalf("failed to lookup message: %s", err)
+		}
+		params, err := messageToQueryParameters(message, reg, []descriptor.Parameter{}, nil, "")
+		if err != nil {
+			t.Fatalf("failed to convert message to query parameters: %s", err)
+		}
+		if !reflect.DeepEqual(params, test.Params) {
+			t.Errorf("repeated_message_query_params=%v: expected %#v, got %#v", test.RepeatedMessageQueryParams, test.Params, params)
+		}
+	}
+}
+
 // TestMessageToQueryParametersNoRecursive, is a check that cyclical references between messages
 // are not falsely detected given previous known edge-cases.
 func TestMessageToQueryParametersNoRecursive(t *testing.T) {
@@ -0,0 +1,293 @@
+package runtime
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/textproto"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// AccessLogRedactor returns the value to log for the header or metadata key name.
+type AccessLogRedactor func(name, value string) string
+
+// AccessLogSamplingRule selects the fraction of the requests which are logged.
+// Empty fields match any request.
+type AccessLogSamplingRule struct {
+	// HTTPMethod is the HTTP method of the route.
+	HTTPMethod string
+	// HTTPPathPattern is the path template of the route, as reported in
+	// RequestMetrics.
+	HTTPPathPattern string
+	// StatusClass is the first digit of the HTTP status, for example 2 for
+	// successful responses and 5 for server errors. Zero matches any status.
+	StatusClass int
+	// Rate is the fraction of the matching requests which are logged, between 0 and 1.
+	Rate float64
+}
+
+func (r AccessLogSamplingRule) matches(m RequestMetrics) bool {
+	return (r.HTTPMethod == "" || r.HTTPMethod == m.HTTPMethod) &&
+		(r.HTTPPathPattern == "" || r.HTTPPathPattern == m.HTTPPathPattern) &&
+		(r.StatusClass == 0 || r.StatusClass == m.HTTPStatus/100)
+}
+
+// AccessLoggerOption is an option that can be given to NewAccessLogger.
+type AccessLoggerOption func(*AccessLogger)
+
+// WithAccessLogTrustedProxies sets the networks of the proxies whose
+// X-Forwarded-For header is trusted to find the client IP. By default, the
+// client IP is the address of the peer of the connection.
+func WithAccessLogTrustedProxies(proxies ...netip.Prefix) AccessLoggerOption {
+	return func(l *AccessLogger) {
+		l.trustedProxies = append(l.trustedProxies, proxies...)
+	}
+}
+
+// WithAccessLogHeaders adds the given request headers to the access log.
+func WithAccessLogHeaders(headers ...string) AccessLoggerOption {
+	return func(l *AccessLogger) {
+		for _, h := range headers {
+			l.headers = append(l.headers, textproto.CanonicalMIMEHeaderKey(h))
+		}
+	}
+}
+
+// WithAccessLogMetadata adds the given keys of the header and trailer
+// metadata sent by the gRPC server to the access log.
+func WithAccessLogMetadata(keys ...string) AccessLoggerOption {
+	return func(l *AccessLogger) {
+		for _, k := range keys {
+			l.metadata = append(l.metadata, strings.ToLower(k))
+		}
+	}
+}
+
+// WithAccessLogRedactor sets the function redacting the logged headers and
+// metadata. The default is RedactSensitiveValues.
+func WithAccessLogRedactor(redactor AccessLogRedactor) AccessLoggerOption {
+	return func(l *AccessLogger) {
+		l.redactor = redactor
+	}
+}
+
+// WithAccessLogSampling sets the sampling rules of the access log. The rate
+// of the first rule matching a request is used, and requests matching no rule
+// are always logged.
+func WithAccessLogSampling(rules ...AccessLogSamplingRule) AccessLoggerOption {
+	return func(l *AccessLogger) {
+		l.sampling = append(l.sampling, rules...)
+	}
+}
+
+// sensitiveKeys are the header and metadata keys redacted by RedactSensitiveValues.
+var sensitiveKeys = map[string]bool{
+	"authorization":               true,
+	"proxy-authorization":         true,
+	"cookie":                      true,
+	"set-cookie":                  true,
+	"x-api-key":                   true,
+	"grpc-metadata-authorization": true,
+}
+
+// RedactSensitiveValues is an AccessLogRedactor hiding the values of
+// credentials-bearing keys, like Authorization and Cookie.
+func RedactSensitiveValues(name, value string) string {
+	if sensitiveKeys[strings.ToLower(name)] {
+		return "[REDACTED]"
+	}
+	return value
+}
+
+// AccessLogger is a GatewayStatsHandler writing an access log entry with
+// log/slog for each request matching a route of a ServeMux:
+//
+//	mux := runtime.NewServeMux(runtime.WithStatsHandler(runtime.NewAccessLogger(slog.Default())))
+//
+// Unlike an HTTP middleware, it logs the gRPC method and code of each request.
+// Server errors are logged at the error level, client errors at the warning
+// level and other requests at the info level.
+type AccessLogger struct {
+	logger         *slog.Logger
+	trustedProxies []netip.Prefix
+	headers        []string
+	metadata       []string
+	redactor       AccessLogRedactor
+	sampling       []AccessLogSamplingRule
+}
+
+// NewAccessLogger returns an AccessLogger writing to logger.
+func NewAccessLogger(logger *slog.Logger, opts ...AccessLoggerOption) *AccessLogger {
+	l := &AccessLogger{
+		logger:   logger,
+		redactor: RedactSensitiveValues,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+type accessLogEntryKey struct{}
+
+// accessLogEntry holds the fields of a request which are not part of its
+// RequestMetrics.
+type accessLogEntry struct {
+	clientIP      string
+	userAgent     string
+	contentLength int64
+	headers       []slog.Attr
+
+	mu           sync.Mutex
+	decodedBytes int64
+	md           []slog.Attr
+}
+
+// TagRequest implements GatewayStatsHandler.
+func (l *AccessLogger) TagRequest(ctx context.Context, info *StatsRouteMatched) context.Context {
+	r := info.Request
+	e := &accessLogEntry{
+		clientIP:      clientIP(r, l.trustedProxies),
+		userAgent:     r.UserAgent(),
+		contentLength: r.ContentLength,
+	}
+	for _, h := range l.headers {
+		if vs := r.Header.Values(h); len(vs) > 0 {
+			e.headers = append(e.headers, slog.String(h, l.redactor(h, strings.Join(vs, ", "))))
+		}
+	}
+	return context.WithValue(ctx, accessLogEntryKey{}, e)
+}
+
+// HandleStats implements GatewayStatsHandler.
+func (l *AccessLogger) HandleStats(ctx context.Context, s GatewayStats) {
+	e, ok := ctx.Value(accessLogEntryKey{}).(*accessLogEntry)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *StatsBodyDecoded:
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.decodedBytes += s.Bytes
+	case *StatsUpstreamEnd:
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.md = l.metadataAttrs(s.Header, s.Trailer)
+	case *StatsRequestFinished:
+		l.log(ctx, e, s.RequestMetrics)
+	}
+}
+
+func (l *AccessLogger) metadataAttrs(header, trailer metadata.MD) []slog.Attr {
+	var attrs []slog.Attr
+	for _, k := range l.metadata {
+		vs := append(append([]string(nil), header.Get(k)...), trailer.Get(k)...)
+		if len(vs) > 0 {
+			attrs = append(attrs, slog.String(k, l.redactor(k, strings.Join(vs, ", "))))
+		}
+	}
+	return attrs
+}
+
+func (l *AccessLogger) sampled(m RequestMetrics) bool {
+	for _, rule := range l.sampling {
+		if rule.matches(m) {
+			return rule.Rate >= 1 || rand.Float64() < rule.Rate
+		}
+	}
+	return true
+}
+
+func (l *AccessLogger) log(ctx context.Context, e *accessLogEntry, m RequestMetrics) {
+	if !l.sampled(m) {
+		return
+	}
+	level := slog.LevelInfo
+	switch {
+	case m.HTTPStatus >= http.StatusInternalServerError:
+		level = slog.LevelError
+	case m.HTTPStatus >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	e.mu.Lock()
+	requestBytes, md := e.decodedBytes, e.md
+	e.mu.Unlock()
+	if e.contentLength >= 0 {
+		// The decoded size is only an estimate for streams of unknown length.
+		requestBytes = e.contentLength
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", m.HTTPMethod),
+		slog.String("pattern", m.HTTPPathPattern),
+		slog.String("rpc_method", m.RPCMethod),
+		slog.Int("status", m.HTTPStatus),
+		slog.String("grpc_code", m.Code.String()),
+		slog.Duration("latency", m.Duration),
+		slog.Int64("request_bytes", requestBytes),
+		slog.Int64("response_bytes", m.ResponseBytes),
+		slog.String("client_ip", e.clientIP),
+		slog.String("user_agent", e.userAgent),
+	}
+	if id, ok := RequestIDFromContext(ctx); ok {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if len(e.headers) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "headers", Value: slog.GroupValue(e.headers...)})
+	}
+	if len(md) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "metadata", Value: slog.GroupValue(md...)})
+	}
+	l.logger.LogAttrs(ctx, level, "access", attrs...)
+}
+
+// clientIP returns the address of the client of r. The X-Forwarded-For header
+// is walked from the right for as long as the addresses are trusted proxies.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	if !isTrustedProxy(ip, trustedProxies) {
+		return ip
+	}
+
+	var hops []string
+	for _, v := range r.Header.Values(xForwardedFor) {
+		for _, hop := range strings.Split(v, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip = hops[i]
+		if !isTrustedProxy(ip, trustedProxies) {
+			break
+		}
+	}
+	return ip
+}
+
+func isTrustedProxy(ip string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range trustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package runtime_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func decodeAccessLog(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("json.Unmarshal(%q) failed with %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAccessLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := runtime.NewAccessLogger(
+		slog.New(slog.NewJSONHandler(&buf, nil)),
+		runtime.WithAccessLogTrustedProxies(netip.MustParsePrefix("10.0.0.0/8")),
+		runtime.WithAccessLogHeaders("Authorization", "x-tenant"),
+		runtime.WithAccessLogMetadata("x-upstream"),
+	)
+	mux := runtime.NewServeMux(runtime.WithStatsHandler(logger))
+	handleGenerated(t, mux, http.MethodPost, "/v1/echo/{id}", "/example.Echo/Echo", replyWith(func() (proto.Message, error) {
+		return wrapperspb.String("hello"), nil
+	}), nil)
+	handleGenerated(t, mux, http.MethodDelete, "/v1/echo/{id}", "/example.Echo/Delete", replyWith(func() (proto.Message, error) {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}), nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/echo/1", strings.NewReader(`"hi"`))
+	req.RemoteAddr = "10.0.0.2:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	req.Header.Set("User-Agent", "test-agent")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Tenant", "acme")
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodDelete, "/v1/echo/1", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := decodeAccessLog(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries; want 2: %s", len(entries), buf.String())
+	}
+
+	ok := entries[0]
+	for key, want := range map[string]interface{}{
+		"level":          "INFO",
+		"msg":            "access",
+		"method":         "POST",
+		"pattern":        "/v1/echo/{id}",
+		"rpc_method":     "/example.Echo/Echo",
+		"status":         float64(http.StatusOK),
+		"grpc_code":      "OK",
+		"request_bytes":  float64(4),
+		"response_bytes": float64(len(`"hello"`)),
+		"client_ip":      "203.0.113.7",
+		"user_agent":     "test-agent",
+	} {
+		if got := ok[key]; got != want {
+			t.Errorf("entry[%q] = %v; want %v", key, got, want)
+		}
+	}
+	if _, found := ok["latency"]; !found {
+		t.Errorf("entry has no latency: %v", ok)
+	}
+	headers, _ := ok["headers"].(map[string]interface{})
+	if headers["Authorization"] != "[REDACTED]" || headers["X-Tenant"] != "acme" {
+		t.Errorf("entry headers = %v; want a redacted Authorization and X-Tenant", ok["headers"])
+	}
+	md, _ := ok["metadata"].(map[string]interface{})
+	if md["x-upstream"] != "1" {
+		t.Errorf("entry metadata = %v; want x-upstream", ok["metadata"])
+	}
+
+	failed := entries[1]
+	if failed["level"] != "ERROR" || failed["status"] != float64(http.StatusServiceUnavailable) || failed["grpc_code"] != "Unavailable" {
+		t.Errorf("error entry = %v", failed)
+	}
+	if failed["client_ip"] != "192.0.2.1" {
+		t.Errorf("client_ip = %v; want the untrusted peer address", failed["client_ip"])
+	}
+}
+
+func TestAccessLoggerSampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := runtime.NewAccessLogger(
+		slog.New(slog.NewJSONHandler(&buf, nil)),
+		runtime.WithAccessLogSampling(
+			runtime.AccessLogSamplingRule{HTTPPathPattern: "/v1/echo/{id}", StatusClass: 2, Rate: 0},
+			runtime.AccessLogSamplingRule{StatusClass: 2, Rate: 1},
+		),
+	)
+	mux := runtime.NewServeMux(runtime.WithStatsHandler(logger))
+	handleGenerated(t, mux, http.MethodGet, "/v1/echo/{id}", "/example.Echo/Echo", replyWith(func() (proto.Message, error) {
+		return wrapperspb.String("hello"), nil
+	}), nil)
+	handleGenerated(t, mux, http.MethodGet, "/v1/other", "/example.Echo/Other", replyWith(func() (proto.Message, error) {
+		return wrapperspb.String("hello"), nil
+	}), nil)
+	handleGenerated(t, mux, http.MethodDelete, "/v1/echo/{id}", "/example.Echo/Delete", replyWith(func() (proto.Message, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}), nil)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/v1/echo/1", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/other", nil),
+		httptest.NewRequest(http.MethodDelete, "/v1/echo/1", nil),
+	} {
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	entries := decodeAccessLog(t, &buf)
+	var got []string
+	for _, e := range entries {
+		got = append(got, e["rpc_method"].(string))
+	}
+	if want := "/example.Echo/Other,/example.Echo/Delete"; strings.Join(got, ",") != want {
+		t.Errorf("logged %v; want %s", got, want)
+	}
+}
@@ -0,0 +1,321 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	goruntime "runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// maxRecentRouteErrors is the number of errors kept for each route.
+const maxRecentRouteErrors = 10
+
+// routeStats holds the live statistics of a route.
+type routeStats struct {
+	inFlight atomic.Int64
+	// forwardedUnary and forwardedStream record whether the route forwarded
+	// unary responses or streams.
+	forwardedUnary, forwardedStream atomic.Bool
+
+	mu           sync.Mutex
+	rpcMethod    string
+	recentErrors []AdminRouteError
+}
+
+type routeStatsKey struct{}
+
+func withRouteStats(ctx context.Context, rs *routeStats) context.Context {
+	return context.WithValue(ctx, routeStatsKey{}, rs)
+}
+
+func routeStatsFromContext(ctx context.Context) *routeStats {
+	if ctx == nil {
+		return nil
+	}
+	rs, _ := ctx.Value(routeStatsKey{}).(*routeStats)
+	return rs
+}
+
+func (rs *routeStats) setRPCMethod(rpcMethod string) {
+	if rs == nil {
+		return
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.rpcMethod = rpcMethod
+}
+
+// method returns the gRPC method called by the route, if it was registered
+// with it or has handled a request.
+func (rs *routeStats) method() string {
+	if rs == nil {
+		return ""
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.rpcMethod
+}
+
+// forwarded records that the route forwarded a unary response, or a stream.
+func (rs *routeStats) forwarded(stream bool) {
+	if rs == nil {
+		return
+	}
+	if stream {
+		rs.forwardedStream.Store(true)
+	} else {
+		rs.forwardedUnary.Store(true)
+	}
+}
+
+// unary reports whether the route only forwarded unary responses so far.
+func (rs *routeStats) unary() bool {
+	return rs != nil && rs.forwardedUnary.Load() && !rs.forwardedStream.Load()
+}
+
+func (rs *routeStats) recordError(err error) {
+	if rs == nil || err == nil {
+		return
+	}
+	var customStatus *HTTPStatusError
+	if errors.As(err, &customStatus) {
+		err = customStatus.Err
+	}
+	st := status.Convert(err)
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if len(rs.recentErrors) == maxRecentRouteErrors {
+		rs.recentErrors = append(rs.recentErrors[:0], rs.recentErrors[1:]...)
+	}
+	rs.recentErrors = append(rs.recentErrors, AdminRouteError{
+		Time:    time.Now(),
+		Code:    st.Code().String(),
+		Message: st.Message(),
+	})
+}
+
+// AdminRoute describes a route of a ServeMux in the admin handler.
+type AdminRoute struct {
+	Method  string `json:"method"`
+	Pattern string `json:"pattern"`
+	// RPCMethod is the gRPC method called by the route. It is known from the
+	// registration of the generated handlers, and once the other routes have
+	// handled a request.
+	RPCMethod    string            `json:"rpc_method,omitempty"`
+	InFlight     int64             `json:"in_flight"`
+	RecentErrors []AdminRouteError `json:"recent_errors"`
+}
+
+// AdminRouteError is one of the last errors returned by a route.
+type AdminRouteError struct {
+	Time    time.Time `json:"time"`
+	Code    string    `json:"code"`
+	Message string    `json:"message"`
+}
+
+// AdminConfig describes the configuration of a ServeMux in the admin handler.
+// Functions are described by their name.
+type AdminConfig struct {
+	Marshalers                map[string]string `json:"marshalers"`
+	UnescapingMode            string            `json:"unescaping_mode"`
+	IncomingHeaderMatcher     string            `json:"incoming_header_matcher"`
+	OutgoingHeaderMatcher     string            `json:"outgoing_header_matcher"`
+	OutgoingTrailerMatcher    string            `json:"outgoing_trailer_matcher"`
+	ErrorHandler              string            `json:"error_handler"`
+	StreamErrorHandler        string            `json:"stream_error_handler"`
+	RoutingErrorHandler       string            `json:"routing_error_handler"`
+	RecoveryHandler           string            `json:"recovery_handler,omitempty"`
+	Middlewares               int               `json:"middlewares"`
+	MetadataAnnotators        int               `json:"metadata_annotators"`
+	ForwardResponseOptions    int               `json:"forward_response_options"`
+	StatsHandlers             int               `json:"stats_handlers"`
+	DisablePathLengthFallback bool              `json:"disable_path_length_fallback"`
+	WriteContentLength        bool              `json:"write_content_length"`
+	Draining                  bool              `json:"draining"`
+}
+
+// AdminRouteMatch is the result of the route tester of the admin handler.
+type AdminRouteMatch struct {
+	Matched    bool              `json:"matched"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	Pattern    string            `json:"pattern,omitempty"`
+	RPCMethod  string            `json:"rpc_method,omitempty"`
+	PathParams map[string]string `json:"path_params,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+var unescapingModeNames = map[UnescapingMode]string{
+	UnescapingModeLegacy:            "UnescapingModeLegacy",
+	UnescapingModeAllExceptReserved: "UnescapingModeAllExceptReserved",
+	UnescapingModeAllExceptSlash:    "UnescapingModeAllExceptSlash",
+	UnescapingModeAllCharacters:     "UnescapingModeAllCharacters",
+}
+
+// NewAdminHandler returns an http.Handler exposing the routes, the
+// configuration and the live statistics of mux as JSON, meant to be served on
+// a separate, internal port:
+//
+//	GET /routes                     routes, with their in-flight requests and recent errors
+//	GET /config                     marshalers, unescaping mode, header matchers and handlers
+//	GET /match?method=GET&path=/v1  the route which would handle a request, with its path parameters
+//
+// Use http.StripPrefix to serve it under a prefix.
+func NewAdminHandler(mux *ServeMux) http.Handler {
+	h := http.NewServeMux()
+	h.HandleFunc("GET /routes", func(w http.ResponseWriter, _ *http.Request) {
+		writeAdminJSON(w, http.StatusOK, mux.adminRoutes())
+	})
+	h.HandleFunc("GET /config", func(w http.ResponseWriter, _ *http.Request) {
+		writeAdminJSON(w, http.StatusOK, mux.adminConfig())
+	})
+	h.HandleFunc("GET /match", func(w http.ResponseWriter, r *http.Request) {
+		method, path := r.URL.Query().Get("method"), r.URL.Query().Get("path")
+		if method == "" {
+			method = http.MethodGet
+		}
+		if path == "" {
+			writeAdminJSON(w, http.StatusBadRequest, AdminRouteMatch{Method: method, Error: "missing path"})
+			return
+		}
+		writeAdminJSON(w, http.StatusOK, mux.matchRoute(strings.ToUpper(method), path))
+	})
+	return h
+}
+
+func writeAdminJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		grpclog.Errorf("Failed to write admin response: %v", err)
+	}
+}
+
+func (s *ServeMux) adminRoutes() []AdminRoute {
+	routes := []AdminRoute{}
+	for meth, handlers := range s.handlers {
+		for _, h := range handlers {
+			route := AdminRoute{
+				Method:       meth,
+				Pattern:      h.pat.String(),
+				RecentErrors: []AdminRouteError{},
+			}
+			if h.stats != nil {
+				route.InFlight = h.stats.inFlight.Load()
+				h.stats.mu.Lock()
+				route.RPCMethod = h.stats.rpcMethod
+				route.RecentErrors = append(route.RecentErrors, h.stats.recentErrors...)
+				h.stats.mu.Unlock()
+			}
+			routes = append(routes, route)
+		}
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Pattern != routes[j].Pattern {
+			return routes[i].Pattern < routes[j].Pattern
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+func (s *ServeMux) adminConfig() AdminConfig {
+	c := AdminConfig{
+		Marshalers:                make(map[string]string),
+		UnescapingMode:            unescapingModeNames[s.unescapingMode],
+		IncomingHeaderMatcher:     funcName(s.incomingHeaderMatcher),
+		OutgoingHeaderMatcher:     funcName(s.outgoingHeaderMatcher),
+		OutgoingTrailerMatcher:    funcName(s.outgoingTrailerMatcher),
+		ErrorHandler:              funcName(s.errorHandler),
+		StreamErrorHandler:        funcName(s.streamErrorHandler),
+		RoutingErrorHandler:       funcName(s.routingErrorHandler),
+		RecoveryHandler:           funcName(s.recoveryHandler),
+		Middlewares:               len(s.middlewares),
+		MetadataAnnotators:        len(s.metadataAnnotators),
+		ForwardResponseOptions:    len(s.forwardResponseOptions),
+		StatsHandlers:             len(s.statsHandlers),
+		DisablePathLengthFallback: s.disablePathLengthFallback,
+		WriteContentLength:        s.writeContentLength,
+		Draining:                  s.Draining(),
+	}
+	for mime, m := range s.marshalers.mimeMap {
+		c.Marshalers[mime] = reflect.TypeOf(m).String()
+	}
+	return c
+}
+
+// funcName returns the name of the function f.
+func funcName(f interface{}) string {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	if fn := goruntime.FuncForPC(v.Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return ""
+}
+
+// matchRoute returns the route which ServeHTTP would select for a request
+// with the given method and path, not considering the path length fallback.
+func (s *ServeMux) matchRoute(method, path string) AdminRouteMatch {
+	m := AdminRouteMatch{Method: method, Path: path}
+	if !strings.HasPrefix(path, "/") {
+		m.Error = "path must start with /"
+		return m
+	}
+
+	var pathComponents []string
+	if s.unescapingMode == UnescapingModeAllCharacters {
+		pathComponents = encodedPathSplitter.Split(path[1:], -1)
+	} else {
+		pathComponents = strings.Split(path[1:], "/")
+	}
+	lastPathComponent := pathComponents[len(pathComponents)-1]
+
+	for _, h := range s.handlers[method] {
+		var verb string
+		comps := append([]string(nil), pathComponents...)
+		if patVerb := h.pat.Verb(); patVerb != "" && strings.HasSuffix(lastPathComponent, ":"+patVerb) {
+			idx := len(lastPathComponent) - len(patVerb) - 1
+			if idx == 0 {
+				break
+			}
+			comps[len(comps)-1], verb = lastPathComponent[:idx], lastPathComponent[idx+1:]
+		}
+
+		pathParams, err := h.pat.MatchAndEscape(comps, verb, s.unescapingMode)
+		if err != nil {
+			var mse MalformedSequenceError
+			if errors.As(err, &mse) {
+				m.Error = mse.Error()
+			}
+			continue
+		}
+		m.Matched = true
+		m.Pattern = h.pat.String()
+		m.PathParams = pathParams
+		m.Error = ""
+		if h.stats != nil {
+			h.stats.mu.Lock()
+			m.RPCMethod = h.stats.rpcMethod
+			h.stats.mu.Unlock()
+		}
+		return m
+	}
+	return m
+}
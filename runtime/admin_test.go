@@ -0,0 +1,148 @@
+package runtime_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func getAdmin(t *testing.T, h http.Handler, target string, v interface{}) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	if got, want := w.Header().Get("Content-Type"), "application/json"; got != want {
+		t.Errorf("Content-Type = %q; want %q", got, want)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed with %v", w.Body.String(), err)
+	}
+	return w.Code
+}
+
+func TestAdminHandlerRoutes(t *testing.T) {
+	mux := runtime.NewServeMux()
+	handleGenerated(t, mux, http.MethodGet, "/v1/echo/{id}", "/example.Echo/Echo", replyWith(func() (proto.Message, error) {
+		return wrapperspb.String("hello"), nil
+	}), nil)
+	handleGenerated(t, mux, http.MethodDelete, "/v1/echo/{id}", "/example.Echo/Delete", replyWith(func() (proto.Message, error) {
+		return nil, status.Error(codes.NotFound, "no such echo")
+	}), nil)
+
+	// The RPC of a route registered with it is known before any request.
+	pat := runtime.MustPattern(runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{"idle"}, ""))
+	mux.HandleRPC(http.MethodPut, pat, "/example.Echo/Idle", func(http.ResponseWriter, *http.Request, map[string]string) {})
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	if err := mux.HandlePath(http.MethodPost, "/v1/slow", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		close(entered)
+		<-release
+	}); err != nil {
+		t.Fatalf("mux.HandlePath(...) failed with %v", err)
+	}
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/echo/1", nil))
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/v1/echo/1", nil))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/slow", nil))
+	}()
+	<-entered
+
+	admin := runtime.NewAdminHandler(mux)
+	var routes []runtime.AdminRoute
+	if code := getAdmin(t, admin, "/routes", &routes); code != http.StatusOK {
+		t.Fatalf("status = %d; want %d", code, http.StatusOK)
+	}
+	close(release)
+	<-done
+
+	if len(routes) != 4 {
+		t.Fatalf("got %d routes; want 4: %+v", len(routes), routes)
+	}
+	byMethod := make(map[string]runtime.AdminRoute)
+	for _, r := range routes {
+		byMethod[r.Method] = r
+	}
+	if r := byMethod[http.MethodGet]; r.Pattern != "/v1/echo/{id=*}" || r.RPCMethod != "/example.Echo/Echo" || len(r.RecentErrors) != 0 {
+		t.Errorf("GET route = %+v", r)
+	}
+	if r := byMethod[http.MethodDelete]; len(r.RecentErrors) != 1 || r.RecentErrors[0].Code != "NotFound" || r.RecentErrors[0].Message != "no such echo" {
+		t.Errorf("DELETE route = %+v; want a NotFound error", r)
+	}
+	if r := byMethod[http.MethodPut]; r.Pattern != "/idle" || r.RPCMethod != "/example.Echo/Idle" {
+		t.Errorf("PUT route = %+v; want the RPC it was registered with", r)
+	}
+	if r := byMethod[http.MethodPost]; r.InFlight != 1 {
+		t.Errorf("POST route in flight = %d; want 1", r.InFlight)
+	}
+}
+
+func TestAdminHandlerConfig(t *testing.T) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption("application/x-custom", &runtime.HTTPBodyMarshaler{}),
+		runtime.WithUnescapingMode(runtime.UnescapingModeAllCharacters),
+		runtime.WithMiddlewares(func(next runtime.HandlerFunc) runtime.HandlerFunc { return next }),
+	)
+	var config runtime.AdminConfig
+	getAdmin(t, runtime.NewAdminHandler(mux), "/config", &config)
+
+	if got, want := config.Marshalers["application/x-custom"], "*runtime.HTTPBodyMarshaler"; got != want {
+		t.Errorf("marshalers[application/x-custom] = %q; want %q", got, want)
+	}
+	if got, want := config.UnescapingMode, "UnescapingModeAllCharacters"; got != want {
+		t.Errorf("unescaping mode = %q; want %q", got, want)
+	}
+	if !strings.HasSuffix(config.IncomingHeaderMatcher, "runtime.DefaultHeaderMatcher") {
+		t.Errorf("incoming header matcher = %q; want DefaultHeaderMatcher", config.IncomingHeaderMatcher)
+	}
+	if config.Middlewares != 1 {
+		t.Errorf("middlewares = %d; want 1", config.Middlewares)
+	}
+}
+
+func TestAdminHandlerMatch(t *testing.T) {
+	mux := runtime.NewServeMux()
+	handleGenerated(t, mux, http.MethodGet, "/v1/echo/{id}", "/example.Echo/Echo", nil, nil)
+	handleGenerated(t, mux, http.MethodPost, "/v1/echo/{id}:check", "/example.Echo/Check", nil, nil)
+	admin := runtime.NewAdminHandler(mux)
+
+	for _, spec := range []struct {
+		method, path string
+		wantPattern  string
+		wantParams   map[string]string
+	}{
+		{method: "get", path: "/v1/echo/abc", wantPattern: "/v1/echo/{id=*}", wantParams: map[string]string{"id": "abc"}},
+		{method: "POST", path: "/v1/echo/abc:check", wantPattern: "/v1/echo/{id=*}:check", wantParams: map[string]string{"id": "abc"}},
+		{method: "POST", path: "/v1/echo/abc"},
+		{method: "GET", path: "/v2/echo"},
+	} {
+		var m runtime.AdminRouteMatch
+		q := url.Values{"method": {spec.method}, "path": {spec.path}}
+		getAdmin(t, admin, "/match?"+q.Encode(), &m)
+		if m.Matched != (spec.wantPattern != "") || m.Pattern != spec.wantPattern {
+			t.Errorf("match(%s %s) = %+v; want pattern %q", spec.method, spec.path, m, spec.wantPattern)
+			continue
+		}
+		for k, v := range spec.wantParams {
+			if m.PathParams[k] != v {
+				t.Errorf("match(%s %s).PathParams[%q] = %q; want %q", spec.method, spec.path, k, m.PathParams[k], v)
+			}
+		}
+	}
+
+	var m runtime.AdminRouteMatch
+	if code := getAdmin(t, admin, "/match", &m); code != http.StatusBadRequest {
+		t.Errorf("status without path = %d; want %d", code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,279 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BodyLimits bounds the request bodies read by the handlers of a ServeMux.
+// Zero fields are not limited.
+type BodyLimits struct {
+	// MaxBytes is the maximum size of a request body.
+	MaxBytes int64
+	// MaxMessages is the maximum number of messages decoded from a request
+	// body, which only matters for client streams.
+	MaxMessages int
+	// MinBytesPerSecond is the minimum average rate at which clients must send
+	// request bodies. Only the time spent waiting for the client is counted,
+	// not the time spent by the gRPC server to consume a client stream.
+	// Interactive client streams, which may be idle, should not be limited.
+	MinBytesPerSecond float64
+	// MinRateGracePeriod is the time given to clients before
+	// MinBytesPerSecond is enforced.
+	MinRateGracePeriod time.Duration
+}
+
+// BodyLimitPolicy overrides the body limits of some gRPC methods.
+type BodyLimitPolicy struct {
+	// Selector is the full name of a gRPC method, like "package.Service.Method".
+	// It may end with ".*" to select all the methods of a service or package,
+	// or be "*" to select every method.
+	Selector string
+	// Limits replaces the default limits for the selected methods.
+	Limits BodyLimits
+}
+
+// WithBodyLimits returns a ServeMuxOption bounding the request bodies read by
+// the handlers of the ServeMux. The limits of the first policy selecting the
+// gRPC method of a request are used, or defaults if none does.
+//
+// Requests whose Content-Length is over the limit are rejected before their
+// body is decoded. Other bodies are limited while they are read. Violations
+// fail the request with a ResourceExhausted error, written by the configured
+// error handler with the 413 Request Entity Too Large status, or 408 Request
+// Timeout when the client is too slow. A client stream exceeding its limits
+// is canceled, and its response stream ends with a ResourceExhausted error
+// chunk.
+//
+// The minimum rate is enforced with http.ResponseController.SetReadDeadline
+// when the ResponseWriter supports it, in which case the read deadline of the
+// connection is cleared once the body is read. Otherwise, a stalled client is
+// only detected once it sends data.
+func WithBodyLimits(defaults BodyLimits, policies ...BodyLimitPolicy) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.bodyLimits = &bodyLimitConfig{defaults: defaults, policies: policies}
+	}
+}
+
+type bodyLimitConfig struct {
+	defaults BodyLimits
+	policies []BodyLimitPolicy
+}
+
+func (c *bodyLimitConfig) limitsFor(rpcMethodName string) BodyLimits {
+	fullMethod := fullMethodName(rpcMethodName)
+	for _, p := range c.policies {
+		if selectorMatches(p.Selector, fullMethod) {
+			return p.Limits
+		}
+	}
+	return c.defaults
+}
+
+// bodyLimitState holds the limits and the violation of the body of a request.
+type bodyLimitState struct {
+	// cancel cancels the context of the request.
+	cancel        context.CancelCauseFunc
+	config        *bodyLimitConfig
+	contentLength int64
+	limits        atomic.Pointer[BodyLimits]
+	// read is the number of bytes of the body read so far.
+	read atomic.Int64
+
+	mu        sync.Mutex
+	violation *HTTPStatusError
+}
+
+type bodyLimitStateKey struct{}
+
+func bodyLimitStateFromContext(ctx context.Context) *bodyLimitState {
+	if ctx == nil {
+		return nil
+	}
+	state, _ := ctx.Value(bodyLimitStateKey{}).(*bodyLimitState)
+	return state
+}
+
+// limitBody returns r with a body enforcing the limits of the gRPC method
+// rpcMethodName of the route, or the default limits of c if the route has
+// none, which are replaced by the limits of the gRPC method of the request
+// once it is known, and a function to call once the request is handled.
+func (c *bodyLimitConfig) limitBody(w http.ResponseWriter, r *http.Request, rpcMethodName string) (*http.Request, func()) {
+	state := &bodyLimitState{config: c, contentLength: r.ContentLength}
+	limits := c.defaults
+	if rpcMethodName != "" {
+		limits = c.limitsFor(rpcMethodName)
+	}
+	state.limits.Store(&limits)
+	ctx, cancel := context.WithCancelCause(r.Context())
+	state.cancel = cancel
+	r = r.WithContext(context.WithValue(ctx, bodyLimitStateKey{}, state))
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = &limitedBody{body: r.Body, rc: http.NewResponseController(w), state: state}
+	}
+	return r, func() { cancel(nil) }
+}
+
+// setRPCMethod selects the limits of the gRPC method of the request, and
+// rejects the request if its Content-Length, or the part of its body already
+// read, is over the limit.
+func (s *bodyLimitState) setRPCMethod(rpcMethodName string) error {
+	if s == nil {
+		return nil
+	}
+	limits := s.config.limitsFor(rpcMethodName)
+	s.limits.Store(&limits)
+	if limits.MaxBytes > 0 && (s.contentLength > limits.MaxBytes || s.read.Load() > limits.MaxBytes) {
+		return s.fail(http.StatusRequestEntityTooLarge, "request body is larger than %d bytes", limits.MaxBytes)
+	}
+	return nil
+}
+
+// fail records a violation of the limits and cancels the request, so that
+// client streams sent from another goroutine stop.
+func (s *bodyLimitState) fail(httpStatus int, format string, a ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.violation == nil {
+		s.violation = &HTTPStatusError{
+			HTTPStatus: httpStatus,
+			Err:        status.Errorf(codes.ResourceExhausted, format, a...),
+		}
+		s.cancel(s.violation)
+	}
+	return s.violation
+}
+
+func (s *bodyLimitState) err() *HTTPStatusError {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.violation
+}
+
+// bodyLimitError returns the violation of the body limits of the request of
+// ctx, which caused err, or err if there is none.
+func bodyLimitError(ctx context.Context, err error) error {
+	if v := bodyLimitStateFromContext(ctx).err(); v != nil {
+		return v
+	}
+	return err
+}
+
+// bodyLimitStreamError is like bodyLimitError for stream errors, which have
+// no HTTP status.
+func bodyLimitStreamError(ctx context.Context, err error) error {
+	if v := bodyLimitStateFromContext(ctx).err(); v != nil {
+		return v.Err
+	}
+	return err
+}
+
+// limitedBody is a request body enforcing BodyLimits.
+type limitedBody struct {
+	body  io.ReadCloser
+	rc    *http.ResponseController
+	state *bodyLimitState
+
+	n             int64
+	readTime      time.Duration
+	noDeadline    bool
+	deadlineIsSet bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if v := b.state.err(); v != nil {
+		return 0, v
+	}
+	limits := b.state.limits.Load()
+	if limits.MaxBytes > 0 && int64(len(p)) > limits.MaxBytes-b.n+1 {
+		// Read one byte past the limit at most, to detect larger bodies. The
+		// limit may be below the bytes already read if it was lowered once
+		// the gRPC method was known.
+		p = p[:max(limits.MaxBytes-b.n+1, 0)]
+	}
+	b.setDeadline(limits)
+
+	start := time.Now()
+	n, err := b.body.Read(p)
+	b.readTime += time.Since(start)
+	b.n += int64(n)
+	b.state.read.Store(b.n)
+
+	switch {
+	case limits.MaxBytes > 0 && b.n > limits.MaxBytes:
+		return 0, b.state.fail(http.StatusRequestEntityTooLarge, "request body is larger than %d bytes", limits.MaxBytes)
+	case errors.Is(err, os.ErrDeadlineExceeded) || b.tooSlow(limits):
+		return n, b.state.fail(http.StatusRequestTimeout, "request body is sent slower than %g bytes per second", limits.MinBytesPerSecond)
+	case err != nil:
+		b.clearDeadline()
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	b.clearDeadline()
+	return b.body.Close()
+}
+
+// budget returns the time clients may spend sending the next byte of the body.
+func (b *limitedBody) budget(limits *BodyLimits) time.Duration {
+	allowed := limits.MinRateGracePeriod + time.Duration(float64(b.n+1)/limits.MinBytesPerSecond*float64(time.Second))
+	return allowed - b.readTime
+}
+
+func (b *limitedBody) tooSlow(limits *BodyLimits) bool {
+	return limits.MinBytesPerSecond > 0 && b.readTime > limits.MinRateGracePeriod &&
+		float64(b.n) < limits.MinBytesPerSecond*(b.readTime-limits.MinRateGracePeriod).Seconds()
+}
+
+func (b *limitedBody) setDeadline(limits *BodyLimits) {
+	if limits.MinBytesPerSecond <= 0 || b.noDeadline {
+		return
+	}
+	if err := b.rc.SetReadDeadline(time.Now().Add(max(b.budget(limits), time.Millisecond))); err != nil {
+		b.noDeadline = true
+		return
+	}
+	b.deadlineIsSet = true
+}
+
+func (b *limitedBody) clearDeadline() {
+	if b.deadlineIsSet {
+		_ = b.rc.SetReadDeadline(time.Time{})
+		b.deadlineIsSet = false
+	}
+}
+
+// bodyLimitMarshaler wraps the inbound marshaler of a request to enforce
+// BodyLimits.MaxMessages.
+type bodyLimitMarshaler struct {
+	Marshaler
+	state *bodyLimitState
+}
+
+func (m *bodyLimitMarshaler) NewDecoder(r io.Reader) Decoder {
+	dec := m.Marshaler.NewDecoder(r)
+	var count int
+	return DecoderFunc(func(v interface{}) error {
+		if err := dec.Decode(v); err != nil {
+			return err
+		}
+		count++
+		if limits := m.state.limits.Load(); limits.MaxMessages > 0 && count > limits.MaxMessages {
+			return m.state.fail(http.StatusRequestEntityTooLarge, "request stream has more than %d messages", limits.MaxMessages)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,235 @@
+package runtime_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// handleClientStream registers a route decoding a client stream of
+// StringValue like the generated handlers, and replying with their count.
+func handleClientStream(t *testing.T, mux *runtime.ServeMux, pattern, rpcMethod string) {
+	t.Helper()
+	err := mux.HandlePath(http.MethodPost, pattern, func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		ctx, err := runtime.AnnotateContext(req.Context(), mux, req, rpcMethod, runtime.WithHTTPPathPattern(pattern))
+		if err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+		dec := inboundMarshaler.NewDecoder(req.Body)
+		var count int64
+		for {
+			err := dec.Decode(&wrapperspb.StringValue{})
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, status.Errorf(codes.InvalidArgument, "%v", err))
+				return
+			}
+			count++
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, req, wrapperspb.Int64(count))
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath(%q) failed with %v", pattern, err)
+	}
+}
+
+// slowReader returns one byte of s at a time, after delay.
+type slowReader struct {
+	s     string
+	delay time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if r.s == "" {
+		return 0, io.EOF
+	}
+	time.Sleep(r.delay)
+	p[0], r.s = r.s[0], r.s[1:]
+	return 1, nil
+}
+
+func checkResourceExhausted(t *testing.T, w *httptest.ResponseRecorder, wantStatus int) {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Errorf("status = %d; want %d: %s", w.Code, wantStatus, w.Body.String())
+	}
+	var body struct{ Code codes.Code }
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed with %v", w.Body.String(), err)
+	}
+	if body.Code != codes.ResourceExhausted {
+		t.Errorf("code = %v; want %v", body.Code, codes.ResourceExhausted)
+	}
+}
+
+func TestWithBodyLimitsMaxBytes(t *testing.T) {
+	var called int
+	mux := runtime.NewServeMux(runtime.WithBodyLimits(
+		runtime.BodyLimits{MaxBytes: 8},
+		runtime.BodyLimitPolicy{Selector: "example.Echo.Upload", Limits: runtime.BodyLimits{MaxBytes: 64}},
+	))
+	for _, spec := range []struct{ pattern, rpcMethod string }{
+		{"/v1/echo/{id}", "/example.Echo/Echo"},
+		{"/v1/upload/{id}", "/example.Echo/Upload"},
+	} {
+		handleGenerated(t, mux, http.MethodPost, spec.pattern, spec.rpcMethod, replyWith(func() (proto.Message, error) {
+			called++
+			return wrapperspb.String("ok"), nil
+		}), nil)
+	}
+	handleClientStream(t, mux, "/v1/stream", "/example.Echo/Stream")
+
+	t.Run("content length", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/echo/1", strings.NewReader(`"0123456789"`)))
+		checkResourceExhausted(t, w, http.StatusRequestEntityTooLarge)
+	})
+	t.Run("unknown length", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/stream", strings.NewReader(`"0123" "4567"`))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		checkResourceExhausted(t, w, http.StatusRequestEntityTooLarge)
+	})
+	if called != 0 {
+		t.Errorf("upstream called %d times; want 0", called)
+	}
+	t.Run("method policy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/upload/1", strings.NewReader(`"0123456789"`)))
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d; want %d: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+	})
+}
+
+func TestWithBodyLimitsRouteMethod(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithBodyLimits(
+		runtime.BodyLimits{MaxBytes: 8},
+		runtime.BodyLimitPolicy{Selector: "example.Echo.Upload", Limits: runtime.BodyLimits{MaxBytes: 64}},
+	))
+	// The body is read before AnnotateContext, with the limits of the method
+	// of the route.
+	pat := runtime.MustPattern(runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{"upload"}, ""))
+	mux.HandleRPC(http.MethodPost, pat, "/example.Echo/Upload", func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+		ctx, err := runtime.AnnotateContext(req.Context(), mux, req, "/example.Echo/Upload", runtime.WithHTTPPathPattern("/upload"))
+		if err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, req, wrapperspb.Int64(int64(len(body))))
+	})
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(`"0123456789"`)))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d; want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+}
+
+func TestWithBodyLimitsLoweredAfterRead(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithBodyLimits(
+		runtime.BodyLimits{MaxBytes: 100},
+		runtime.BodyLimitPolicy{Selector: "example.Echo.Small", Limits: runtime.BodyLimits{MaxBytes: 10}},
+	))
+	var readErr error
+	if err := mux.HandlePath(http.MethodPost, "/v1/small", func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		// 50 bytes are read under the default limit before the method is
+		// known.
+		if _, err := io.ReadFull(req.Body, make([]byte, 50)); err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+		_, err := runtime.AnnotateContext(req.Context(), mux, req, "/example.Echo/Small", runtime.WithHTTPPathPattern("/v1/small"))
+		_, readErr = req.Body.Read(make([]byte, 10))
+		if err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("mux.HandlePath(...) failed with %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/small", strings.NewReader(strings.Repeat("a", 60)))
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	checkResourceExhausted(t, w, http.StatusRequestEntityTooLarge)
+	if readErr == nil {
+		t.Errorf("reading the body past the lowered limit succeeded; want an error")
+	}
+}
+
+func TestWithBodyLimitsMaxMessages(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithBodyLimits(runtime.BodyLimits{MaxMessages: 2}))
+	handleClientStream(t, mux, "/v1/upload", "/example.Echo/Upload")
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/upload", strings.NewReader(`"a" "b"`)))
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `"2"` {
+		t.Errorf("response = %d %s; want 200 \"2\"", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/upload", strings.NewReader(`"a" "b" "c"`)))
+	checkResourceExhausted(t, w, http.StatusRequestEntityTooLarge)
+}
+
+func TestWithBodyLimitsMinBytesPerSecond(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithBodyLimits(runtime.BodyLimits{MinBytesPerSecond: 1000}))
+	handleClientStream(t, mux, "/v1/upload", "/example.Echo/Upload")
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/upload", &slowReader{s: `"abc"`, delay: 10 * time.Millisecond})
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	checkResourceExhausted(t, w, http.StatusRequestTimeout)
+}
+
+func TestWithBodyLimitsStalledClient(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithBodyLimits(runtime.BodyLimits{
+		MinBytesPerSecond:  1000,
+		MinRateGracePeriod: 50 * time.Millisecond,
+	}))
+	handleClientStream(t, mux, "/v1/upload", "/example.Echo/Upload")
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	body, stall := io.Pipe()
+	defer stall.Close()
+	go func() {
+		_, _ = stall.Write([]byte(`"a" `))
+	}()
+	resp, err := http.Post(server.URL+"/v1/upload", "application/json", body)
+	if err != nil {
+		t.Fatalf("http.Post(...) failed with %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestTimeout {
+		t.Errorf("status = %d; want %d", resp.StatusCode, http.StatusRequestTimeout)
+	}
+}
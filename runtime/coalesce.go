@@ -0,0 +1,298 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/textproto"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// CoalescingPolicy configures the coalescing of the requests to some gRPC
+// methods.
+type CoalescingPolicy struct {
+	// Selector is the full name of a gRPC method, like "package.Service.Method".
+	// It may end with ".*" to select all the methods of a service or package,
+	// or be "*" to select every method.
+	Selector string
+	// Disabled disables the coalescing of the requests to the selected methods.
+	Disabled bool
+	// Headers are the request headers, besides Accept, Content-Type,
+	// Authorization, Cookie and the headers forwarded as metadata with the
+	// Grpc-Metadata- prefix, which change the response. Only requests with the
+	// same values for these headers are coalesced.
+	Headers []string
+}
+
+// WithRequestCoalescing returns a ServeMuxOption coalescing the concurrent GET
+// requests to the routes of the ServeMux calling unary methods: while a
+// request is calling the gRPC method, the identical requests wait for its
+// response and are served a copy of it, status, headers and body, instead of
+// calling the method again.
+//
+// Requests are identical when they have the same route, path parameters,
+// query and values of the Accept, Content-Type, Authorization and Cookie
+// headers, of the headers forwarded as metadata and of the headers of the
+// policy. The policy of the first CoalescingPolicy selecting the gRPC method of
+// a route applies. Without policies, the requests to every unary method are
+// coalesced; otherwise, only the requests to the methods selected by a policy
+// which is not disabled are. Routes are coalesced once they forwarded a unary
+// response, and requests which accept trailers never are.
+//
+// Each request waits for the shared call until its own context is done. The
+// shared call is canceled once every request waiting for it is gone.
+//
+// The requests served the response of another request are reported to the
+// MetricsCollector in RequestMetrics.Coalesced.
+func WithRequestCoalescing(policies ...CoalescingPolicy) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.coalescing = &coalescingConfig{policies: policies, calls: make(map[string]*coalescedCall)}
+	}
+}
+
+type coalescingConfig struct {
+	policies []CoalescingPolicy
+
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// policyFor returns the policy of the gRPC method, and whether its requests
+// are coalesced.
+func (c *coalescingConfig) policyFor(rpcMethodName string) (CoalescingPolicy, bool) {
+	if len(c.policies) == 0 {
+		return CoalescingPolicy{}, true
+	}
+	fullMethod := fullMethodName(rpcMethodName)
+	for _, p := range c.policies {
+		if selectorMatches(p.Selector, fullMethod) {
+			return p, !p.Disabled
+		}
+	}
+	return CoalescingPolicy{}, false
+}
+
+// keyHeaders returns the canonical names of the headers of r which are part of
+// the coalescing key of the policy.
+func (p CoalescingPolicy) keyHeaders(r *http.Request) []string {
+	headers := []string{acceptHeader, contentTypeHeader, "Authorization", "Cookie"}
+	for _, h := range p.Headers {
+		h = textproto.CanonicalMIMEHeaderKey(h)
+		if !containsString(headers, h) {
+			headers = append(headers, h)
+		}
+	}
+	var forwarded []string
+	for name := range r.Header {
+		if strings.HasPrefix(name, MetadataHeaderPrefix) && !containsString(headers, name) {
+			forwarded = append(forwarded, name)
+		}
+	}
+	sort.Strings(forwarded)
+	return append(headers, forwarded...)
+}
+
+// coalescedCall is a call of a handler shared by identical requests.
+type coalescedCall struct {
+	// done is closed once the response is recorded.
+	done   chan struct{}
+	cancel context.CancelFunc
+	// waiters is the number of requests waiting for the response, guarded by
+	// the mutex of the coalescingConfig.
+	waiters int
+	// leaderGone reports whether the request which started the call is gone,
+	// and panicked holds the value of the panic of its handler, both guarded
+	// by the mutex of the coalescingConfig.
+	leaderGone bool
+	panicked   interface{}
+
+	// shared reports whether the recorded response can be served to the
+	// other requests.
+	shared bool
+	status int
+	header http.Header
+	body   []byte
+}
+
+// serve serves the GET request r with the handler h, sharing its call with
+// the identical requests.
+func (c *coalescingConfig) serve(s *ServeMux, h handler, w http.ResponseWriter, r *http.Request, pathParams map[string]string, next func(http.ResponseWriter, *http.Request)) {
+	if !h.stats.unary() || requestAcceptsTrailers(r) {
+		next(w, r)
+		return
+	}
+	rpcMethod := h.stats.method()
+	policy, ok := c.policyFor(rpcMethod)
+	if !ok {
+		next(w, r)
+		return
+	}
+	ctx := r.Context()
+	key := requestCacheKey(h, r, pathParams, policy.keyHeaders(r))
+
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		call.waiters++
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			c.leave(key, call)
+			_, outboundMarshaler := MarshalerForRequest(s, r)
+			HTTPError(ctx, s, outboundMarshaler, w, r, status.FromContextError(ctx.Err()).Err())
+			return
+		}
+		if !call.shared {
+			next(w, r)
+			return
+		}
+		stats := requestStatsFromContext(ctx)
+		stats.setRPCMethod(rpcMethod)
+		stats.coalesced()
+		call.write(w)
+		return
+	}
+	// The shared call is only canceled once every request waiting for it is
+	// gone, starting with this one.
+	callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	call := &coalescedCall{done: make(chan struct{}), cancel: cancel, waiters: 1}
+	c.calls[key] = call
+	c.mu.Unlock()
+	stop := context.AfterFunc(ctx, func() { c.leave(key, call) })
+
+	// The shared call runs on its own, so that this request stops waiting for
+	// it like the others once its context is done. It does not read the body
+	// of the request, which is not part of the key, and is gone once the
+	// request is served.
+	shared := r.WithContext(callCtx)
+	shared.Body = http.NoBody
+	rec := &coalescingResponseWriter{header: make(http.Header)}
+	go func() {
+		defer c.finish(key, call, rec, stop)
+		defer c.recoverShared(call)
+		next(rec, shared)
+		rec.complete = true
+	}()
+	select {
+	case <-call.done:
+		if call.panicked != nil {
+			panic(call.panicked)
+		}
+		call.write(w)
+	case <-ctx.Done():
+		c.mu.Lock()
+		call.leaderGone = true
+		p := call.panicked
+		c.mu.Unlock()
+		if p != nil {
+			panic(p)
+		}
+		_, outboundMarshaler := MarshalerForRequest(s, r)
+		HTTPError(ctx, s, outboundMarshaler, w, r, status.FromContextError(ctx.Err()).Err())
+	}
+}
+
+// recoverShared recovers from a panic of the handler of the shared call. The
+// request which started the call panics again with its value, or it is logged
+// if the request is gone.
+func (c *coalescingConfig) recoverShared(call *coalescedCall) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if call.leaderGone {
+		grpclog.Errorf("Recovered from a panic of a coalesced call: %v\n%s", p, debug.Stack())
+		return
+	}
+	call.panicked = p
+}
+
+// finish records the response of the shared call, once its handler returned
+// or panicked, and wakes up the requests waiting for it.
+func (c *coalescingConfig) finish(key string, call *coalescedCall, rec *coalescingResponseWriter, stop func() bool) {
+	stop()
+	c.mu.Lock()
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+	c.mu.Unlock()
+	// The response of a handler which panicked or streamed its response is
+	// not shared, and the waiting requests call the handler themselves.
+	call.shared = rec.complete && !rec.flushed
+	call.status, call.header, call.body = rec.status, rec.header, rec.body.Bytes()
+	if call.status == 0 {
+		call.status = http.StatusOK
+	}
+	close(call.done)
+	call.cancel()
+}
+
+// leave records that a request stopped waiting for call, and cancels it if no
+// request is waiting anymore.
+func (c *coalescingConfig) leave(key string, call *coalescedCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	// The next identical request starts a new call.
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+	call.cancel()
+}
+
+// write writes a copy of the recorded response to w.
+func (call *coalescedCall) write(w http.ResponseWriter) {
+	for name, values := range call.header {
+		w.Header()[name] = append([]string(nil), values...)
+	}
+	w.WriteHeader(call.status)
+	if _, err := w.Write(call.body); err != nil {
+		grpclog.Errorf("Failed to write response: %v", err)
+	}
+}
+
+// coalescingResponseWriter records the response of a shared call.
+type coalescingResponseWriter struct {
+	header   http.Header
+	status   int
+	body     bytes.Buffer
+	flushed  bool
+	complete bool
+}
+
+func (w *coalescingResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *coalescingResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *coalescingResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.body.Write(b)
+}
+
+// Flush implements http.Flusher. Only streams are flushed, so the response is
+// not shared.
+func (w *coalescingResponseWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.flushed = true
+}
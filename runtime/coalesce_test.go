@@ -0,0 +1,209 @@
+package runtime_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// blockingUpstream counts its calls, which wait for release.
+type blockingUpstream struct {
+	calls    atomic.Int32
+	started  chan struct{}
+	release  chan struct{}
+	canceled atomic.Int32
+}
+
+func newBlockingUpstream() *blockingUpstream {
+	return &blockingUpstream{started: make(chan struct{}, 10), release: make(chan struct{})}
+}
+
+func (u *blockingUpstream) call(ctx context.Context, _ *http.Request, _ string) (proto.Message, metadata.MD, error) {
+	n := u.calls.Add(1)
+	u.started <- struct{}{}
+	select {
+	case <-u.release:
+	case <-ctx.Done():
+		u.canceled.Add(1)
+		return nil, nil, ctx.Err()
+	}
+	return wrapperspb.String("book " + strconv.Itoa(int(n))), nil, nil
+}
+
+func TestRequestCoalescing(t *testing.T) {
+	collector := &recordingMetricsCollector{}
+	mux := runtime.NewServeMux(runtime.WithRequestCoalescing(), runtime.WithMetricsCollector(collector))
+	upstream := newBlockingUpstream()
+	handleGenerated(t, mux, http.MethodGet, "/v1/books/{id}", "/example.Books/Get", upstream.call, nil)
+
+	// The route is coalesced once it forwarded a unary response.
+	close(upstream.release)
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/books/1", nil))
+	<-upstream.started
+	upstream.release = make(chan struct{})
+
+	serve := func(path string, header ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		for i := 0; i < len(header); i += 2 {
+			r.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+	var wg sync.WaitGroup
+	responses := make([]*httptest.ResponseRecorder, 6)
+	start := func(i int, path string, header ...string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i] = serve(path, header...)
+		}()
+	}
+	start(0, "/v1/books/1")
+	<-upstream.started
+	start(1, "/v1/books/1")
+	start(2, "/v1/books/1")
+	start(3, "/v1/books/1", "Authorization", "Bearer token")
+	start(4, "/v1/books/2")
+	start(5, "/v1/books/1", "Grpc-Metadata-Tenant", "acme")
+	<-upstream.started
+	<-upstream.started
+	<-upstream.started
+	// Wait for the identical requests to join the first one.
+	time.Sleep(50 * time.Millisecond)
+	close(upstream.release)
+	wg.Wait()
+
+	if got, want := upstream.calls.Load(), int32(5); got != want {
+		t.Errorf("calls = %d; want %d", got, want)
+	}
+	for i := 1; i < 3; i++ {
+		if responses[i].Code != http.StatusOK || responses[i].Body.String() != responses[0].Body.String() {
+			t.Errorf("response %d = %d %s; want %d %s", i, responses[i].Code, responses[i].Body, http.StatusOK, responses[0].Body)
+		}
+		if got, want := responses[i].Header().Get("Content-Type"), "application/json"; got != want {
+			t.Errorf("response %d: Content-Type = %q; want %q", i, got, want)
+		}
+	}
+	for _, i := range []int{3, 4, 5} {
+		if responses[i].Body.String() == responses[0].Body.String() {
+			t.Errorf("response %d = %s; want the response of another call", i, responses[i].Body)
+		}
+	}
+
+	coalesced := 0
+	for _, m := range collector.metrics {
+		if m.Coalesced {
+			coalesced++
+			if m.RPCMethod != "/example.Books/Get" {
+				t.Errorf("RequestMetrics.RPCMethod = %q; want %q", m.RPCMethod, "/example.Books/Get")
+			}
+		}
+	}
+	if coalesced != 2 {
+		t.Errorf("coalesced requests = %d; want 2", coalesced)
+	}
+}
+
+func TestRequestCoalescingCancel(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithRequestCoalescing(runtime.CoalescingPolicy{Selector: "example.Books.*"}))
+	upstream := newBlockingUpstream()
+	handleGenerated(t, mux, http.MethodGet, "/v1/books/{id}", "/example.Books/Get", upstream.call, nil)
+	close(upstream.release)
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/books/1", nil))
+	<-upstream.started
+	upstream.release = make(chan struct{})
+
+	serve := func(ctx context.Context) <-chan *httptest.ResponseRecorder {
+		done := make(chan *httptest.ResponseRecorder, 1)
+		go func() {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/books/1", nil).WithContext(ctx))
+			done <- w
+		}()
+		return done
+	}
+
+	// The shared call goes on when the first request is canceled, as long as
+	// another request waits for it.
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	first := serve(firstCtx)
+	<-upstream.started
+	secondCtx, cancelSecond := context.WithCancel(context.Background())
+	defer cancelSecond()
+	second := serve(secondCtx)
+	thirdCtx, cancelThird := context.WithCancel(context.Background())
+	third := serve(thirdCtx)
+	time.Sleep(50 * time.Millisecond)
+
+	cancelThird()
+	if w := <-third; w.Code != 499 {
+		t.Errorf("canceled request status = %d; want 499", w.Code)
+	}
+	// The first request stops waiting for the shared call it started once
+	// it is canceled, like the others.
+	cancelFirst()
+	select {
+	case w := <-first:
+		if w.Code != 499 {
+			t.Errorf("canceled first request status = %d; want 499", w.Code)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("the canceled first request still waits for the shared call")
+	}
+	close(upstream.release)
+	if w := <-second; w.Code != http.StatusOK || w.Body.String() != `"book 2"` {
+		t.Errorf("response = %d %s; want %d %s", w.Code, w.Body, http.StatusOK, `"book 2"`)
+	}
+	if got := upstream.canceled.Load(); got != 0 {
+		t.Errorf("canceled calls = %d; want 0", got)
+	}
+
+	// The shared call is canceled once every request is gone.
+	upstream.release = make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := serve(ctx)
+	<-upstream.started
+	cancel()
+	<-done
+	// The request does not wait for the shared call to return.
+	for deadline := time.Now().Add(time.Second); upstream.canceled.Load() == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	if got := upstream.canceled.Load(); got != 1 {
+		t.Errorf("canceled calls = %d; want 1", got)
+	}
+}
+
+func TestRequestCoalescingPanic(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithRequestCoalescing())
+	var calls atomic.Int32
+	handleGenerated(t, mux, http.MethodGet, "/v1/books/{id}", "/example.Books/Get", replyWith(func() (proto.Message, error) {
+		if calls.Add(1) > 1 {
+			panic("upstream failed")
+		}
+		return wrapperspb.String("book"), nil
+	}), nil)
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/books/1", nil))
+
+	// The panic of the shared call is raised again by the request which
+	// started it.
+	defer func() {
+		if p := recover(); p != "upstream failed" {
+			t.Errorf("recovered %v; want %q", p, "upstream failed")
+		}
+	}()
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/books/1", nil))
+	t.Errorf("mux.ServeHTTP(...) did not panic")
+}
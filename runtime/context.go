@@ -80,6 +80,7 @@ func AnnotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcM
 	if err != nil {
 		return nil, err
 	}
+	requestStatsFromContext(ctx).startUpstream(ctx)
 	if md == nil {
 		return ctx, nil
 	}
@@ -94,6 +95,7 @@ func AnnotateIncomingContext(ctx context.Context, mux *ServeMux, req *http.Reque
 	if err != nil {
 		return nil, err
 	}
+	requestStatsFromContext(ctx).startUpstream(ctx)
 	if md == nil {
 		return ctx, nil
 	}
@@ -135,6 +137,13 @@ func isValidGRPCMetadataTextValue(textValue string) bool {
 
 func annotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcMethodName string, options ...AnnotateContextOption) (context.Context, metadata.MD, error) {
 	ctx = withRPCMethod(ctx, rpcMethodName)
+	routeStatsFromContext(ctx).setRPCMethod(rpcMethodName)
+	if err := visibilityStateFromContext(ctx).checkMethod(rpcMethodName); err != nil {
+		return nil, nil, err
+	}
+	if err := bodyLimitStateFromContext(ctx).setRPCMethod(rpcMethodName); err != nil {
+		return nil, nil, err
+	}
 	for _, o := range options {
 		ctx = o(ctx)
 	}
@@ -154,6 +163,10 @@ func annotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcM
 			// Handled separately below
 			continue
 		}
+		if mux.requestID != nil && key == mux.requestID.header {
+			// Replaced by the request ID below
+			continue
+		}
 
 		for _, val := range vals {
 			// For backwards-compatibility, pass through 'authorization' header with no prefix.
@@ -197,17 +210,30 @@ func annotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcM
 	if len(xff) > 0 {
 		pairs = append(pairs, strings.ToLower(xForwardedFor), strings.Join(xff, ", "))
 	}
+	if id, ok := RequestIDFromContext(ctx); ok && mux.requestID != nil {
+		pairs = append(pairs, mux.requestID.metadataKey(), id)
+	}
 
 	if timeout != 0 {
 		ctx, _ = context.WithTimeout(ctx, timeout)
 	}
 	if len(pairs) == 0 {
+		if mux.rateLimit != nil {
+			if err := mux.rateLimit.check(ctx, req, rpcMethodName, nil); err != nil {
+				return nil, nil, err
+			}
+		}
 		return ctx, nil, nil
 	}
 	md := metadata.Pairs(pairs...)
 	for _, mda := range mux.metadataAnnotators {
 		md = metadata.Join(md, mda(ctx, req))
 	}
+	if mux.rateLimit != nil {
+		if err := mux.rateLimit.check(ctx, req, rpcMethodName, md); err != nil {
+			return nil, nil, err
+		}
+	}
 	return ctx, md, nil
 }
 
@@ -224,6 +250,7 @@ func NewServerMetadataContext(ctx context.Context, md ServerMetadata) context.Co
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	requestStatsFromContext(ctx).endUpstream()
 	return context.WithValue(ctx, serverMetadataKey{}, md)
 }
 
@@ -0,0 +1,253 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// DefaultDrainRetryDelay is the delay after which clients are told to retry
+// the requests rejected or interrupted by ServeMux.Drain, unless
+// WithDrainRetryDelay is given to the ServeMux.
+const DefaultDrainRetryDelay = time.Second
+
+var (
+	// errDraining is the cause of the cancellation of the streams interrupted
+	// by ServeMux.Drain.
+	errDraining = errors.New("runtime: ServeMux is draining")
+	// errDrainGracePeriodExpired is the cause of the cancellation of the
+	// requests still in flight at the end of the grace period of ServeMux.Drain.
+	errDrainGracePeriodExpired = errors.New("runtime: ServeMux drain grace period expired")
+)
+
+// WithDrainRetryDelay returns a ServeMuxOption setting the delay after which
+// clients are told to retry the requests rejected or interrupted by
+// ServeMux.Drain, in the Retry-After header and in a google.rpc.RetryInfo
+// error detail. The default is DefaultDrainRetryDelay.
+func WithDrainRetryDelay(delay time.Duration) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.drain.retryDelay = delay
+	}
+}
+
+// WithDisableDrainCancellation returns a ServeMuxOption stopping the ServeMux
+// from tracking the context of every request, which ServeMux.Drain needs to
+// interrupt the response streams and cancel the requests still in flight at
+// the end of its grace period. With it, Drain rejects the new requests and
+// waits for those in flight to complete.
+func WithDisableDrainCancellation() ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.drain.cancellation = false
+	}
+}
+
+// InFlightCounts is the number of requests handled by the routes of a ServeMux.
+type InFlightCounts struct {
+	// Requests is the number of requests, including streams.
+	Requests int
+	// Streams is the number of requests forwarding a response stream.
+	Streams int
+}
+
+// drainer counts the requests handled by the routes of a ServeMux, and tracks
+// them unless WithDisableDrainCancellation is given, so that they can be
+// interrupted by ServeMux.Drain.
+type drainer struct {
+	retryDelay   time.Duration
+	cancellation bool
+	draining     atomic.Bool
+
+	requests, streams atomic.Int64
+
+	mu sync.Mutex
+	// cancels holds the requests in flight, unless cancellation is disabled.
+	cancels map[*inFlightRequest]struct{}
+	idle    chan struct{}
+}
+
+type inFlightRequest struct {
+	cancel context.CancelCauseFunc
+	stream bool
+}
+
+type inFlightRequestKey struct{}
+
+func newDrainer() *drainer {
+	return &drainer{retryDelay: DefaultDrainRetryDelay, cancellation: true}
+}
+
+// track counts a request, and returns its context, which is canceled when the
+// request is interrupted, and the request to give to done once it is handled.
+// It returns false if the ServeMux is draining.
+func (d *drainer) track(ctx context.Context) (context.Context, *inFlightRequest, bool) {
+	d.requests.Add(1)
+	if d.draining.Load() {
+		d.done(nil)
+		return ctx, nil, false
+	}
+	if !d.cancellation {
+		return ctx, nil, true
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	r := &inFlightRequest{cancel: cancel}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancels == nil {
+		d.cancels = make(map[*inFlightRequest]struct{})
+	}
+	d.cancels[r] = struct{}{}
+	return context.WithValue(ctx, inFlightRequestKey{}, r), r, true
+}
+
+// done records that the request r returned by track is handled.
+func (d *drainer) done(r *inFlightRequest) {
+	if r != nil {
+		d.mu.Lock()
+		delete(d.cancels, r)
+		d.mu.Unlock()
+		r.cancel(nil)
+	}
+	if d.requests.Add(-1) != 0 || !d.draining.Load() {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.idle != nil {
+		select {
+		case <-d.idle:
+		default:
+			close(d.idle)
+		}
+	}
+}
+
+// startStream marks the request of ctx as forwarding a response stream, which
+// is interrupted as soon as the ServeMux drains, and returns a function to
+// call once the stream ends.
+func (d *drainer) startStream(ctx context.Context) func() {
+	d.streams.Add(1)
+	if r, ok := ctx.Value(inFlightRequestKey{}).(*inFlightRequest); ok {
+		d.mu.Lock()
+		r.stream = true
+		d.mu.Unlock()
+		if d.draining.Load() {
+			r.cancel(errDraining)
+		}
+	}
+	return func() { d.streams.Add(-1) }
+}
+
+func (d *drainer) inFlight() InFlightCounts {
+	return InFlightCounts{
+		Requests: int(d.requests.Load()),
+		Streams:  int(d.streams.Load()),
+	}
+}
+
+// unavailable returns the error of the requests rejected or interrupted
+// because the ServeMux is draining.
+func (d *drainer) unavailable() error {
+	st := status.New(codes.Unavailable, "server is shutting down")
+	if withDelay, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(d.retryDelay)}); err == nil {
+		st = withDelay
+	}
+	return st.Err()
+}
+
+// convert returns the error to report for err, replacing the cancellations
+// caused by Drain with an Unavailable error.
+func (d *drainer) convert(ctx context.Context, err error) error {
+	if ctx == nil || status.Code(err) != codes.Canceled {
+		return err
+	}
+	if cause := context.Cause(ctx); cause != errDraining && cause != errDrainGracePeriodExpired {
+		return err
+	}
+	return d.unavailable()
+}
+
+// rejectDraining writes the response of the requests received while the ServeMux is draining.
+func (s *ServeMux) rejectDraining(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Connection", "close")
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Max(1, math.Ceil(s.drain.retryDelay.Seconds())))))
+	_, outboundMarshaler := MarshalerForRequest(s, r)
+	s.errorHandler(ctx, s, outboundMarshaler, w, r, s.drain.unavailable())
+}
+
+// Drain gracefully stops the ServeMux:
+//   - New requests are rejected with 503 Service Unavailable and the
+//     Connection: close header, so that clients and load balancers move to
+//     other instances.
+//   - Response streams are interrupted at once, and end with an Unavailable
+//     error chunk carrying a google.rpc.RetryInfo detail.
+//   - Other requests are given until ctx is done to complete. The contexts of
+//     those still in flight are then canceled, which cancels their calls to
+//     the gRPC server, and they fail with Unavailable.
+//
+// With WithDisableDrainCancellation, Drain only rejects the new requests and
+// waits for those in flight.
+//
+// Drain returns once no request is in flight, or ctx.Err() if ctx is done
+// first. It is typically called before http.Server.Shutdown, which does not
+// interrupt streams:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	_ = mux.Drain(ctx)
+//	_ = server.Shutdown(ctx)
+//
+// A ServeMux cannot be used again once it has drained.
+func (s *ServeMux) Drain(ctx context.Context) error {
+	d := s.drain
+	d.draining.Store(true)
+	d.mu.Lock()
+	for r := range d.cancels {
+		if r.stream {
+			r.cancel(errDraining)
+		}
+	}
+	if d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	if d.requests.Load() == 0 {
+		select {
+		case <-d.idle:
+		default:
+			close(d.idle)
+		}
+	}
+	idle := d.idle
+	d.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		d.mu.Lock()
+		for r := range d.cancels {
+			r.cancel(errDrainGracePeriodExpired)
+		}
+		d.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Draining reports whether Drain has been called.
+func (s *ServeMux) Draining() bool {
+	return s.drain.draining.Load()
+}
+
+// InFlight returns the number of requests being handled by the routes of the ServeMux.
+func (s *ServeMux) InFlight() InFlightCounts {
+	return s.drain.inFlight()
+}
@@ -0,0 +1,177 @@
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// handleBlocking registers a route forwarding a response stream if stream is
+// true, or a unary response otherwise, whose upstream call blocks until its
+// context is canceled.
+func handleBlocking(t *testing.T, mux *runtime.ServeMux, pattern string, stream bool) {
+	t.Helper()
+	err := mux.HandlePath(http.MethodGet, pattern, func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		ctx, err := runtime.AnnotateContext(req.Context(), mux, req, "/example.Echo/Block", runtime.WithHTTPPathPattern(pattern))
+		if err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+		ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+		if !stream {
+			<-ctx.Done()
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, status.FromContextError(ctx.Err()).Err())
+			return
+		}
+		sent := false
+		runtime.ForwardResponseStream(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) {
+			if !sent {
+				sent = true
+				return wrapperspb.String("first"), nil
+			}
+			<-ctx.Done()
+			return nil, status.FromContextError(ctx.Err()).Err()
+		})
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath(%q) failed with %v", pattern, err)
+	}
+}
+
+func waitInFlight(t *testing.T, mux *runtime.ServeMux, want runtime.InFlightCounts) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for mux.InFlight() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("mux.InFlight() = %+v; want %+v", mux.InFlight(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func serveAsync(mux *runtime.ServeMux, target string) (*httptest.ResponseRecorder, <-chan struct{}) {
+	w := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	}()
+	return w, done
+}
+
+func TestServeMuxDrainStreams(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithDrainRetryDelay(2500 * time.Millisecond))
+	handleBlocking(t, mux, "/v1/stream", true)
+
+	w, done := serveAsync(mux, "/v1/stream")
+	waitInFlight(t, mux, runtime.InFlightCounts{Requests: 1, Streams: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := mux.Drain(ctx); err != nil {
+		t.Fatalf("mux.Drain(ctx) failed with %v", err)
+	}
+	<-done
+	if !mux.Draining() {
+		t.Errorf("mux.Draining() = false; want true")
+	}
+	if got := mux.InFlight(); got != (runtime.InFlightCounts{}) {
+		t.Errorf("mux.InFlight() = %+v; want none", got)
+	}
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d chunks; want 2: %s", len(lines), w.Body.String())
+	}
+	var chunk struct {
+		Error struct {
+			Code    codes.Code
+			Details []map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &chunk); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed with %v", lines[1], err)
+	}
+	if chunk.Error.Code != codes.Unavailable {
+		t.Errorf("error chunk code = %v; want %v", chunk.Error.Code, codes.Unavailable)
+	}
+	if len(chunk.Error.Details) != 1 || chunk.Error.Details[0]["retryDelay"] != "2.500s" {
+		t.Errorf("error chunk details = %v; want a RetryInfo of 2.5s", chunk.Error.Details)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/stream", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status after drain = %d; want %d", w.Code, http.StatusServiceUnavailable)
+	}
+	if got := w.Header().Get("Connection"); got != "close" {
+		t.Errorf("Connection = %q; want close", got)
+	}
+	if got := w.Header().Get("Retry-After"); got != "3" {
+		t.Errorf("Retry-After = %q; want 3", got)
+	}
+}
+
+func TestServeMuxDrainGracePeriod(t *testing.T) {
+	mux := runtime.NewServeMux()
+	handleBlocking(t, mux, "/v1/unary", false)
+
+	w, done := serveAsync(mux, "/v1/unary")
+	waitInFlight(t, mux, runtime.InFlightCounts{Requests: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := mux.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("mux.Drain(ctx) = %v; want %v", err, context.DeadlineExceeded)
+	}
+	<-done
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d; want %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestServeMuxDrainWithoutCancellation(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithDisableDrainCancellation())
+	entered, release := make(chan struct{}), make(chan struct{})
+	if err := mux.HandlePath(http.MethodGet, "/v1/unary", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		close(entered)
+		<-release
+		if err := r.Context().Err(); err != nil {
+			t.Errorf("request context canceled with %v; want it untouched by Drain", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("mux.HandlePath(...) failed with %v", err)
+	}
+
+	w, done := serveAsync(mux, "/v1/unary")
+	<-entered
+	if got, want := mux.InFlight(), (runtime.InFlightCounts{Requests: 1}); got != want {
+		t.Errorf("mux.InFlight() = %+v; want %+v", got, want)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := mux.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("mux.Drain(ctx) = %v; want %v", err, context.DeadlineExceeded)
+	}
+	close(release)
+	<-done
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d; want %d", w.Code, http.StatusOK)
+	}
+	if err := mux.Drain(context.Background()); err != nil {
+		t.Errorf("mux.Drain(ctx) once idle = %v; want nil", err)
+	}
+}
@@ -0,0 +1,153 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor/apiconfig"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// FilesFromDescriptorSet returns the files of a serialized
+// google.protobuf.FileDescriptorSet, as written by
+// "protoc --include_imports --descriptor_set_out" or "buf build -o".
+func FilesFromDescriptorSet(data []byte) (*protoregistry.Files, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("failed to parse FileDescriptorSet: %w", err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FileDescriptorSet: %w", err)
+	}
+	return files, nil
+}
+
+// FilesFromReflection returns the files of the services of the gRPC server
+// of conn, and their dependencies, fetched with the grpc.reflection.v1
+// server reflection service.
+func FilesFromReflection(ctx context.Context, conn grpc.ClientConnInterface) (*protoregistry.Files, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start server reflection: %w", err)
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+
+	call := func(req *reflectionpb.ServerReflectionRequest) (*reflectionpb.ServerReflectionResponse, error) {
+		if err := stream.Send(req); err != nil {
+			return nil, fmt.Errorf("server reflection: %w", err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("server reflection: %w", err)
+		}
+		if e := resp.GetErrorResponse(); e != nil {
+			return nil, fmt.Errorf("server reflection: %s (code %d)", e.GetErrorMessage(), e.GetErrorCode())
+		}
+		return resp, nil
+	}
+
+	fds := make(map[string]*descriptorpb.FileDescriptorProto)
+	addFiles := func(resp *reflectionpb.ServerReflectionResponse) error {
+		for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(b, fd); err != nil {
+				return fmt.Errorf("server reflection: invalid file descriptor: %w", err)
+			}
+			fds[fd.GetName()] = fd
+		}
+		return nil
+	}
+
+	resp, err := call(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{ListServices: "*"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		resp, err := call(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: svc.GetName()},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := addFiles(resp); err != nil {
+			return nil, err
+		}
+	}
+	// Servers may omit the dependencies they already sent.
+	for {
+		var missing []string
+		for _, fd := range fds {
+			for _, dep := range fd.GetDependency() {
+				if _, ok := fds[dep]; !ok {
+					missing = append(missing, dep)
+				}
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+		for _, name := range missing {
+			if _, ok := fds[name]; ok {
+				continue
+			}
+			resp, err := call(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := addFiles(resp); err != nil {
+				return nil, err
+			}
+			if _, ok := fds[name]; !ok {
+				return nil, fmt.Errorf("server reflection: file %q not found", name)
+			}
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range fds {
+		set.File = append(set.File, fd)
+	}
+	sort.Slice(set.File, func(i, j int) bool {
+		return set.File[i].GetName() < set.File[j].GetName()
+	})
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("server reflection: invalid file descriptors: %w", err)
+	}
+	return files, nil
+}
+
+// LoadGrpcAPIConfiguration returns the HTTP rules of a gRPC API
+// configuration in YAML, to be given to WithHTTPRules. See
+// https://grpc-ecosystem.github.io/grpc-gateway/docs/mapping/grpc_api_configuration/.
+func LoadGrpcAPIConfiguration(data []byte) ([]*annotations.HttpRule, error) {
+	service, err := apiconfig.LoadFromYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gRPC API Configuration from YAML: %w", err)
+	}
+
+	rules := service.GetHttp().GetRules()
+	for _, rule := range rules {
+		if strings.ContainsAny(strings.TrimSpace(rule.GetSelector()), "*, ") {
+			return nil, fmt.Errorf("selector %q must specify a single service method without wildcards", rule.GetSelector())
+		}
+	}
+	return rules, nil
+}
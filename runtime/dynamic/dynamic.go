@@ -0,0 +1,370 @@
+// Package dynamic builds the handlers of a runtime.ServeMux at runtime from
+// protobuf service descriptors and their google.api.http annotations, without
+// code generation.
+//
+// The handlers behave like the ones generated by protoc-gen-grpc-gateway:
+// they bind the path, query and body of HTTP requests to dynamicpb request
+// messages, call the gRPC methods with grpc.ClientConnInterface.Invoke or
+// NewStream, and forward their responses with runtime.ForwardResponseMessage
+// or runtime.ForwardResponseStream. Like the handlers generated with the
+// enforce_field_behavior option, they enforce the google.api.field_behavior
+// annotations of the request messages when the ServeMux is given
+// runtime.WithFieldBehavior, and they retry unary calls as configured by
+// runtime.WithRetryPolicy. The descriptors can be read from a
+// FileDescriptorSet with FilesFromDescriptorSet, or fetched from a gRPC server
+// with FilesFromReflection:
+//
+//	conn, err := grpc.NewClient(backend, grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	...
+//	files, err := dynamic.FilesFromReflection(ctx, conn)
+//	...
+//	mux := runtime.NewServeMux()
+//	if err := dynamic.Register(mux, conn, files); err != nil {
+//		...
+//	}
+package dynamic
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/httprule"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Option is an option that can be given to Register.
+type Option func(*config)
+
+type config struct {
+	services        map[protoreflect.FullName]bool
+	rules           map[protoreflect.FullName][]*annotations.HttpRule
+	unboundMethods  bool
+	allowDeleteBody bool
+}
+
+// WithServices restricts the registered handlers to the given services. By
+// default, the handlers of every service are registered.
+func WithServices(names ...protoreflect.FullName) Option {
+	return func(c *config) {
+		if c.services == nil {
+			c.services = make(map[protoreflect.FullName]bool)
+		}
+		for _, name := range names {
+			c.services[name] = true
+		}
+	}
+}
+
+// WithHTTPRules adds HTTP rules to the methods selected by their selector,
+// like the rules of a gRPC API configuration, which are used in addition to
+// the google.api.http annotations of the methods.
+func WithHTTPRules(rules ...*annotations.HttpRule) Option {
+	return func(c *config) {
+		if c.rules == nil {
+			c.rules = make(map[protoreflect.FullName][]*annotations.HttpRule)
+		}
+		for _, rule := range rules {
+			selector := protoreflect.FullName(strings.TrimPrefix(strings.TrimSpace(rule.GetSelector()), "."))
+			c.rules[selector] = append(c.rules[selector], rule)
+		}
+	}
+}
+
+// WithUnboundMethods registers the methods without HTTP rule at
+// "POST /package.Service/Method", with the request message as body, like the
+// generate_unbound_methods option of protoc-gen-grpc-gateway.
+func WithUnboundMethods() Option {
+	return func(c *config) {
+		c.unboundMethods = true
+	}
+}
+
+// WithAllowDeleteBody allows HTTP rules with the DELETE method to have a
+// body, like the allow_delete_body option of protoc-gen-grpc-gateway.
+func WithAllowDeleteBody() Option {
+	return func(c *config) {
+		c.allowDeleteBody = true
+	}
+}
+
+// Register registers on mux the handlers of the HTTP rules of the methods of
+// the services of files, which forward requests to conn.
+//
+// No handler is registered if an HTTP rule is invalid.
+func Register(mux *runtime.ServeMux, conn grpc.ClientConnInterface, files *protoregistry.Files, opts ...Option) error {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	var (
+		bindings []*binding
+		err      error
+	)
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			sd := services.Get(i)
+			if c.services != nil && !c.services[sd.FullName()] {
+				continue
+			}
+			var bs []*binding
+			bs, err = c.serviceBindings(sd)
+			if err != nil {
+				return false
+			}
+			bindings = append(bindings, bs...)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, b := range bindings {
+		mux.HandleRPC(b.httpMethod, b.pattern, b.fullMethod, b.handler(mux, conn))
+	}
+	return nil
+}
+
+func (c *config) serviceBindings(sd protoreflect.ServiceDescriptor) ([]*binding, error) {
+	var bindings []*binding
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		rules := append([]*annotations.HttpRule(nil), c.rules[md.FullName()]...)
+		if rule, ok := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule); ok && rule != nil {
+			rules = append(rules, rule)
+		}
+		if len(rules) == 0 && c.unboundMethods {
+			rules = append(rules, &annotations.HttpRule{
+				Pattern: &annotations.HttpRule_Post{Post: fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())},
+				Body:    "*",
+			})
+		}
+
+		for _, rule := range rules {
+			b, err := c.newBinding(md, rule)
+			if err != nil {
+				return nil, err
+			}
+			if b != nil {
+				bindings = append(bindings, b)
+			}
+			for _, additional := range rule.GetAdditionalBindings() {
+				if len(additional.GetAdditionalBindings()) > 0 {
+					return nil, fmt.Errorf("additional_binding in additional_binding not allowed: %s", md.FullName())
+				}
+				b, err := c.newBinding(md, additional)
+				if err != nil {
+					return nil, err
+				}
+				if b != nil {
+					bindings = append(bindings, b)
+				}
+			}
+		}
+	}
+	return bindings, nil
+}
+
+// binding is an HTTP rule of a gRPC method.
+type binding struct {
+	method     protoreflect.MethodDescriptor
+	fullMethod string
+	streamDesc *grpc.StreamDesc
+	input      protoreflect.MessageType
+	output     protoreflect.MessageType
+
+	httpMethod string
+	pattern    runtime.Pattern
+	template   string
+	pathParams []pathParam
+
+	hasBody      bool
+	bodyPath     string
+	body         []protoreflect.FieldDescriptor
+	fieldMask    protoreflect.FieldDescriptor
+	responseBody []protoreflect.FieldDescriptor
+
+	hasQueryParam bool
+	queryFilter   *utilities.DoubleArray
+}
+
+type pathParam struct {
+	name   string
+	fields []protoreflect.FieldDescriptor
+}
+
+func (c *config) newBinding(md protoreflect.MethodDescriptor, rule *annotations.HttpRule) (*binding, error) {
+	var httpMethod, pathTemplate string
+	switch {
+	case rule.GetGet() != "":
+		httpMethod, pathTemplate = http.MethodGet, rule.GetGet()
+		if rule.GetBody() != "" {
+			return nil, fmt.Errorf("must not set request body when http method is GET: %s", md.FullName())
+		}
+	case rule.GetPut() != "":
+		httpMethod, pathTemplate = http.MethodPut, rule.GetPut()
+	case rule.GetPost() != "":
+		httpMethod, pathTemplate = http.MethodPost, rule.GetPost()
+	case rule.GetDelete() != "":
+		httpMethod, pathTemplate = http.MethodDelete, rule.GetDelete()
+		if rule.GetBody() != "" && !c.allowDeleteBody {
+			return nil, fmt.Errorf("must not set request body when http method is DELETE except allow_delete_body option is true: %s", md.FullName())
+		}
+	case rule.GetPatch() != "":
+		httpMethod, pathTemplate = http.MethodPatch, rule.GetPatch()
+	case rule.GetCustom() != nil:
+		httpMethod, pathTemplate = rule.GetCustom().GetKind(), rule.GetCustom().GetPath()
+	default:
+		return nil, nil
+	}
+
+	parsed, err := httprule.Parse(pathTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", md.FullName(), err)
+	}
+	tmpl := parsed.Compile()
+	if md.IsStreamingClient() && len(tmpl.Fields) > 0 {
+		return nil, fmt.Errorf("cannot use path parameter in client streaming: %s", md.FullName())
+	}
+	pattern, err := runtime.NewPattern(tmpl.Version, tmpl.OpCodes, tmpl.Pool, tmpl.Verb)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", md.FullName(), err)
+	}
+
+	b := &binding{
+		method:     md,
+		fullMethod: fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name()),
+		streamDesc: &grpc.StreamDesc{
+			StreamName:    string(md.Name()),
+			ServerStreams: md.IsStreamingServer(),
+			ClientStreams: md.IsStreamingClient(),
+		},
+		input:      messageType(md.Input()),
+		output:     messageType(md.Output()),
+		httpMethod: httpMethod,
+		pattern:    pattern,
+		template:   tmpl.Template,
+	}
+
+	var seqs [][]string
+	for _, name := range tmpl.Fields {
+		fields, err := resolveFieldPath(md.Input(), name)
+		if err != nil {
+			return nil, fmt.Errorf("path parameter of %s: %w", md.FullName(), err)
+		}
+		b.pathParams = append(b.pathParams, pathParam{name: name, fields: fields})
+		seqs = append(seqs, strings.Split(name, "."))
+	}
+
+	b.bodyPath = rule.GetBody()
+	switch body := rule.GetBody(); body {
+	case "":
+	case "*":
+		b.hasBody = true
+	default:
+		b.hasBody = true
+		if b.body, err = resolveFieldPath(md.Input(), body); err != nil {
+			return nil, fmt.Errorf("body of %s: %w", md.FullName(), err)
+		}
+		if err := checkBodyField(b.body[len(b.body)-1]); err != nil {
+			return nil, fmt.Errorf("body of %s: %w", md.FullName(), err)
+		}
+		seqs = append(seqs, strings.Split(body, "."))
+		if httpMethod == http.MethodPatch {
+			b.fieldMask = fieldMaskField(md.Input())
+		}
+	}
+	if responseBody := rule.GetResponseBody(); responseBody != "" {
+		if b.responseBody, err = resolveFieldPath(md.Output(), responseBody); err != nil {
+			return nil, fmt.Errorf("response body of %s: %w", md.FullName(), err)
+		}
+	}
+
+	b.hasQueryParam = hasQueryParam(md.Input(), rule.GetBody(), tmpl.Fields)
+	b.queryFilter = utilities.NewDoubleArray(seqs)
+	return b, nil
+}
+
+// resolveFieldPath returns the fields of the dot-separated path in msg.
+func resolveFieldPath(msg protoreflect.MessageDescriptor, path string) ([]protoreflect.FieldDescriptor, error) {
+	var fields []protoreflect.FieldDescriptor
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 {
+			prev := fields[i-1]
+			if prev.Message() == nil || prev.Cardinality() == protoreflect.Repeated {
+				return nil, fmt.Errorf("%q is not a singular message field of %s", prev.Name(), msg.FullName())
+			}
+			msg = prev.Message()
+		}
+		fd := msg.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("no field %q found in %s", path, msg.FullName())
+		}
+		fields = append(fields, fd)
+	}
+	return fields, nil
+}
+
+// hasQueryParam reports whether some fields of msg may be bound to query
+// parameters, like the generated handlers.
+func hasQueryParam(msg protoreflect.MessageDescriptor, body string, pathParams []string) bool {
+	if body == "*" {
+		return false
+	}
+	bound := map[string]bool{body: true}
+	for _, p := range pathParams {
+		bound[p] = true
+	}
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if !bound[string(fields.Get(i).Name())] {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldMaskField returns the google.protobuf.FieldMask field of msg updated
+// by PATCH requests, if it has exactly one.
+func fieldMaskField(msg protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	var found protoreflect.FieldDescriptor
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() != nil && fd.Message().FullName() == "google.protobuf.FieldMask" {
+			if found != nil {
+				return nil
+			}
+			found = fd
+		}
+	}
+	return found
+}
+
+// linkedPackages are the packages whose messages are passed to the
+// marshalers as generated Go types rather than dynamic messages, since the
+// runtime handles some of them specially, like google.api.HttpBody.
+var linkedPackages = []string{"google.api.", "google.protobuf.", "google.rpc."}
+
+// messageType returns the type of the request or response messages of a method.
+func messageType(md protoreflect.MessageDescriptor) protoreflect.MessageType {
+	for _, pkg := range linkedPackages {
+		if strings.HasPrefix(string(md.FullName()), pkg) {
+			if mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err == nil {
+				return mt
+			}
+		}
+	}
+	return dynamicpb.NewMessageType(md)
+}
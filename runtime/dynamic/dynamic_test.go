@@ -0,0 +1,249 @@
+package dynamic_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/dynamic"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// libraryFiles returns the files of a library.Library service, which has no
+// HTTP rule, through FilesFromDescriptorSet.
+func libraryFiles(t *testing.T) *protoregistry.Files {
+	t.Helper()
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("library.proto"),
+		Package: proto.String("library"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("ListBooksRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("shelves", 1, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("page", 2, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+			},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Library"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("ListBooks"),
+				InputType:  proto.String(".library.ListBooksRequest"),
+				OutputType: proto.String(".library.ListBooksRequest"),
+			}},
+		}},
+	}}}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatalf("proto.Marshal(set) failed with %v", err)
+	}
+	files, err := dynamic.FilesFromDescriptorSet(data)
+	if err != nil {
+		t.Fatalf("dynamic.FilesFromDescriptorSet(...) failed with %v", err)
+	}
+	return files
+}
+
+// echoConn is a grpc.ClientConnInterface answering the unary calls with their
+// request, and recording their method.
+type echoConn struct {
+	method string
+}
+
+func (c *echoConn) Invoke(_ context.Context, method string, args, reply interface{}, _ ...grpc.CallOption) error {
+	c.method = method
+	proto.Merge(reply.(proto.Message), args.(proto.Message))
+	return nil
+}
+
+func (c *echoConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streams are not supported")
+}
+
+// countingQueryParser is a runtime.QueryParameterParser counting its calls.
+type countingQueryParser struct {
+	runtime.DefaultQueryParser
+	calls int
+}
+
+func (p *countingQueryParser) Parse(msg proto.Message, values url.Values, filter *utilities.DoubleArray) error {
+	p.calls++
+	return p.DefaultQueryParser.Parse(msg, values, filter)
+}
+
+func TestRegister(t *testing.T) {
+	rules, err := dynamic.LoadGrpcAPIConfiguration([]byte(`
+type: google.api.Service
+config_version: 3
+http:
+  rules:
+  - selector: library.Library.ListBooks
+    get: /v1/shelves/{shelves}/books
+`))
+	if err != nil {
+		t.Fatalf("dynamic.LoadGrpcAPIConfiguration(...) failed with %v", err)
+	}
+	parser := &countingQueryParser{}
+	mux := runtime.NewServeMux(runtime.SetQueryParameterParser(parser))
+	conn := &echoConn{}
+	if err := dynamic.Register(mux, conn, libraryFiles(t), dynamic.WithHTTPRules(rules...)); err != nil {
+		t.Fatalf("dynamic.Register(...) failed with %v", err)
+	}
+
+	for _, spec := range []struct {
+		name, method, target, body string
+		wantBody                   string
+		wantParses                 int
+	}{
+		{
+			name:       "repeated path parameter",
+			method:     http.MethodGet,
+			target:     "/v1/shelves/a,b/books?page=2",
+			wantBody:   `{"shelves":["a","b"],"page":2}`,
+			wantParses: 2,
+		},
+		{
+			name:       "query parameter",
+			method:     http.MethodGet,
+			target:     "/v1/shelves/a/books?page=3",
+			wantBody:   `{"shelves":["a"],"page":3}`,
+			wantParses: 2,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			parser.calls = 0
+			r := httptest.NewRequest(spec.method, spec.target, strings.NewReader(spec.body))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d; want %d: %s", w.Code, http.StatusOK, w.Body)
+			}
+			if got, want := conn.method, "/library.Library/ListBooks"; got != want {
+				t.Errorf("method = %q; want %q", got, want)
+			}
+			if got := strings.Join(strings.Fields(w.Body.String()), ""); got != spec.wantBody {
+				t.Errorf("body = %s; want %s", got, spec.wantBody)
+			}
+			// The repeated path parameters are parsed by the parser of the
+			// ServeMux, like the query.
+			if parser.calls != spec.wantParses {
+				t.Errorf("query parser calls = %d; want %d", parser.calls, spec.wantParses)
+			}
+		})
+	}
+}
+
+func TestRegisterUnboundMethods(t *testing.T) {
+	for _, spec := range []struct {
+		name     string
+		opts     []dynamic.Option
+		wantCode int
+	}{
+		{name: "unbound methods", opts: []dynamic.Option{dynamic.WithUnboundMethods()}, wantCode: http.StatusOK},
+		{name: "no unbound methods", wantCode: http.StatusNotFound},
+		{name: "other service", opts: []dynamic.Option{dynamic.WithUnboundMethods(), dynamic.WithServices("library.Other")}, wantCode: http.StatusNotFound},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux()
+			if err := dynamic.Register(mux, &echoConn{}, libraryFiles(t), spec.opts...); err != nil {
+				t.Fatalf("dynamic.Register(...) failed with %v", err)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/library.Library/ListBooks", strings.NewReader(`{"shelves":["c"]}`)))
+			if w.Code != spec.wantCode {
+				t.Errorf("status = %d; want %d", w.Code, spec.wantCode)
+			}
+		})
+	}
+}
+
+func TestRegisterInvalidRule(t *testing.T) {
+	mux := runtime.NewServeMux()
+	rules, err := dynamic.LoadGrpcAPIConfiguration([]byte(`
+http:
+  rules:
+  - selector: library.Library.ListBooks
+    get: /v1/books/{unknown}
+`))
+	if err != nil {
+		t.Fatalf("dynamic.LoadGrpcAPIConfiguration(...) failed with %v", err)
+	}
+	if err := dynamic.Register(mux, &echoConn{}, libraryFiles(t), dynamic.WithHTTPRules(rules...)); err == nil {
+		t.Errorf("dynamic.Register(...) with a path parameter of no field succeeded; want an error")
+	}
+}
+
+func TestLoadGrpcAPIConfiguration(t *testing.T) {
+	rules, err := dynamic.LoadGrpcAPIConfiguration([]byte(`
+type: google.api.Service
+config_version: 3
+name: ignored.example.com
+http:
+  rules:
+  - selector: library.Library.ListBooks
+    get: /v1/books
+    additional_bindings:
+    - post: /v1/books:list
+      body: "*"
+`))
+	if err != nil {
+		t.Fatalf("dynamic.LoadGrpcAPIConfiguration(...) failed with %v", err)
+	}
+	if len(rules) != 1 || rules[0].GetGet() != "/v1/books" || len(rules[0].GetAdditionalBindings()) != 1 || rules[0].GetAdditionalBindings()[0].GetBody() != "*" {
+		t.Errorf("rules = %v; want a GET rule with a POST additional binding", rules)
+	}
+
+	for _, spec := range []struct {
+		name, yaml, wantErr string
+	}{
+		{
+			name:    "wildcard selector",
+			yaml:    "http:\n  rules:\n  - selector: library.Library.*\n    get: /v1/books\n",
+			wantErr: `selector "library.Library.*" must specify a single service method without wildcards`,
+		},
+		{
+			name:    "invalid YAML",
+			yaml:    "http:\n  rules:\n  - selector: a\n   - get: b\n",
+			wantErr: "failed to parse gRPC API Configuration from YAML",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			if _, err := dynamic.LoadGrpcAPIConfiguration([]byte(spec.yaml)); err == nil || !strings.Contains(err.Error(), spec.wantErr) {
+				t.Errorf("dynamic.LoadGrpcAPIConfiguration(...) = %v; want error %q", err, spec.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilesFromDescriptorSet(t *testing.T) {
+	d, err := libraryFiles(t).FindDescriptorByName("library.Library.ListBooks")
+	if err != nil {
+		t.Fatalf("files.FindDescriptorByName(...) failed with %v", err)
+	}
+	if got, want := d.FullName(), protoreflect.FullName("library.Library.ListBooks"); got != want {
+		t.Errorf("descriptor = %q; want %q", got, want)
+	}
+	if _, err := dynamic.FilesFromDescriptorSet([]byte("not a descriptor set")); err == nil {
+		t.Errorf("dynamic.FilesFromDescriptorSet(...) succeeded on invalid data; want an error")
+	}
+}
@@ -0,0 +1,418 @@
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// handler returns the handler of b, which mirrors the generated ones.
+func (b *binding) handler(mux *runtime.ServeMux, conn grpc.ClientConnInterface) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, b.fullMethod, runtime.WithHTTPPathPattern(b.template))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		var (
+			resp   proto.Message
+			stream grpc.ClientStream
+			md     runtime.ServerMetadata
+		)
+		switch {
+		case b.method.IsStreamingClient() && b.method.IsStreamingServer():
+			stream, md, err = b.requestBidiStream(annotatedContext, inboundMarshaler, conn, req)
+		case b.method.IsStreamingClient():
+			resp, stream, md, err = b.requestClientStream(annotatedContext, inboundMarshaler, conn, req)
+		case b.method.IsStreamingServer():
+			_, stream, md, err = b.request(annotatedContext, inboundMarshaler, conn, req, pathParams)
+		default:
+			resp, md, err = runtime.RetryUnary(annotatedContext, req, func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+				resp, _, md, err := b.request(ctx, inboundMarshaler, conn, req, pathParams)
+				return resp, md, err
+			})
+		}
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		runtime.ReportUpstreamEnd(annotatedContext, err)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		if b.method.IsStreamingServer() {
+			runtime.ForwardResponseStream(annotatedContext, mux, outboundMarshaler, w, req, func() (proto.Message, error) {
+				msg := b.output.New().Interface()
+				err := stream.RecvMsg(msg)
+				return b.response(msg), err
+			}, mux.GetForwardResponseOptions()...)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, mux, outboundMarshaler, w, req, b.response(resp), mux.GetForwardResponseOptions()...)
+	}
+}
+
+// request calls a unary or server streaming method, like the generated
+// client-rpc-request-func. It returns the response of unary methods and the
+// stream of server streaming ones.
+func (b *binding) request(ctx context.Context, marshaler runtime.Marshaler, conn grpc.ClientConnInterface, req *http.Request, pathParams map[string]string) (proto.Message, grpc.ClientStream, runtime.ServerMetadata, error) {
+	var metadata runtime.ServerMetadata
+	protoReq := b.input.New()
+	if err := b.decodeBody(marshaler, req, protoReq); err != nil {
+		return nil, nil, metadata, err
+	}
+	for _, p := range b.pathParams {
+		val, ok := pathParams[p.name]
+		if !ok {
+			return nil, nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", p.name)
+		}
+		var err error
+		if p.fields[len(p.fields)-1].IsList() {
+			err = runtime.PopulateQueryParametersContext(req.Context(), protoReq.Interface(), url.Values{p.name: strings.Split(val, ",")}, utilities.NewDoubleArray(nil))
+		} else {
+			err = runtime.PopulateFieldFromPath(protoReq.Interface(), p.name, val)
+			// The generated handlers report the conversion errors of
+			// top-level fields as is.
+			if inner := errors.Unwrap(err); inner != nil && len(p.fields) == 1 {
+				err = inner
+			}
+		}
+		if err != nil {
+			return nil, nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", p.name, err)
+		}
+	}
+	if b.hasQueryParam {
+		if err := req.ParseForm(); err != nil {
+			return nil, nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if err := runtime.PopulateQueryParametersContext(req.Context(), protoReq.Interface(), req.Form, b.queryFilter); err != nil {
+			return nil, nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	if err := runtime.EnforceFieldBehavior(ctx, req, protoReq.Interface(), b.bodyPath); err != nil {
+		return nil, nil, metadata, err
+	}
+
+	runtime.ReportUpstreamBegin(ctx)
+	if !b.method.IsStreamingServer() {
+		msg := b.output.New().Interface()
+		err := conn.Invoke(ctx, b.fullMethod, protoReq.Interface(), msg, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
+		return msg, nil, metadata, err
+	}
+	stream, err := conn.NewStream(ctx, b.streamDesc, b.fullMethod)
+	if err != nil {
+		return nil, nil, metadata, err
+	}
+	if err := stream.SendMsg(protoReq.Interface()); err != nil {
+		return nil, nil, metadata, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, nil, metadata, err
+	}
+	header, err := stream.Header()
+	if err != nil {
+		return nil, nil, metadata, err
+	}
+	metadata.HeaderMD = header
+	return nil, stream, metadata, nil
+}
+
+// decodeBody decodes the body of req into protoReq.
+func (b *binding) decodeBody(marshaler runtime.Marshaler, req *http.Request, protoReq protoreflect.Message) error {
+	if !b.hasBody {
+		if req.Body != nil {
+			_, _ = io.Copy(io.Discard, req.Body)
+		}
+		return nil
+	}
+
+	parent, field := protoReq, protoreflect.FieldDescriptor(nil)
+	if len(b.body) > 0 {
+		for _, fd := range b.body[:len(b.body)-1] {
+			parent = parent.Mutable(fd).Message()
+		}
+		field = b.body[len(b.body)-1]
+	}
+
+	if b.fieldMask == nil {
+		if err := decodeInto(marshaler.NewDecoder(req.Body), parent, field); err != nil && !errors.Is(err, io.EOF) {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if req.Body != nil {
+			_, _ = io.Copy(io.Discard, req.Body)
+		}
+		return nil
+	}
+
+	newReader, berr := utilities.IOReaderFactory(req.Body)
+	if berr != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", berr)
+	}
+	if runtime.IsJSONPatch(req) {
+		target := parent.Mutable(field).Message()
+		fieldMask, err := runtime.DecodeJSONPatch(newReader(), target.Interface())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.Set(b.fieldMask, protoreflect.ValueOfMessage(fieldMask.ProtoReflect()))
+	} else if err := decodeInto(marshaler.NewDecoder(newReader()), parent, field); err != nil && !errors.Is(err, io.EOF) {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+	}
+	if !protoReq.Has(b.fieldMask) || protoReq.Get(b.fieldMask).Message().Get(b.fieldMask.Message().Fields().ByName("paths")).List().Len() == 0 {
+		fieldMask, err := runtime.FieldMaskFromRequestBody(newReader(), parent.Get(field).Message().Interface())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		protoReq.Set(b.fieldMask, protoreflect.ValueOfMessage(fieldMask.ProtoReflect()))
+	}
+	return nil
+}
+
+// decodeInto decodes a message with dec into the field of parent, or into
+// parent itself if field is nil. The field is only set if a value is decoded.
+func decodeInto(dec runtime.Decoder, parent protoreflect.Message, field protoreflect.FieldDescriptor) error {
+	if field == nil {
+		return dec.Decode(parent.Interface())
+	}
+	if field.Message() != nil && !field.IsList() && !field.IsMap() {
+		msg := parent.NewField(field).Message()
+		if err := dec.Decode(msg.Interface()); err != nil {
+			return err
+		}
+		parent.Set(field, protoreflect.ValueOfMessage(msg))
+		return nil
+	}
+
+	// Scalar fields are decoded into Go values, like in the generated handlers.
+	goType := scalarGoTypes[field.Kind()]
+	if field.IsList() {
+		goType = reflect.SliceOf(goType)
+	}
+	v := reflect.New(goType)
+	if err := dec.Decode(v.Interface()); err != nil {
+		return err
+	}
+	if !field.IsList() {
+		parent.Set(field, scalarValue(field, v.Elem()))
+		return nil
+	}
+	list := parent.Mutable(field).List()
+	for i := 0; i < v.Elem().Len(); i++ {
+		list.Append(scalarValue(field, v.Elem().Index(i)))
+	}
+	return nil
+}
+
+// scalarGoTypes are the Go types into which scalar body fields are decoded.
+var scalarGoTypes = map[protoreflect.Kind]reflect.Type{
+	protoreflect.BoolKind:     reflect.TypeOf(false),
+	protoreflect.EnumKind:     reflect.TypeOf(int32(0)),
+	protoreflect.Int32Kind:    reflect.TypeOf(int32(0)),
+	protoreflect.Sint32Kind:   reflect.TypeOf(int32(0)),
+	protoreflect.Sfixed32Kind: reflect.TypeOf(int32(0)),
+	protoreflect.Int64Kind:    reflect.TypeOf(int64(0)),
+	protoreflect.Sint64Kind:   reflect.TypeOf(int64(0)),
+	protoreflect.Sfixed64Kind: reflect.TypeOf(int64(0)),
+	protoreflect.Uint32Kind:   reflect.TypeOf(uint32(0)),
+	protoreflect.Fixed32Kind:  reflect.TypeOf(uint32(0)),
+	protoreflect.Uint64Kind:   reflect.TypeOf(uint64(0)),
+	protoreflect.Fixed64Kind:  reflect.TypeOf(uint64(0)),
+	protoreflect.FloatKind:    reflect.TypeOf(float32(0)),
+	protoreflect.DoubleKind:   reflect.TypeOf(float64(0)),
+	protoreflect.StringKind:   reflect.TypeOf(""),
+	protoreflect.BytesKind:    reflect.TypeOf([]byte(nil)),
+}
+
+func scalarValue(field protoreflect.FieldDescriptor, v reflect.Value) protoreflect.Value {
+	if field.Kind() == protoreflect.EnumKind {
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v.Int()))
+	}
+	return protoreflect.ValueOf(v.Interface())
+}
+
+// checkBodyField returns an error if the body field fd cannot be decoded.
+func checkBodyField(fd protoreflect.FieldDescriptor) error {
+	switch {
+	case fd.IsMap():
+		return fmt.Errorf("map field %q cannot be the body", fd.Name())
+	case fd.IsList() && fd.Message() != nil:
+		return fmt.Errorf("repeated message field %q cannot be the body", fd.Name())
+	}
+	return nil
+}
+
+// requestClientStream calls a client streaming method, like the generated
+// client-streaming-request-func.
+func (b *binding) requestClientStream(ctx context.Context, marshaler runtime.Marshaler, conn grpc.ClientConnInterface, req *http.Request) (proto.Message, grpc.ClientStream, runtime.ServerMetadata, error) {
+	var metadata runtime.ServerMetadata
+	runtime.ReportUpstreamBegin(ctx)
+	stream, err := conn.NewStream(ctx, b.streamDesc, b.fullMethod)
+	if err != nil {
+		grpclog.Errorf("Failed to start streaming: %v", err)
+		return nil, nil, metadata, err
+	}
+	dec := marshaler.NewDecoder(req.Body)
+	for {
+		protoReq := b.input.New().Interface()
+		err = dec.Decode(protoReq)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			grpclog.Errorf("Failed to decode request: %v", err)
+			return nil, nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if err = stream.SendMsg(protoReq); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			grpclog.Errorf("Failed to send request: %v", err)
+			return nil, nil, metadata, err
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		grpclog.Errorf("Failed to terminate client stream: %v", err)
+		return nil, nil, metadata, err
+	}
+	header, err := stream.Header()
+	if err != nil {
+		grpclog.Errorf("Failed to get header from client: %v", err)
+		return nil, nil, metadata, err
+	}
+	metadata.HeaderMD = header
+	if b.method.IsStreamingServer() {
+		return nil, stream, metadata, nil
+	}
+	msg := b.output.New().Interface()
+	err = stream.RecvMsg(msg)
+	metadata.TrailerMD = stream.Trailer()
+	return msg, nil, metadata, err
+}
+
+// requestBidiStream calls a bidirectional streaming method, like the
+// generated bidi-streaming-request-func.
+func (b *binding) requestBidiStream(ctx context.Context, marshaler runtime.Marshaler, conn grpc.ClientConnInterface, req *http.Request) (grpc.ClientStream, runtime.ServerMetadata, error) {
+	var metadata runtime.ServerMetadata
+	runtime.ReportUpstreamBegin(ctx)
+	stream, err := conn.NewStream(ctx, b.streamDesc, b.fullMethod)
+	if err != nil {
+		grpclog.Errorf("Failed to start streaming: %v", err)
+		return nil, metadata, err
+	}
+	dec := marshaler.NewDecoder(req.Body)
+	handleSend := func() error {
+		protoReq := b.input.New().Interface()
+		err := dec.Decode(protoReq)
+		if errors.Is(err, io.EOF) {
+			return err
+		}
+		if err != nil {
+			grpclog.Errorf("Failed to decode request: %v", err)
+			return status.Errorf(codes.InvalidArgument, "Failed to decode request: %v", err)
+		}
+		if err := stream.SendMsg(protoReq); err != nil {
+			grpclog.Errorf("Failed to send request: %v", err)
+			return err
+		}
+		return nil
+	}
+	go func() {
+		for {
+			if err := handleSend(); err != nil {
+				break
+			}
+		}
+		if err := stream.CloseSend(); err != nil {
+			grpclog.Errorf("Failed to terminate client stream: %v", err)
+		}
+	}()
+	header, err := stream.Header()
+	if err != nil {
+		grpclog.Errorf("Failed to get header from client: %v", err)
+		return nil, metadata, err
+	}
+	metadata.HeaderMD = header
+	return stream, metadata, nil
+}
+
+// response returns the message to forward for the response msg.
+func (b *binding) response(msg proto.Message) proto.Message {
+	if len(b.responseBody) == 0 {
+		return msg
+	}
+	return responseBody{Message: msg, fields: b.responseBody}
+}
+
+// responseBody is a response whose response_body field is forwarded, like
+// the generated response_* types.
+type responseBody struct {
+	proto.Message
+	fields []protoreflect.FieldDescriptor
+}
+
+// XXX_ResponseBody returns the value of the response_body field, in the Go
+// types expected by the marshalers.
+func (r responseBody) XXX_ResponseBody() interface{} {
+	m := r.Message.ProtoReflect()
+	for _, fd := range r.fields[:len(r.fields)-1] {
+		m = m.Get(fd).Message()
+	}
+	fd := r.fields[len(r.fields)-1]
+	v := m.Get(fd)
+	switch {
+	case fd.IsList():
+		list := v.List()
+		if fd.Message() != nil {
+			msgs := make([]proto.Message, list.Len())
+			for i := range msgs {
+				msgs[i] = list.Get(i).Message().Interface()
+			}
+			return msgs
+		}
+		values := make([]interface{}, list.Len())
+		for i := range values {
+			values[i] = goValue(fd, list.Get(i))
+		}
+		return values
+	case fd.IsMap():
+		values := make(map[string]interface{}, v.Map().Len())
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			values[k.String()] = goValue(fd.MapValue(), v)
+			return true
+		})
+		return values
+	default:
+		return goValue(fd, v)
+	}
+}
+
+func goValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch {
+	case fd.Message() != nil:
+		return v.Message().Interface()
+	case fd.Kind() == protoreflect.EnumKind:
+		return int32(v.Enum())
+	default:
+		return v.Interface()
+	}
+}
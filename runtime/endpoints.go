@@ -0,0 +1,331 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/health" // registers the client side of the gRPC health protocol
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	endpointsScheme        = "grpc-gateway-endpoints"
+	endpointsRoundRobin    = "grpc_gateway_endpoints_round_robin"
+	endpointsPickFirst     = "grpc_gateway_endpoints_pick_first"
+	defaultEndpointsTarget = "localhost"
+)
+
+func init() {
+	balancer.Register(base.NewBalancerBuilder(endpointsRoundRobin, endpointsPickerBuilder{}, base.Config{HealthCheck: true}))
+	balancer.Register(base.NewBalancerBuilder(endpointsPickFirst, endpointsPickerBuilder{pickFirst: true}, base.Config{HealthCheck: true}))
+}
+
+// EndpointsOption is an option that can be given to NewEndpointsClient.
+type EndpointsOption func(*endpointsConfig)
+
+type endpointsConfig struct {
+	pickFirst       bool
+	healthCheck     bool
+	healthService   string
+	outlierEjection *OutlierEjection
+	dialOpts        []grpc.DialOption
+}
+
+// OutlierEjection configures the ejection of the endpoints whose calls keep
+// failing. An ejected endpoint gets no calls until its ejection time is over,
+// unless no other endpoint is ready.
+type OutlierEjection struct {
+	// ConsecutiveFailures is the number of consecutive calls failing with
+	// Unavailable, Unknown, Internal or DataLoss which ejects an endpoint.
+	// Defaults to 5.
+	ConsecutiveFailures int
+	// BaseEjectionTime is the time an endpoint is ejected for, multiplied by
+	// the number of times it was ejected. Defaults to 30 seconds.
+	BaseEjectionTime time.Duration
+	// MaxEjectionTime bounds the time an endpoint is ejected for. Defaults to
+	// 5 minutes.
+	MaxEjectionTime time.Duration
+	// MaxEjectionPercent is the maximum percentage of the endpoints which may
+	// be ejected at once. At least one endpoint may be ejected, and the last
+	// endpoint which is not ejected never is. Defaults to 50.
+	MaxEjectionPercent int
+}
+
+// WithPickFirst sends all the calls to the first endpoint which is ready,
+// in the order they are given to NewEndpointsClient, and fails over to the
+// next ones. The calls are balanced between the ready endpoints with
+// round-robin by default.
+func WithPickFirst() EndpointsOption {
+	return func(c *endpointsConfig) {
+		c.pickFirst = true
+	}
+}
+
+// WithEndpointHealthCheck checks the health of the endpoints with the gRPC
+// health protocol, grpc.health.v1.Health/Watch, for the service serviceName,
+// or the server as a whole if empty. Endpoints which are not SERVING get no
+// calls. Endpoints which do not implement the health service are considered
+// healthy.
+func WithEndpointHealthCheck(serviceName string) EndpointsOption {
+	return func(c *endpointsConfig) {
+		c.healthCheck = true
+		c.healthService = serviceName
+	}
+}
+
+// WithOutlierEjection ejects the endpoints whose calls keep failing.
+func WithOutlierEjection(ejection OutlierEjection) EndpointsOption {
+	return func(c *endpointsConfig) {
+		c.outlierEjection = &ejection
+	}
+}
+
+// WithEndpointsDialOptions sets the options given to grpc.NewClient, like
+// the transport credentials. grpc.WithContextDialer can be used to reach
+// in-process servers, like the ones of the google.golang.org/grpc/test/bufconn
+// package.
+func WithEndpointsDialOptions(opts ...grpc.DialOption) EndpointsOption {
+	return func(c *endpointsConfig) {
+		c.dialOpts = append(c.dialOpts, opts...)
+	}
+}
+
+// NewEndpointsClient returns a client connection to the gRPC servers at
+// endpoints, between which calls are balanced. Unlike grpc.NewClient, it
+// does not resolve names: endpoints are addresses like "10.0.0.1:9090",
+// "backend:9090" or "unix:///run/backend.sock", which are dialed as is.
+//
+// The calls are balanced with round-robin, or sent to the first ready
+// endpoint with WithPickFirst. Endpoints can be health checked with
+// WithEndpointHealthCheck, and ejected when their calls fail with
+// WithOutlierEjection.
+func NewEndpointsClient(endpoints []string, opts ...EndpointsOption) (*grpc.ClientConn, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoints")
+	}
+	c := &endpointsConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	state := &endpointsState{config: c}
+	addrs := make([]resolver.Address, len(endpoints))
+	for i, endpoint := range endpoints {
+		info := &endpointInfo{state: state, index: i}
+		state.endpoints = append(state.endpoints, info)
+		addrs[i] = resolver.Address{
+			Addr:       endpoint,
+			Attributes: attributes.New(endpointKey{}, info),
+		}
+	}
+	r := manual.NewBuilderWithScheme(endpointsScheme)
+	r.InitialState(resolver.State{Addresses: addrs})
+
+	serviceConfig, err := c.serviceConfig()
+	if err != nil {
+		return nil, err
+	}
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithResolvers(r),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithContextDialer(dialEndpoint),
+	}, c.dialOpts...)
+	return grpc.NewClient(fmt.Sprintf("%s:///%s", endpointsScheme, endpointsTarget(endpoints[0])), dialOpts...)
+}
+
+func (c *endpointsConfig) serviceConfig() (string, error) {
+	policy := endpointsRoundRobin
+	if c.pickFirst {
+		policy = endpointsPickFirst
+	}
+	sc := map[string]interface{}{
+		"loadBalancingConfig": []map[string]interface{}{{policy: map[string]interface{}{}}},
+	}
+	if c.healthCheck {
+		sc["healthCheckConfig"] = map[string]interface{}{"serviceName": c.healthService}
+	}
+	b, err := json.Marshal(sc)
+	return string(b), err
+}
+
+// endpointsTarget returns the target name, used as the default authority of
+// the calls, for the endpoint address.
+func endpointsTarget(endpoint string) string {
+	if strings.HasPrefix(endpoint, "unix:") {
+		return defaultEndpointsTarget
+	}
+	return endpoint
+}
+
+// dialEndpoint dials the Unix socket of "unix:" addresses, and other
+// addresses over TCP.
+func dialEndpoint(ctx context.Context, addr string) (net.Conn, error) {
+	var d net.Dialer
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return d.DialContext(ctx, "unix", strings.TrimPrefix(path, "//"))
+	}
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+type endpointKey struct{}
+
+// endpointInfo is attached to the addresses of the endpoints.
+type endpointInfo struct {
+	state *endpointsState
+	index int
+
+	// The fields below are guarded by state.mu.
+	consecutiveFailures int
+	ejections           int
+	ejectedUntil        time.Time
+}
+
+// endpointsState tracks the outlier ejection of the endpoints of a client.
+type endpointsState struct {
+	config    *endpointsConfig
+	endpoints []*endpointInfo
+	mu        sync.Mutex
+}
+
+func (s *endpointsState) ejectionConfig() OutlierEjection {
+	e := *s.config.outlierEjection
+	if e.ConsecutiveFailures <= 0 {
+		e.ConsecutiveFailures = 5
+	}
+	if e.BaseEjectionTime <= 0 {
+		e.BaseEjectionTime = 30 * time.Second
+	}
+	if e.MaxEjectionTime <= 0 {
+		e.MaxEjectionTime = 5 * time.Minute
+	}
+	if e.MaxEjectionPercent <= 0 {
+		e.MaxEjectionPercent = 50
+	}
+	return e
+}
+
+// done records the outcome of a call to the endpoint.
+func (s *endpointsState) done(endpoint *endpointInfo, err error) {
+	if s.config.outlierEjection == nil {
+		return
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Unknown, codes.Internal, codes.DataLoss:
+	default:
+		s.mu.Lock()
+		endpoint.consecutiveFailures = 0
+		s.mu.Unlock()
+		return
+	}
+
+	e := s.ejectionConfig()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoint.consecutiveFailures++
+	if endpoint.consecutiveFailures < e.ConsecutiveFailures {
+		return
+	}
+	now := time.Now()
+	if now.Before(endpoint.ejectedUntil) {
+		return
+	}
+	ejected := 0
+	for _, other := range s.endpoints {
+		if now.Before(other.ejectedUntil) {
+			ejected++
+		}
+	}
+	maxEjected := max(1, len(s.endpoints)*e.MaxEjectionPercent/100)
+	if ejected >= maxEjected || ejected+1 >= len(s.endpoints) {
+		return
+	}
+	endpoint.consecutiveFailures = 0
+	endpoint.ejections++
+	ejection := e.BaseEjectionTime * time.Duration(endpoint.ejections)
+	if ejection > e.MaxEjectionTime {
+		ejection = e.MaxEjectionTime
+	}
+	endpoint.ejectedUntil = now.Add(ejection)
+}
+
+func (s *endpointsState) ejected(endpoint *endpointInfo, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Before(endpoint.ejectedUntil)
+}
+
+type endpointsPickerBuilder struct {
+	pickFirst bool
+}
+
+type readyEndpoint struct {
+	subConn  balancer.SubConn
+	endpoint *endpointInfo
+}
+
+func (b endpointsPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	ready := make([]readyEndpoint, 0, len(info.ReadySCs))
+	for sc, sci := range info.ReadySCs {
+		endpoint, _ := sci.Address.Attributes.Value(endpointKey{}).(*endpointInfo)
+		if endpoint == nil {
+			continue
+		}
+		ready = append(ready, readyEndpoint{subConn: sc, endpoint: endpoint})
+	}
+	if len(ready) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	// Order the endpoints like they were given, for pick first.
+	for i := 1; i < len(ready); i++ {
+		for j := i; j > 0 && ready[j].endpoint.index < ready[j-1].endpoint.index; j-- {
+			ready[j], ready[j-1] = ready[j-1], ready[j]
+		}
+	}
+	return &endpointsPicker{pickFirst: b.pickFirst, ready: ready}
+}
+
+type endpointsPicker struct {
+	pickFirst bool
+	ready     []readyEndpoint
+	next      atomic.Uint32
+}
+
+func (p *endpointsPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	now := time.Now()
+	n := len(p.ready)
+	start := 0
+	if !p.pickFirst {
+		start = int(p.next.Add(1)-1) % n
+	}
+	picked := p.ready[start]
+	for i := 0; i < n; i++ {
+		candidate := p.ready[(start+i)%n]
+		if !candidate.endpoint.state.ejected(candidate.endpoint, now) {
+			picked = candidate
+			break
+		}
+	}
+	return balancer.PickResult{
+		SubConn: picked.subConn,
+		Done: func(info balancer.DoneInfo) {
+			picked.endpoint.state.done(picked.endpoint, info.Err)
+		},
+	}, nil
+}
@@ -0,0 +1,165 @@
+package runtime_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// endpointBackend is an in-process gRPC server with a health service, which
+// sets the "backend" header of its responses to its name.
+type endpointBackend struct {
+	lis    *bufconn.Listener
+	srv    *grpc.Server
+	health *health.Server
+	// failing makes the calls other than health checks fail.
+	failing atomic.Bool
+}
+
+// startEndpointBackends starts backends named by names, and returns them
+// with the dial options reaching them by name.
+func startEndpointBackends(t *testing.T, names ...string) (map[string]*endpointBackend, grpc.DialOption) {
+	t.Helper()
+	backends := make(map[string]*endpointBackend)
+	for _, name := range names {
+		b := &endpointBackend{lis: bufconn.Listen(1 << 20), health: health.NewServer()}
+		b.srv = grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			_ = grpc.SetHeader(ctx, metadata.Pairs("backend", name))
+			if b.failing.Load() {
+				return nil, status.Error(codes.Unavailable, "failing")
+			}
+			return handler(ctx, req)
+		}))
+		grpc_health_v1.RegisterHealthServer(b.srv, b.health)
+		go func() { _ = b.srv.Serve(b.lis) }()
+		t.Cleanup(b.srv.Stop)
+		backends[name] = b
+	}
+	dialer := grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		return backends[addr].lis.DialContext(ctx)
+	})
+	return backends, dialer
+}
+
+func newEndpointsClient(t *testing.T, endpoints []string, dialer grpc.DialOption, opts ...runtime.EndpointsOption) grpc_health_v1.HealthClient {
+	t.Helper()
+	opts = append(opts, runtime.WithEndpointsDialOptions(dialer, grpc.WithTransportCredentials(insecure.NewCredentials())))
+	conn, err := runtime.NewEndpointsClient(endpoints, opts...)
+	if err != nil {
+		t.Fatalf("runtime.NewEndpointsClient(%q) failed with %v", endpoints, err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+// callBackend makes a call and returns the name of the backend handling it,
+// or "" if it failed.
+func callBackend(t *testing.T, client grpc_health_v1.HealthClient) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var header metadata.MD
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header), grpc.WaitForReady(true))
+	if err != nil {
+		return ""
+	}
+	if v := header.Get("backend"); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// waitForBackends makes calls until the ones in a row are handled by want,
+// and returns the backends handling these calls.
+func waitForBackends(t *testing.T, client grpc_health_v1.HealthClient, calls int, want func(backend string) bool) map[string]int {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	got := make(map[string]int)
+	for time.Now().Before(deadline) {
+		backend := callBackend(t, client)
+		if !want(backend) {
+			got = make(map[string]int)
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		got[backend]++
+		if calls--; calls == 0 {
+			return got
+		}
+	}
+	t.Fatalf("calls were not handled as expected in time: %v", got)
+	return nil
+}
+
+func TestNewEndpointsClientRoundRobin(t *testing.T) {
+	_, dialer := startEndpointBackends(t, "a", "b", "c")
+	client := newEndpointsClient(t, []string{"a", "b", "c"}, dialer)
+	got := waitForBackends(t, client, 30, func(backend string) bool { return backend != "" })
+	for _, backend := range []string{"a", "b", "c"} {
+		if got[backend] == 0 {
+			t.Errorf("backend %s handled no calls: %v", backend, got)
+		}
+	}
+}
+
+func TestNewEndpointsClientPickFirst(t *testing.T) {
+	backends, dialer := startEndpointBackends(t, "a", "b")
+	client := newEndpointsClient(t, []string{"a", "b"}, dialer, runtime.WithPickFirst())
+	waitForBackends(t, client, 10, func(backend string) bool { return backend == "a" })
+
+	backends["a"].srv.Stop()
+	waitForBackends(t, client, 10, func(backend string) bool { return backend == "b" })
+}
+
+func TestNewEndpointsClientHealthCheck(t *testing.T) {
+	backends, dialer := startEndpointBackends(t, "a", "b")
+	client := newEndpointsClient(t, []string{"a", "b"}, dialer, runtime.WithEndpointHealthCheck(""))
+	waitForBackends(t, client, 10, func(backend string) bool { return backend != "" })
+
+	backends["a"].health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	waitForBackends(t, client, 10, func(backend string) bool { return backend == "b" })
+
+	backends["a"].health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	got := waitForBackends(t, client, 10, func(backend string) bool { return backend != "" })
+	if got["a"] == 0 {
+		t.Errorf("healthy backend a handled no calls: %v", got)
+	}
+}
+
+func TestNewEndpointsClientOutlierEjection(t *testing.T) {
+	backends, dialer := startEndpointBackends(t, "a", "b")
+	client := newEndpointsClient(t, []string{"a", "b"}, dialer, runtime.WithOutlierEjection(runtime.OutlierEjection{
+		ConsecutiveFailures: 2,
+		BaseEjectionTime:    time.Hour,
+	}))
+	waitForBackends(t, client, 10, func(backend string) bool { return backend != "" })
+
+	backends["a"].failing.Store(true)
+	waitForBackends(t, client, 10, func(backend string) bool { return backend == "b" })
+
+	// The last endpoint which is not ejected is never ejected.
+	backends["b"].failing.Store(true)
+	for i := 0; i < 5; i++ {
+		callBackend(t, client)
+	}
+	backends["b"].failing.Store(false)
+	waitForBackends(t, client, 10, func(backend string) bool { return backend == "b" })
+}
+
+func TestNewEndpointsClientNoEndpoints(t *testing.T) {
+	if _, err := runtime.NewEndpointsClient(nil); err == nil {
+		t.Errorf("runtime.NewEndpointsClient(nil) succeeded; want error")
+	}
+}
@@ -78,13 +78,18 @@ func HTTPStatusFromCode(code codes.Code) int {
 
 // HTTPError uses the mux-configured error handler.
 func HTTPError(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	err = recoveredError(ctx, mux.drain.convert(ctx, bodyLimitError(ctx, err)))
+	requestStatsFromContext(ctx).setError(err)
+	routeStatsFromContext(ctx).recordError(err)
 	mux.errorHandler(ctx, mux, marshaler, w, r, err)
 }
 
 // HTTPStreamError uses the mux-configured stream error handler to notify error to the client without closing the connection.
 func HTTPStreamError(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, err error) {
-	st := mux.streamErrorHandler(ctx, err)
-	msg := errorChunk(st)
+	st := mux.streamErrorHandler(ctx, mux.drain.convert(ctx, bodyLimitStreamError(ctx, err)))
+	requestStatsFromContext(ctx).setCode(st.Code())
+	routeStatsFromContext(ctx).recordError(st.Err())
+	msg := errorChunk(withRequestInfo(ctx, st))
 	buf, err := marshaler.Marshal(msg)
 	if err != nil {
 		grpclog.Errorf("Failed to marshal an error: %v", err)
@@ -115,7 +120,7 @@ func DefaultHTTPErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marsh
 		err = customStatus.Err
 	}
 
-	s := status.Convert(err)
+	s := withRequestInfo(ctx, status.Convert(err))
 
 	w.Header().Del("Trailer")
 	w.Header().Del("Transfer-Encoding")
@@ -0,0 +1,350 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldBehaviorOption is an option that can be given to WithFieldBehavior.
+type FieldBehaviorOption func(*fieldBehaviorConfig)
+
+type fieldBehaviorConfig struct {
+	rejectOutputOnly bool
+
+	// fieldBehaviors caches the field behaviors of field descriptors, by
+	// descriptor.
+	fieldBehaviors sync.Map
+}
+
+// WithRejectOutputOnlyFields makes requests setting OUTPUT_ONLY fields fail
+// with an InvalidArgument error. By default these fields are cleared.
+func WithRejectOutputOnlyFields() FieldBehaviorOption {
+	return func(c *fieldBehaviorConfig) {
+		c.rejectOutputOnly = true
+	}
+}
+
+// WithFieldBehavior returns a ServeMuxOption that enforces the
+// google.api.field_behavior annotations of the request messages, once they
+// are populated from the body, path and query parameters of the requests:
+//   - OUTPUT_ONLY fields set by the requests are cleared, or rejected with
+//     WithRejectOutputOnlyFields,
+//   - PATCH requests whose update mask selects IMMUTABLE fields are rejected,
+//     or, with the "*" mask, whose body sets IMMUTABLE fields,
+//   - requests missing REQUIRED fields are rejected. For PATCH requests with an
+//     update mask, only the required fields selected by the mask are checked
+//     in the updated resource.
+//
+// Requests are rejected with an InvalidArgument error listing the offending
+// fields, with a google.rpc.BadRequest detail.
+//
+// The annotations are enforced by the handlers generated with the
+// enforce_field_behavior option of protoc-gen-grpc-gateway and by the handlers
+// of the runtime/dynamic package, which call EnforceFieldBehavior.
+func WithFieldBehavior(opts ...FieldBehaviorOption) ServeMuxOption {
+	c := &fieldBehaviorConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return func(serveMux *ServeMux) {
+		serveMux.fieldBehavior = c
+	}
+}
+
+type fieldBehaviorConfigKey struct{}
+
+func withFieldBehaviorConfig(ctx context.Context, c *fieldBehaviorConfig) context.Context {
+	return context.WithValue(ctx, fieldBehaviorConfigKey{}, c)
+}
+
+func fieldBehaviorConfigFromContext(ctx context.Context) *fieldBehaviorConfig {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(fieldBehaviorConfigKey{}).(*fieldBehaviorConfig)
+	return c
+}
+
+// EnforceFieldBehavior enforces the google.api.field_behavior annotations of
+// the request message msg of req, as configured by WithFieldBehavior. It does
+// nothing if the ServeMux handling req was not given WithFieldBehavior.
+//
+// bodyField is the field path of the body of the HTTP rule of the handler,
+// like "book", "*" if msg is the body, or "" if the rule has no body. The update
+// mask of PATCH requests is relative to the body field.
+func EnforceFieldBehavior(ctx context.Context, req *http.Request, msg proto.Message, bodyField string) error {
+	c := fieldBehaviorConfigFromContext(ctx)
+	if c == nil || msg == nil {
+		return nil
+	}
+	m := msg.ProtoReflect()
+
+	if outputOnly := c.outputOnlyFields(m, "", !c.rejectOutputOnly); len(outputOnly) > 0 && c.rejectOutputOnly {
+		return fieldBehaviorError("output only fields cannot be set", outputOnly)
+	}
+
+	var mask []string
+	if req != nil && req.Method == http.MethodPatch {
+		mask = updateMaskPaths(m)
+	}
+	resourcePath := ""
+	if bodyField != "" && bodyField != "*" && len(mask) > 0 {
+		resourcePath = bodyField
+		if resource := fieldMessageByPath(m, bodyField); resource != nil {
+			if immutable := c.immutableFields(resource, mask); len(immutable) > 0 {
+				return fieldBehaviorError("immutable fields cannot be updated", prefixPaths(bodyField, immutable))
+			}
+		}
+	} else if len(mask) > 0 {
+		if immutable := c.immutableFields(m, mask); len(immutable) > 0 {
+			return fieldBehaviorError("immutable fields cannot be updated", immutable)
+		}
+	}
+
+	missing := c.missingFields(m, "", func(path string) bool {
+		if len(mask) == 0 {
+			return true
+		}
+		if resourcePath != "" {
+			if path == resourcePath || strings.HasPrefix(resourcePath, path+".") {
+				return true
+			}
+			if !strings.HasPrefix(path, resourcePath+".") {
+				return true
+			}
+			path = strings.TrimPrefix(path, resourcePath+".")
+		}
+		return maskSelects(mask, path)
+	})
+	if len(missing) > 0 {
+		return fieldBehaviorError("missing required fields", missing)
+	}
+	return nil
+}
+
+func fieldBehaviorError(msg string, fields []string) error {
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("%s: %s", msg, strings.Join(fields, ", ")))
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+	for i, field := range fields {
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: field, Description: msg}
+	}
+	if withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// behaviors returns the field behaviors of fd.
+func (c *fieldBehaviorConfig) behaviors(fd protoreflect.FieldDescriptor) map[annotations.FieldBehavior]bool {
+	if b, ok := c.fieldBehaviors.Load(fd); ok {
+		return b.(map[annotations.FieldBehavior]bool)
+	}
+	b := make(map[annotations.FieldBehavior]bool)
+	if fbs, ok := proto.GetExtension(fd.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior); ok {
+		for _, fb := range fbs {
+			b[fb] = true
+		}
+	}
+	c.fieldBehaviors.Store(fd, b)
+	return b
+}
+
+// outputOnlyFields returns the paths of the OUTPUT_ONLY fields set in m,
+// clearing them if clear is true.
+func (c *fieldBehaviorConfig) outputOnlyFields(m protoreflect.Message, prefix string, clear bool) []string {
+	var paths []string
+	rangeFields(m, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+		path := prefix + fd.TextName()
+		if c.behaviors(fd)[annotations.FieldBehavior_OUTPUT_ONLY] {
+			paths = append(paths, path)
+			if clear {
+				m.Clear(fd)
+			}
+			return
+		}
+		forEachMessage(fd, v, path, func(elem protoreflect.Message, elemPath string) {
+			paths = append(paths, c.outputOnlyFields(elem, elemPath+".", clear)...)
+		})
+	})
+	return paths
+}
+
+// missingFields returns the paths of the REQUIRED fields which are not set in
+// m, among the fields whose path is checked.
+func (c *fieldBehaviorConfig) missingFields(m protoreflect.Message, prefix string, checked func(path string) bool) []string {
+	var paths []string
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + fd.TextName()
+		if !checked(path) {
+			continue
+		}
+		if !m.Has(fd) {
+			if c.behaviors(fd)[annotations.FieldBehavior_REQUIRED] {
+				paths = append(paths, path)
+			}
+			continue
+		}
+		forEachMessage(fd, m.Get(fd), path, func(elem protoreflect.Message, elemPath string) {
+			paths = append(paths, c.missingFields(elem, elemPath+".", checked)...)
+		})
+	}
+	return paths
+}
+
+// immutableFields returns the paths of mask, relative to the resource m, which
+// select IMMUTABLE fields or fields of IMMUTABLE fields. The "*" mask selects
+// every field, so it returns the paths of the IMMUTABLE fields set in m.
+func (c *fieldBehaviorConfig) immutableFields(m protoreflect.Message, mask []string) []string {
+	for _, path := range mask {
+		if path == "*" {
+			return c.setImmutableFields(m, "")
+		}
+	}
+	var paths []string
+	for _, path := range mask {
+		d := m.Descriptor()
+		for _, name := range strings.Split(path, ".") {
+			if d == nil {
+				break
+			}
+			fd := d.Fields().ByName(protoreflect.Name(name))
+			if fd == nil {
+				break
+			}
+			if c.behaviors(fd)[annotations.FieldBehavior_IMMUTABLE] {
+				paths = append(paths, path)
+				break
+			}
+			d = fd.Message()
+			if fd.IsMap() {
+				d = nil
+			}
+		}
+	}
+	return paths
+}
+
+// setImmutableFields returns the paths of the IMMUTABLE fields set in m.
+func (c *fieldBehaviorConfig) setImmutableFields(m protoreflect.Message, prefix string) []string {
+	var paths []string
+	rangeFields(m, func(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+		path := prefix + fd.TextName()
+		if c.behaviors(fd)[annotations.FieldBehavior_IMMUTABLE] {
+			paths = append(paths, path)
+			return
+		}
+		forEachMessage(fd, v, path, func(elem protoreflect.Message, elemPath string) {
+			paths = append(paths, c.setImmutableFields(elem, elemPath+".")...)
+		})
+	})
+	return paths
+}
+
+// rangeFields calls f with the populated fields of m in the order of their
+// numbers, as the order of m.Range is undefined.
+func rangeFields(m protoreflect.Message, f func(fd protoreflect.FieldDescriptor, v protoreflect.Value)) {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Number() < fields[j].Number() })
+	for _, fd := range fields {
+		f(fd, m.Get(fd))
+	}
+}
+
+// forEachMessage calls f with the messages of the value v of fd, and their
+// paths.
+func forEachMessage(fd protoreflect.FieldDescriptor, v protoreflect.Value, path string, f func(m protoreflect.Message, path string)) {
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil {
+			return
+		}
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			f(v.Message(), fmt.Sprintf("%s[%v]", path, k.Interface()))
+			return true
+		})
+	case fd.IsList():
+		if fd.Message() == nil {
+			return
+		}
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			f(list.Get(i).Message(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case fd.Message() != nil:
+		f(v.Message(), path)
+	}
+}
+
+// updateMaskPaths returns the paths of the google.protobuf.FieldMask field of
+// m, if m has exactly one.
+func updateMaskPaths(m protoreflect.Message) []string {
+	var mask protoreflect.FieldDescriptor
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && fd.Message().FullName() == "google.protobuf.FieldMask" {
+			if mask != nil {
+				return nil
+			}
+			mask = fd
+		}
+	}
+	if mask == nil || !m.Has(mask) {
+		return nil
+	}
+	list := m.Get(mask).Message().Get(mask.Message().Fields().ByName("paths")).List()
+	paths := make([]string, list.Len())
+	for i := range paths {
+		paths[i] = list.Get(i).String()
+	}
+	return paths
+}
+
+// fieldMessageByPath returns the message of the field of m at the
+// dot-separated path, or nil if it is not a message field.
+func fieldMessageByPath(m protoreflect.Message, path string) protoreflect.Message {
+	for _, name := range strings.Split(path, ".") {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || fd.IsList() || fd.IsMap() || fd.Message() == nil {
+			return nil
+		}
+		m = m.Get(fd).Message()
+	}
+	return m
+}
+
+// maskSelects reports whether the field at path, or a field inside of it, is
+// selected by mask.
+func maskSelects(mask []string, path string) bool {
+	for _, p := range mask {
+		if p == "*" || p == path || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") || strings.HasPrefix(p, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func prefixPaths(prefix string, paths []string) []string {
+	prefixed := make([]string, len(paths))
+	for i, path := range paths {
+		prefixed[i] = prefix + "." + path
+	}
+	return prefixed
+}
@@ -0,0 +1,273 @@
+package runtime_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// fieldBehaviorField returns a field with the given field behaviors.
+func fieldBehaviorField(name string, number int32, typeName string, behaviors ...annotations.FieldBehavior) *descriptorpb.FieldDescriptorProto {
+	fd := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		Options:  &descriptorpb.FieldOptions{},
+	}
+	if typeName != "" {
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String(typeName)
+	}
+	if len(behaviors) > 0 {
+		proto.SetExtension(fd.Options, annotations.E_FieldBehavior, behaviors)
+	}
+	return fd
+}
+
+// fieldBehaviorRequest returns the UpdateBookRequest message type of a file
+// whose fields have field behaviors.
+func fieldBehaviorRequest(t *testing.T) protoreflect.MessageType {
+	t.Helper()
+	editors := fieldBehaviorField("editors", 6, ".fieldbehavior.Author")
+	editors.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("fieldbehavior.proto"),
+		Package:    proto.String("fieldbehavior"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/field_mask.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Book"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					fieldBehaviorField("name", 1, "", annotations.FieldBehavior_IDENTIFIER),
+					fieldBehaviorField("title", 2, "", annotations.FieldBehavior_REQUIRED),
+					fieldBehaviorField("create_time", 3, "", annotations.FieldBehavior_OUTPUT_ONLY),
+					fieldBehaviorField("isbn", 4, "", annotations.FieldBehavior_IMMUTABLE),
+					fieldBehaviorField("author", 5, ".fieldbehavior.Author"),
+					editors,
+				},
+			},
+			{
+				Name: proto.String("Author"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					fieldBehaviorField("name", 1, "", annotations.FieldBehavior_REQUIRED),
+					fieldBehaviorField("id", 2, "", annotations.FieldBehavior_OUTPUT_ONLY),
+				},
+			},
+			{
+				Name: proto.String("UpdateBookRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					fieldBehaviorField("book", 1, ".fieldbehavior.Book", annotations.FieldBehavior_REQUIRED),
+					fieldBehaviorField("update_mask", 2, ".google.protobuf.FieldMask"),
+				},
+			},
+		},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("protodesc.NewFile(...) failed with %v", err)
+	}
+	return dynamicpb.NewMessageType(fd.Messages().ByName("UpdateBookRequest"))
+}
+
+// setUpdateMask sets the paths of the update_mask field of req.
+func setUpdateMask(req proto.Message, paths []string) {
+	m := req.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName("update_mask")
+	list := m.Mutable(fd).Message().Mutable(fd.Message().Fields().ByName("paths")).List()
+	for _, path := range paths {
+		list.Append(protoreflect.ValueOfString(path))
+	}
+}
+
+func TestEnforceFieldBehavior(t *testing.T) {
+	typ := fieldBehaviorRequest(t)
+	for _, spec := range []struct {
+		name      string
+		opts      []runtime.FieldBehaviorOption
+		method    string
+		bodyField string
+		req       string
+		// updateMask sets the paths of the update mask of req, as JSON does not
+		// accept the "*" path.
+		updateMask []string
+		// want is the request once enforced, if it succeeds.
+		want       string
+		wantMsg    string
+		wantFields []string
+	}{
+		{
+			name:      "valid",
+			method:    http.MethodPost,
+			bodyField: "book",
+			req:       `{"book": {"title": "t", "author": {"name": "a"}}}`,
+			want:      `{"book": {"title": "t", "author": {"name": "a"}}}`,
+		},
+		{
+			name:       "missing required fields",
+			method:     http.MethodPost,
+			bodyField:  "book",
+			req:        `{"book": {"author": {}, "editors": [{"name": "e"}, {}]}}`,
+			wantMsg:    "missing required fields: book.title, book.author.name, book.editors[1].name",
+			wantFields: []string{"book.title", "book.author.name", "book.editors[1].name"},
+		},
+		{
+			name:       "missing required message",
+			method:     http.MethodPost,
+			bodyField:  "*",
+			req:        `{}`,
+			wantMsg:    "missing required fields: book",
+			wantFields: []string{"book"},
+		},
+		{
+			name:      "cleared output only fields",
+			method:    http.MethodPost,
+			bodyField: "book",
+			req:       `{"book": {"title": "t", "create_time": "now", "editors": [{"name": "e", "id": "1"}]}}`,
+			want:      `{"book": {"title": "t", "editors": [{"name": "e"}]}}`,
+		},
+		{
+			name:       "rejected output only fields",
+			opts:       []runtime.FieldBehaviorOption{runtime.WithRejectOutputOnlyFields()},
+			method:     http.MethodPost,
+			bodyField:  "book",
+			req:        `{"book": {"title": "t", "create_time": "now", "editors": [{"name": "e", "id": "1"}]}}`,
+			wantMsg:    "output only fields cannot be set: book.create_time, book.editors[0].id",
+			wantFields: []string{"book.create_time", "book.editors[0].id"},
+		},
+		{
+			name:      "update mask",
+			method:    http.MethodPatch,
+			bodyField: "book",
+			req:       `{"book": {"name": "b", "author": {"name": "a"}}, "update_mask": "author"}`,
+			want:      `{"book": {"name": "b", "author": {"name": "a"}}, "update_mask": "author"}`,
+		},
+		{
+			name:       "missing required field in update mask",
+			method:     http.MethodPatch,
+			bodyField:  "book",
+			req:        `{"book": {"name": "b"}, "update_mask": "title,author.name"}`,
+			wantMsg:    "missing required fields: book.title",
+			wantFields: []string{"book.title"},
+		},
+		{
+			name:       "immutable field in update mask",
+			method:     http.MethodPatch,
+			bodyField:  "book",
+			req:        `{"book": {"name": "b", "isbn": "i"}, "update_mask": "isbn"}`,
+			wantMsg:    "immutable fields cannot be updated: book.isbn",
+			wantFields: []string{"book.isbn"},
+		},
+		{
+			name:       "immutable field set with a wildcard update mask",
+			method:     http.MethodPatch,
+			bodyField:  "book",
+			req:        `{"book": {"name": "b", "title": "t", "isbn": "i"}}`,
+			updateMask: []string{"*"},
+			wantMsg:    "immutable fields cannot be updated: book.isbn",
+			wantFields: []string{"book.isbn"},
+		},
+		{
+			name:       "immutable field unset with a wildcard update mask",
+			method:     http.MethodPatch,
+			bodyField:  "book",
+			req:        `{"book": {"name": "b", "title": "t"}}`,
+			updateMask: []string{"*"},
+			want:       `{"book": {"name": "b", "title": "t"}}`,
+		},
+		{
+			name:      "immutable field without update mask",
+			method:    http.MethodPost,
+			bodyField: "book",
+			req:       `{"book": {"title": "t", "isbn": "i"}}`,
+			want:      `{"book": {"title": "t", "isbn": "i"}}`,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			req := typ.New().Interface()
+			if err := protojson.Unmarshal([]byte(spec.req), req); err != nil {
+				t.Fatalf("protojson.Unmarshal(%s) failed with %v", spec.req, err)
+			}
+			if spec.updateMask != nil {
+				setUpdateMask(req, spec.updateMask)
+			}
+
+			var err error
+			mux := runtime.NewServeMux(runtime.WithFieldBehavior(spec.opts...))
+			if err := mux.HandlePath(spec.method, "/v1/books", func(_ http.ResponseWriter, r *http.Request, _ map[string]string) {
+				err = runtime.EnforceFieldBehavior(r.Context(), r, req, spec.bodyField)
+			}); err != nil {
+				t.Fatalf("mux.HandlePath(...) failed with %v", err)
+			}
+			mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(spec.method, "/v1/books", nil))
+
+			if spec.wantMsg != "" {
+				st := status.Convert(err)
+				if st.Code() != codes.InvalidArgument || st.Message() != spec.wantMsg {
+					t.Fatalf("EnforceFieldBehavior(...) = %v; want InvalidArgument %q", err, spec.wantMsg)
+				}
+				var fields []string
+				for _, detail := range st.Details() {
+					if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+						for _, v := range badRequest.GetFieldViolations() {
+							fields = append(fields, v.GetField())
+						}
+					}
+				}
+				if len(fields) != len(spec.wantFields) {
+					t.Fatalf("field violations = %q; want %q", fields, spec.wantFields)
+				}
+				for i := range fields {
+					if fields[i] != spec.wantFields[i] {
+						t.Errorf("field violations = %q; want %q", fields, spec.wantFields)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EnforceFieldBehavior(...) failed with %v; want success", err)
+			}
+			want := typ.New().Interface()
+			if err := protojson.Unmarshal([]byte(spec.want), want); err != nil {
+				t.Fatalf("protojson.Unmarshal(%s) failed with %v", spec.want, err)
+			}
+			if spec.updateMask != nil {
+				setUpdateMask(want, spec.updateMask)
+			}
+			if !proto.Equal(req, want) {
+				t.Errorf("request = %v; want %v", req, want)
+			}
+		})
+	}
+}
+
+func TestEnforceFieldBehaviorDisabled(t *testing.T) {
+	req := fieldBehaviorRequest(t).New().Interface()
+	var err error
+	mux := runtime.NewServeMux()
+	if err := mux.HandlePath(http.MethodPost, "/v1/books", func(_ http.ResponseWriter, r *http.Request, _ map[string]string) {
+		err = runtime.EnforceFieldBehavior(r.Context(), r, req, "*")
+	}); err != nil {
+		t.Fatalf("mux.HandlePath(...) failed with %v", err)
+	}
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/books", nil))
+	if err != nil {
+		t.Errorf("EnforceFieldBehavior(...) failed with %v; want success without WithFieldBehavior", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -22,6 +23,9 @@ func getFieldByName(fields protoreflect.FieldDescriptors, name string) protorefl
 }
 
 // FieldMaskFromRequestBody creates a FieldMask printing all complete paths from the JSON body.
+//
+// The body is treated as a JSON Merge Patch (RFC 7386): a field explicitly set
+// to null is included in the mask, so that it is cleared by the update.
 func FieldMaskFromRequestBody(r io.Reader, msg proto.Message) (*field_mask.FieldMask, error) {
 	fm := &field_mask.FieldMask{}
 	var root interface{}
@@ -45,12 +49,12 @@ func FieldMaskFromRequestBody(r io.Reader, msg proto.Message) (*field_mask.Field
 			// if the item is an object, then enqueue all of its children
 			for k, v := range m {
 				if item.msg == nil {
-					return nil, errors.New("JSON structure did not match request type")
+					return nil, fmt.Errorf("JSON structure did not match request type at %q", item.path)
 				}
 
 				fd := getFieldByName(item.msg.Descriptor().Fields(), k)
 				if fd == nil {
-					return nil, fmt.Errorf("could not find field %q in %q", k, item.msg.Descriptor().FullName())
+					return nil, fmt.Errorf("could not find field %q in %q", joinFieldMaskPath(item.path, k), item.msg.Descriptor().FullName())
 				}
 
 				if isDynamicProtoMessage(fd.Message()) {
@@ -65,23 +69,23 @@ func FieldMaskFromRequestBody(r io.Reader, msg proto.Message) (*field_mask.Field
 				}
 
 				if isProtobufAnyMessage(fd.Message()) && !fd.IsList() {
-					_, hasTypeField := v.(map[string]interface{})["@type"]
-					if hasTypeField {
-						queue = append(queue, fieldMaskPathItem{path: k})
+					anyPath := joinFieldMaskPath(item.path, string(fd.Name()))
+					if v == nil {
+						// Merge Patch clears the field.
+						queue = append(queue, fieldMaskPathItem{path: anyPath})
 						continue
-					} else {
-						return nil, fmt.Errorf("could not find field @type in %q in message %q", k, item.msg.Descriptor().FullName())
 					}
-
+					obj, _ := v.(map[string]interface{})
+					if _, hasTypeField := obj["@type"]; hasTypeField {
+						queue = append(queue, fieldMaskPathItem{path: anyPath})
+						continue
+					}
+					return nil, fmt.Errorf("could not find field @type in %q in message %q", anyPath, item.msg.Descriptor().FullName())
 				}
 
 				child := fieldMaskPathItem{
 					node: v,
-				}
-				if item.path == "" {
-					child.path = string(fd.FullName().Name())
-				} else {
-					child.path = item.path + "." + string(fd.FullName().Name())
+					path: joinFieldMaskPath(item.path, string(fd.FullName().Name())),
 				}
 
 				switch {
@@ -111,6 +115,14 @@ func FieldMaskFromRequestBody(r io.Reader, msg proto.Message) (*field_mask.Field
 	return fm, nil
 }
 
+// joinFieldMaskPath appends name to the dotted field mask path prefix.
+func joinFieldMaskPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
 func isProtobufAnyMessage(md protoreflect.MessageDescriptor) bool {
 	return md != nil && (md.FullName() == "google.protobuf.Any")
 }
@@ -166,3 +178,286 @@ type fieldMaskPathItem struct {
 	// parent message
 	msg protoreflect.Message
 }
+
+// ApplyFieldMaskOption configures the behavior of ApplyFieldMask.
+type ApplyFieldMaskOption func(*applyFieldMaskOptions)
+
+type applyFieldMaskOptions struct {
+	mergeRepeated bool
+}
+
+// WithMergeRepeatedFields makes ApplyFieldMask append the elements of repeated
+// fields and add the entries of map fields named by the mask, instead of
+// replacing them.
+func WithMergeRepeatedFields() ApplyFieldMaskOption {
+	return func(o *applyFieldMaskOptions) {
+		o.mergeRepeated = true
+	}
+}
+
+// ApplyFieldMask updates dst with the fields of src named by mask, following
+// the update semantics of AIP-134 (https://google.aip.dev/134).
+//
+// A field named by the mask is copied from src to dst, or cleared in dst if
+// it is not set in src. Paths may name fields by their proto or JSON names,
+// may traverse singular message fields, and may select a single map entry by
+// its key, as in "labels.key"; keys which are not identifiers are quoted with
+// backticks as described in AIP-161. The path "*" replaces every field of dst.
+// An empty mask updates the fields which are populated in src.
+//
+// dst and src must be messages of the same type. The mask is validated
+// against their descriptor before dst is modified.
+func ApplyFieldMask(dst, src proto.Message, mask *field_mask.FieldMask, opts ...ApplyFieldMaskOption) error {
+	var o applyFieldMaskOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dstMsg, srcMsg := dst.ProtoReflect(), src.ProtoReflect()
+	if dstMsg.Descriptor().FullName() != srcMsg.Descriptor().FullName() {
+		return fmt.Errorf("cannot apply field mask from %q to %q", srcMsg.Descriptor().FullName(), dstMsg.Descriptor().FullName())
+	}
+
+	paths, err := resolveFieldMask(mask, dstMsg.Descriptor())
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(mask.GetPaths()) == 0:
+		var populated []protoreflect.FieldDescriptor
+		srcMsg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			populated = append(populated, fd)
+			return true
+		})
+		for _, fd := range populated {
+			applyField(dstMsg, srcMsg, fd, o)
+		}
+	case paths == nil:
+		// The wildcard path replaces the whole message.
+		fields := dstMsg.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			applyField(dstMsg, srcMsg, fields.Get(i), o)
+		}
+	default:
+		for _, steps := range paths {
+			applyFieldMaskPath(dstMsg, srcMsg, steps, o)
+		}
+	}
+
+	return nil
+}
+
+// ValidateFieldMask checks that every path of mask names a field of md.
+// Fields are resolved by their proto or JSON names, like in
+// FieldMaskFromRequestBody, and paths follow the syntax accepted by
+// ApplyFieldMask.
+func ValidateFieldMask(mask *field_mask.FieldMask, md protoreflect.MessageDescriptor) error {
+	_, err := resolveFieldMask(mask, md)
+	return err
+}
+
+// fieldMaskStep is a resolved segment of a field mask path.
+type fieldMaskStep struct {
+	fd protoreflect.FieldDescriptor
+	// mapKey selects a single entry of the map field fd if hasMapKey is set.
+	mapKey    protoreflect.MapKey
+	hasMapKey bool
+}
+
+// resolveFieldMask resolves the paths of mask against md. It returns nil
+// without an error if the mask is empty or is the wildcard path.
+func resolveFieldMask(mask *field_mask.FieldMask, md protoreflect.MessageDescriptor) ([][]fieldMaskStep, error) {
+	var paths [][]fieldMaskStep
+	for _, path := range mask.GetPaths() {
+		if path == "*" {
+			if len(mask.GetPaths()) > 1 {
+				return nil, errors.New(`invalid field mask: "*" must be the only path`)
+			}
+			return nil, nil
+		}
+		steps, err := resolveFieldMaskPath(path, md)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field mask path %q: %w", path, err)
+		}
+		paths = append(paths, steps)
+	}
+	return paths, nil
+}
+
+func resolveFieldMaskPath(path string, md protoreflect.MessageDescriptor) ([]fieldMaskStep, error) {
+	segments, err := splitFieldMaskPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []fieldMaskStep
+	for i := 0; i < len(segments); i++ {
+		if md == nil {
+			return nil, fmt.Errorf("field %q is not a message", steps[len(steps)-1].fd.FullName())
+		}
+		fd := getFieldByName(md.Fields(), segments[i])
+		if fd == nil {
+			return nil, fmt.Errorf("could not find field %q in %q", segments[i], md.FullName())
+		}
+		step := fieldMaskStep{fd: fd}
+		switch {
+		case fd.IsMap():
+			md = nil
+			if i+1 < len(segments) {
+				i++
+				key, err := parseField(fd.MapKey(), segments[i], defaultQueryLimits)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %q for map field %q: %w", segments[i], fd.FullName(), err)
+				}
+				step.mapKey = key.MapKey()
+				step.hasMapKey = true
+				md = fd.MapValue().Message()
+			}
+		case fd.IsList():
+			if i+1 < len(segments) {
+				return nil, fmt.Errorf("cannot traverse repeated field %q", fd.FullName())
+			}
+		default:
+			md = fd.Message()
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// splitFieldMaskPath splits a field mask path into its segments. A segment
+// enclosed in backticks may contain dots, and a doubled backtick inside it
+// stands for a literal one.
+func splitFieldMaskPath(path string) ([]string, error) {
+	var segments []string
+	for {
+		var segment string
+		if strings.HasPrefix(path, "`") {
+			var b strings.Builder
+			i := 1
+			for ; i < len(path); i++ {
+				if path[i] != '`' {
+					b.WriteByte(path[i])
+					continue
+				}
+				if i+1 < len(path) && path[i+1] == '`' {
+					b.WriteByte('`')
+					i++
+					continue
+				}
+				break
+			}
+			if i >= len(path) {
+				return nil, errors.New("unterminated backtick")
+			}
+			segment, path = b.String(), path[i+1:]
+			if path != "" && path[0] != '.' {
+				return nil, errors.New("unexpected character after backtick")
+			}
+		} else {
+			end := strings.IndexByte(path, '.')
+			if end < 0 {
+				end = len(path)
+			}
+			segment, path = path[:end], path[end:]
+			if segment == "" {
+				return nil, errors.New("empty field name")
+			}
+		}
+		segments = append(segments, segment)
+		if path == "" {
+			return segments, nil
+		}
+		path = path[1:]
+	}
+}
+
+func applyFieldMaskPath(dst, src protoreflect.Message, steps []fieldMaskStep, o applyFieldMaskOptions) {
+	step, rest := steps[0], steps[1:]
+	fd := step.fd
+
+	if !step.hasMapKey {
+		if len(rest) == 0 {
+			applyField(dst, src, fd, o)
+			return
+		}
+		if !src.Has(fd) && !dst.Has(fd) {
+			return
+		}
+		applyFieldMaskPath(dst.Mutable(fd).Message(), src.Get(fd).Message(), rest, o)
+		return
+	}
+
+	srcMap := src.Get(fd).Map()
+	if len(rest) == 0 {
+		switch {
+		case srcMap.Has(step.mapKey):
+			dst.Mutable(fd).Map().Set(step.mapKey, cloneFieldValue(fd.MapValue(), srcMap.Get(step.mapKey)))
+		case dst.Has(fd):
+			dst.Mutable(fd).Map().Clear(step.mapKey)
+		}
+		return
+	}
+	if !srcMap.Has(step.mapKey) && !dst.Get(fd).Map().Has(step.mapKey) {
+		return
+	}
+	dstEntry := dst.Mutable(fd).Map().Mutable(step.mapKey).Message()
+	srcEntry := dstEntry.Type().Zero()
+	if srcMap.Has(step.mapKey) {
+		srcEntry = srcMap.Get(step.mapKey).Message()
+	}
+	applyFieldMaskPath(dstEntry, srcEntry, rest, o)
+}
+
+// applyField copies the field fd from src to dst, or clears it in dst if it
+// is not set in src.
+func applyField(dst, src protoreflect.Message, fd protoreflect.FieldDescriptor, o applyFieldMaskOptions) {
+	if !src.Has(fd) {
+		if !o.mergeRepeated || !(fd.IsList() || fd.IsMap()) {
+			dst.Clear(fd)
+		}
+		return
+	}
+
+	switch {
+	case fd.IsList():
+		var dstList protoreflect.List
+		if o.mergeRepeated {
+			dstList = dst.Mutable(fd).List()
+		} else {
+			dstList = dst.NewField(fd).List()
+		}
+		srcList := src.Get(fd).List()
+		for i := 0; i < srcList.Len(); i++ {
+			dstList.Append(cloneFieldValue(fd, srcList.Get(i)))
+		}
+		dst.Set(fd, protoreflect.ValueOfList(dstList))
+	case fd.IsMap():
+		var dstMap protoreflect.Map
+		if o.mergeRepeated {
+			dstMap = dst.Mutable(fd).Map()
+		} else {
+			dstMap = dst.NewField(fd).Map()
+		}
+		src.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			dstMap.Set(k, cloneFieldValue(fd.MapValue(), v))
+			return true
+		})
+		dst.Set(fd, protoreflect.ValueOfMap(dstMap))
+	default:
+		dst.Set(fd, cloneFieldValue(fd, src.Get(fd)))
+	}
+}
+
+// cloneFieldValue returns a deep copy of the singular value v of field fd.
+func cloneFieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) protoreflect.Value {
+	switch {
+	case fd.Message() != nil:
+		return protoreflect.ValueOfMessage(proto.Clone(v.Message().Interface()).ProtoReflect())
+	case fd.Kind() == protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(append([]byte(nil), v.Bytes()...))
+	default:
+		return v
+	}
+}
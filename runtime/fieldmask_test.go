@@ -10,6 +10,7 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/emptypb"
 	field_mask "google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
@@ -182,6 +183,12 @@ func TestFieldMaskFromRequestBody(t *testing.T) {
 			input:    `{"repeated_anytype":[{"@type": "xx.xx/examplepb.NestedOuter", "one":{"two":{"three":{"a":true, "b":false}}}}]}`,
 			expected: newFieldMask("repeated_anytype"), //going deeper makes no sense
 		},
+		{
+			name:     "merge-patch-null",
+			msg:      &examplepb.ABitOfEverything{},
+			input:    `{"uuid": null, "single_nested": null, "nested_annotation": {"name": null}, "anytype": null}`,
+			expected: newFieldMask("uuid", "single_nested", "nested_annotation.name", "anytype"),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			actual, err := FieldMaskFromRequestBody(bytes.NewReader([]byte(tc.input)), tc.msg)
@@ -270,7 +277,12 @@ func TestFieldMaskErrors(t *testing.T) {
 		{
 			name:        "object under scalar",
 			input:       `{"uuid": {"a": "x"}}`,
-			expectedErr: errors.New("JSON structure did not match request type"),
+			expectedErr: errors.New(`JSON structure did not match request type at "uuid"`),
+		},
+		{
+			name:        "unknown nested field",
+			input:       `{"single_nested": {"unknown": "x"}}`,
+			expectedErr: errors.New(`could not find field "single_nested.unknown" in "grpc.gateway.runtime.internal.examplepb.ABitOfEverything.Nested"`),
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -364,3 +376,219 @@ func BenchmarkNonStandardFieldMaskFromRequestBody(b *testing.B) {
 	}
 	result = r
 }
+
+func TestApplyFieldMask(t *testing.T) {
+	newDst := func() *examplepb.ABitOfEverything {
+		return &examplepb.ABitOfEverything{
+			Uuid:                "dst",
+			StringValue:         "keep",
+			SingleNested:        &examplepb.ABitOfEverything_Nested{Name: "dst", Amount: 1},
+			Nested:              []*examplepb.ABitOfEverything_Nested{{Name: "a"}},
+			RepeatedStringValue: []string{"a"},
+			MappedStringValue:   map[string]string{"a": "dst", "b": "dst"},
+			MappedNestedValue:   map[string]*examplepb.ABitOfEverything_Nested{"a": {Name: "dst", Amount: 1}},
+			OneofValue:          &examplepb.ABitOfEverything_OneofString{OneofString: "dst"},
+		}
+	}
+	src := &examplepb.ABitOfEverything{
+		Uuid:                "src",
+		SingleNested:        &examplepb.ABitOfEverything_Nested{Name: "src"},
+		Nested:              []*examplepb.ABitOfEverything_Nested{{Name: "b"}},
+		RepeatedStringValue: []string{"b"},
+		MappedStringValue:   map[string]string{"b": "src", "c.d": "src"},
+		MappedNestedValue:   map[string]*examplepb.ABitOfEverything_Nested{"a": {Name: "src"}},
+		OneofValue:          &examplepb.ABitOfEverything_OneofEmpty{OneofEmpty: &emptypb.Empty{}},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		paths    []string
+		opts     []ApplyFieldMaskOption
+		expected func(*examplepb.ABitOfEverything)
+	}{
+		{
+			name:  "scalar fields",
+			paths: []string{"uuid", "stringValue"},
+			expected: func(m *examplepb.ABitOfEverything) {
+				m.Uuid = "src"
+				m.StringValue = ""
+			},
+		},
+		{
+			name:  "nested field",
+			paths: []string{"single_nested.name"},
+			expected: func(m *examplepb.ABitOfEverything) {
+				m.SingleNested.Name = "src"
+			},
+		},
+		{
+			name:  "whole message",
+			paths: []string{"single_nested"},
+			expected: func(m *examplepb.ABitOfEverything) {
+				m.SingleNested = &examplepb.ABitOfEverything_Nested{Name: "src"}
+			},
+		},
+		{
+			name:     "unset fields stay unset",
+			paths:    []string{"nested_annotation", "timestamp_value.seconds"},
+			expected: func(*examplepb.ABitOfEverything) {},
+		},
+		{
+			name:  "replace repeated fields",
+			paths: []string{"nested", "repeated_string_value", "mapped_string_value"},
+			expected: func(m *examplepb.ABitOfEverything) {
+				m.Nested = []*examplepb.ABitOfEverything_Nested{{Name: "b"}}
+				m.RepeatedStringValue = []string{"b"}
+				m.MappedStringValue = map[string]string{"b": "src", "c.d": "src"}
+			},
+		},
+		{
+			name:  "merge repeated fields",
+			paths: []string{"nested", "repeated_string_value", "mapped_string_value"},
+			opts:  []ApplyFieldMaskOption{WithMergeRepeatedFields()},
+			expected: func(m *examplepb.ABitOfEverything) {
+				m.Nested = []*examplepb.ABitOfEverything_Nested{{Name: "a"}, {Name: "b"}}
+				m.RepeatedStringValue = []string{"a", "b"}
+				m.MappedStringValue = map[string]string{"a": "dst", "b": "src", "c.d": "src"}
+			},
+		},
+		{
+			name:  "map keys",
+			paths: []string{"mapped_string_value.a", "mapped_string_value.`c.d`", "mapped_nested_value.a.name"},
+			expected: func(m *examplepb.ABitOfEverything) {
+				m.MappedStringValue = map[string]string{"b": "dst", "c.d": "src"}
+				m.MappedNestedValue["a"].Name = "src"
+			},
+		},
+		{
+			name:  "oneof",
+			paths: []string{"oneof_empty"},
+			expected: func(m *examplepb.ABitOfEverything) {
+				m.OneofValue = &examplepb.ABitOfEverything_OneofEmpty{OneofEmpty: &emptypb.Empty{}}
+			},
+		},
+		{
+			name:  "wildcard",
+			paths: []string{"*"},
+			expected: func(m *examplepb.ABitOfEverything) {
+				proto.Reset(m)
+				proto.Merge(m, src)
+			},
+		},
+		{
+			name: "empty mask updates populated fields",
+			expected: func(m *examplepb.ABitOfEverything) {
+				m.Uuid = "src"
+				m.SingleNested = &examplepb.ABitOfEverything_Nested{Name: "src"}
+				m.Nested = []*examplepb.ABitOfEverything_Nested{{Name: "b"}}
+				m.RepeatedStringValue = []string{"b"}
+				m.MappedStringValue = map[string]string{"b": "src", "c.d": "src"}
+				m.MappedNestedValue = map[string]*examplepb.ABitOfEverything_Nested{"a": {Name: "src"}}
+				m.OneofValue = &examplepb.ABitOfEverything_OneofEmpty{OneofEmpty: &emptypb.Empty{}}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := newDst()
+			if err := ApplyFieldMask(dst, src, newFieldMask(tc.paths...), tc.opts...); err != nil {
+				t.Fatalf("ApplyFieldMask() failed with %v", err)
+			}
+			expected := newDst()
+			tc.expected(expected)
+			if diff := cmp.Diff(expected, dst, protocmp.Transform()); diff != "" {
+				t.Errorf("messages differed (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("does not alias src", func(t *testing.T) {
+		dst := newDst()
+		if err := ApplyFieldMask(dst, src, newFieldMask("single_nested", "nested")); err != nil {
+			t.Fatalf("ApplyFieldMask() failed with %v", err)
+		}
+		dst.SingleNested.Name = "changed"
+		dst.Nested[0].Name = "changed"
+		if src.SingleNested.Name != "src" || src.Nested[0].Name != "b" {
+			t.Errorf("modifying dst changed src: %v", src)
+		}
+	})
+}
+
+func TestApplyFieldMaskErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		dst         proto.Message
+		paths       []string
+		expectedErr string
+	}{
+		{
+			name:        "unknown field",
+			paths:       []string{"uuid", "single_nested.unknown"},
+			expectedErr: `invalid field mask path "single_nested.unknown": could not find field "unknown" in "grpc.gateway.runtime.internal.examplepb.ABitOfEverything.Nested"`,
+		},
+		{
+			name:        "scalar subfield",
+			paths:       []string{"uuid.a"},
+			expectedErr: `invalid field mask path "uuid.a": field "grpc.gateway.runtime.internal.examplepb.ABitOfEverything.uuid" is not a message`,
+		},
+		{
+			name:        "repeated subfield",
+			paths:       []string{"nested.name"},
+			expectedErr: `invalid field mask path "nested.name": cannot traverse repeated field "grpc.gateway.runtime.internal.examplepb.ABitOfEverything.nested"`,
+		},
+		{
+			name:        "wildcard with other paths",
+			paths:       []string{"*", "uuid"},
+			expectedErr: `invalid field mask: "*" must be the only path`,
+		},
+		{
+			name:        "unterminated backtick",
+			paths:       []string{"mapped_string_value.`a"},
+			expectedErr: `invalid field mask path "mapped_string_value.` + "`a" + `": unterminated backtick`,
+		},
+		{
+			name:        "empty segment",
+			paths:       []string{"single_nested..name"},
+			expectedErr: `invalid field mask path "single_nested..name": empty field name`,
+		},
+		{
+			name:        "different types",
+			dst:         &examplepb.NonStandardMessage{},
+			paths:       []string{"uuid"},
+			expectedErr: `cannot apply field mask from "grpc.gateway.runtime.internal.examplepb.ABitOfEverything" to "grpc.gateway.runtime.internal.examplepb.NonStandardMessage"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := tc.dst
+			if dst == nil {
+				dst = &examplepb.ABitOfEverything{Uuid: "dst"}
+			}
+			before := proto.Clone(dst)
+			err := ApplyFieldMask(dst, &examplepb.ABitOfEverything{Uuid: "src"}, newFieldMask(tc.paths...))
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("errors did not match: got %v, wanted %q", err, tc.expectedErr)
+			}
+			if !proto.Equal(before, dst) {
+				t.Errorf("dst was modified despite the error: %v", dst)
+			}
+		})
+	}
+}
+
+func TestValidateFieldMask(t *testing.T) {
+	md := (&examplepb.ABitOfEverything{}).ProtoReflect().Descriptor()
+	for _, paths := range [][]string{
+		nil,
+		{"*"},
+		{"uuid", "singleNested.name", "single_nested.amount"},
+		{"mapped_string_value.a", "mapped_nested_value.`a.b`.name", "map_value"},
+		{"nested", "timestamp_value.seconds"},
+	} {
+		if err := ValidateFieldMask(newFieldMask(paths...), md); err != nil {
+			t.Errorf("ValidateFieldMask(%q) failed with %v", paths, err)
+		}
+	}
+	if err := ValidateFieldMask(newFieldMask("singleNested.unknown"), md); err == nil {
+		t.Errorf("ValidateFieldMask() succeeded for an unknown field")
+	}
+}
@@ -0,0 +1,583 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/visibility"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+
+	// grpcWebTrailerFlag marks the frame holding the trailers at the end of a
+	// gRPC-Web response body.
+	grpcWebTrailerFlag = 0x80
+	// grpcWebCompressedFlag marks the frames holding compressed messages.
+	grpcWebCompressedFlag = 0x01
+
+	// grpcWebBinarySuffix ends the keys of the binary metadata, whose values
+	// are base64 encoded in headers.
+	grpcWebBinarySuffix = "-bin"
+
+	defaultGRPCWebMaxRequestBytes = 4 << 20
+)
+
+// GRPCWebOptions configures the gRPC-Web support of a ServeMux.
+type GRPCWebOptions struct {
+	// AllowedOrigins are the origins of the web pages allowed to call the
+	// gRPC methods from browsers, like "https://example.com", or "*" to allow
+	// every origin. Requests from the pages served by the host of the ServeMux
+	// are always allowed.
+	AllowedOrigins []string
+	// AllowCredentials lets the browsers send the cookies and credentials of
+	// the allowed origins with their requests.
+	AllowCredentials bool
+	// MaxRequestBytes is the maximum size of the request bodies. The default
+	// is 4 MiB, the default maximum message size of gRPC servers.
+	MaxRequestBytes int64
+}
+
+// WithGRPCWeb returns a ServeMuxOption serving gRPC-Web requests, so that the
+// ServeMux replaces a gRPC-Web proxy for browser clients.
+//
+// POST requests to /package.Service/Method paths with an application/grpc-web
+// content type, or application/grpc-web-text for base64 encoded bodies, are
+// forwarded as gRPC calls to the services registered with RegisterServiceConn,
+// which the generated Register{Service}Handler functions call with their
+// connection. Their messages are forwarded as is, and their trailers are sent
+// at the end of the response body, as required by the gRPC-Web protocol.
+// Unary and server streaming methods are supported.
+//
+// The metadata of the calls is built from the headers of the requests like
+// for the other requests, with the matcher of WithIncomingHeaderMatcher and
+// the annotators of WithMetadata, so the header matcher must accept the custom
+// metadata of the clients. grpc-timeout sets the deadline of the call. CORS
+// preflight requests are answered for the origins of opts.
+//
+// WithRateLimit, WithRecovery and WithVisibility restrictions apply to
+// gRPC-Web requests. To hide the fields which are not visible, their messages
+// are decoded with the types of protoregistry.GlobalTypes, which requires the
+// proto subtype: requests of other subtypes, like application/grpc-web+json,
+// fail with Unimplemented for the methods whose messages have fields or enum
+// values with a visibility restriction. gRPC-Web requests do not go through
+// the routes of the ServeMux, so their body limits, caches and metrics do not
+// apply.
+func WithGRPCWeb(opts GRPCWebOptions) ServeMuxOption {
+	if opts.MaxRequestBytes <= 0 {
+		opts.MaxRequestBytes = defaultGRPCWebMaxRequestBytes
+	}
+	return func(serveMux *ServeMux) {
+		serveMux.grpcWeb = &grpcWebConfig{opts: opts}
+	}
+}
+
+type grpcWebConfig struct {
+	opts GRPCWebOptions
+}
+
+// serviceConn is a connection to the gRPC server of a service, given to
+// RegisterServiceConn.
+type serviceConn struct {
+	conn grpc.ClientConnInterface
+	opts RegisterOptions
+}
+
+// RegisterServiceConn registers conn as the connection to the gRPC server of
+// the service serviceName, like "package.Service", for the methods selected by
+// opts. It is called by the generated Register{Service}Handler functions, and
+// used to forward gRPC-Web requests when WithGRPCWeb is set.
+func (s *ServeMux) RegisterServiceConn(serviceName string, conn grpc.ClientConnInterface, opts RegisterOptions) {
+	if s.serviceConns == nil {
+		s.serviceConns = make(map[string]serviceConn)
+	}
+	s.serviceConns[strings.TrimPrefix(serviceName, ".")] = serviceConn{conn: conn, opts: opts}
+}
+
+// handles reports whether r is a gRPC-Web request, or the CORS preflight
+// request of one.
+func (c *grpcWebConfig) handles(s *ServeMux, r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost:
+		return isGRPCWebContentType(r.Header.Get(contentTypeHeader))
+	case http.MethodOptions:
+		if r.Header.Get("Access-Control-Request-Method") == "" {
+			return false
+		}
+		_, _, ok := s.grpcWebMethod(r.URL.Path)
+		return ok
+	default:
+		return false
+	}
+}
+
+func isGRPCWebContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, grpcWebContentType)
+}
+
+func isGRPCWebTextContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, grpcWebTextContentType)
+}
+
+// grpcWebMethod returns the connection to the gRPC server of the method of
+// path, like "/package.Service/Method", and the name of the method, if it is
+// registered.
+func (s *ServeMux) grpcWebMethod(path string) (grpc.ClientConnInterface, string, bool) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !ok || service == "" || method == "" || strings.Contains(method, "/") {
+		return nil, "", false
+	}
+	sc, ok := s.serviceConns[service]
+	if !ok {
+		return nil, "", false
+	}
+	fullMethod := service + "." + method
+	if !sc.opts.Includes(fullMethod, visibilityRestrictions(fullMethod)...) {
+		return nil, "", false
+	}
+	return sc.conn, "/" + service + "/" + method, true
+}
+
+// visibilityRestrictions returns the google.api.VisibilityRule restrictions
+// of the service and of the method with the full name fullMethod, if it is
+// registered in protoregistry.GlobalFiles.
+func visibilityRestrictions(fullMethod string) []string {
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(fullMethod))
+	if err != nil {
+		return nil
+	}
+	md, ok := d.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil
+	}
+	serviceRule, _ := proto.GetExtension(md.Parent().Options(), visibility.E_ApiVisibility).(*visibility.VisibilityRule)
+	methodRule, _ := proto.GetExtension(md.Options(), visibility.E_MethodVisibility).(*visibility.VisibilityRule)
+	return []string{serviceRule.GetRestriction(), methodRule.GetRestriction()}
+}
+
+// allowOrigin sets the CORS headers of the response to a request from origin,
+// and reports whether the origin is allowed.
+func (c *grpcWebConfig) allowOrigin(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	w.Header().Add("Vary", "Origin")
+	allowed := containsString(c.opts.AllowedOrigins, origin) || containsString(c.opts.AllowedOrigins, "*")
+	if u, err := url.Parse(origin); err == nil && u.Host == r.Host {
+		allowed = true
+	}
+	if !allowed {
+		return false
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if c.opts.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+	return true
+}
+
+// serve serves the gRPC-Web request r, or its CORS preflight request.
+func (c *grpcWebConfig) serve(s *ServeMux, w http.ResponseWriter, r *http.Request) {
+	if !c.allowOrigin(w, r) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+		}
+		w.Header().Set("Access-Control-Max-Age", "600")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	contentType := r.Header.Get(contentTypeHeader)
+	gw := &grpcWebResponseWriter{w: w, text: isGRPCWebTextContentType(contentType)}
+	w.Header().Set(contentTypeHeader, contentType)
+	if s.recoveryHandler != nil {
+		defer s.recoverGRPCWeb(gw, r)
+	}
+	ctx, inFlight, ok := s.drain.track(r.Context())
+	if !ok {
+		gw.writeTrailers(s.drain.unavailable(), nil)
+		return
+	}
+	defer s.drain.done(inFlight)
+
+	conn, rpcMethod, ok := s.grpcWebMethod(r.URL.Path)
+	if !ok {
+		gw.writeTrailers(status.Errorf(codes.Unimplemented, "unknown method %s", r.URL.Path), nil)
+		return
+	}
+	_, subtype, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(contentType, grpcWebTextContentType), grpcWebContentType), "+")
+	if subtype = strings.ToLower(strings.TrimSpace(strings.Split(subtype, ";")[0])); subtype == "" {
+		subtype = "proto"
+	}
+	var fields *grpcWebFields
+	if s.visibility != nil {
+		state := s.visibility.newState(ctx, r)
+		if err := state.checkMethod(rpcMethod); err != nil {
+			gw.writeTrailers(status.Errorf(codes.Unimplemented, "unknown method %s", r.URL.Path), nil)
+			return
+		}
+		var err error
+		if fields, err = newGRPCWebFields(state, rpcMethod, subtype); err != nil {
+			gw.writeTrailers(err, nil)
+			return
+		}
+	}
+	if s.rateLimit != nil {
+		ctx = withResponseHeader(ctx, w.Header())
+	}
+	// The metadata of the call is built like for the other requests, which
+	// also enforces the rate limits.
+	ctx, md, err := annotateContext(ctx, s, r, rpcMethod)
+	if err != nil {
+		gw.writeTrailers(err, nil)
+		return
+	}
+	if md != nil {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	messages, err := c.readRequest(w, r)
+	if err != nil {
+		gw.writeTrailers(err, nil)
+		return
+	}
+	if fields != nil {
+		for i, msg := range messages {
+			if messages[i], err = fields.checkRequest(msg); err != nil {
+				gw.writeTrailers(err, nil)
+				return
+			}
+		}
+	}
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, rpcMethod, grpc.ForceCodec(rawCodec{name: subtype}))
+	if err != nil {
+		gw.writeTrailers(err, nil)
+		return
+	}
+	for _, msg := range messages {
+		if err := stream.SendMsg(&msg); err != nil {
+			// The status of the call is returned by RecvMsg.
+			break
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		grpclog.Errorf("Failed to close gRPC-Web request stream: %v", err)
+	}
+
+	header, _ := stream.Header()
+	gw.writeHeader(header)
+	for {
+		var msg []byte
+		err := stream.RecvMsg(&msg)
+		if errors.Is(err, io.EOF) {
+			gw.writeTrailers(nil, stream.Trailer())
+			return
+		}
+		if err != nil {
+			gw.writeTrailers(err, stream.Trailer())
+			return
+		}
+		if fields != nil {
+			if msg, err = fields.stripResponse(msg); err != nil {
+				gw.writeTrailers(err, nil)
+				return
+			}
+		}
+		if err := gw.writeFrame(0, msg); err != nil {
+			grpclog.Errorf("Failed to write gRPC-Web response: %v", err)
+			return
+		}
+	}
+}
+
+// readRequest returns the messages of the body of the gRPC-Web request r.
+func (c *grpcWebConfig) readRequest(w http.ResponseWriter, r *http.Request) ([][]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, c.opts.MaxRequestBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, status.Errorf(codes.ResourceExhausted, "request body larger than %d bytes", maxBytesErr.Limit)
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "reading request body: %v", err)
+	}
+	if isGRPCWebTextContentType(r.Header.Get(contentTypeHeader)) {
+		if body, err = decodeGRPCWebText(body); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "decoding request body: %v", err)
+		}
+	}
+	var messages [][]byte
+	for len(body) > 0 {
+		if len(body) < 5 {
+			return nil, status.Error(codes.InvalidArgument, "truncated gRPC-Web frame")
+		}
+		flags, length := body[0], binary.BigEndian.Uint32(body[1:5])
+		if uint64(len(body)-5) < uint64(length) {
+			return nil, status.Error(codes.InvalidArgument, "truncated gRPC-Web frame")
+		}
+		msg := body[5 : 5+length]
+		body = body[5+length:]
+		switch {
+		case flags&grpcWebTrailerFlag != 0:
+			continue
+		case flags&grpcWebCompressedFlag != 0:
+			return nil, status.Error(codes.Unimplemented, "compressed gRPC-Web messages are not supported")
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+// decodeGRPCWebText decodes a base64 encoded request body, which may be the
+// concatenation of padded chunks.
+func decodeGRPCWebText(body []byte) ([]byte, error) {
+	body = bytes.Join(bytes.Fields(body), nil)
+	var decoded []byte
+	for len(body) > 0 {
+		// Padding ends a chunk.
+		end := len(body)
+		if i := bytes.IndexByte(body, '='); i >= 0 {
+			end = i
+			for end < len(body) && body[end] == '=' {
+				end++
+			}
+		}
+		chunk, err := base64.StdEncoding.DecodeString(string(body[:end]))
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, chunk...)
+		body = body[end:]
+	}
+	return decoded, nil
+}
+
+// grpcWebFields enforces the visibility of the fields of the messages of a
+// gRPC-Web call, which are otherwise forwarded as is.
+type grpcWebFields struct {
+	state             *visibilityState
+	request, response protoreflect.MessageType
+}
+
+// newGRPCWebFields returns the grpcWebFields of a call of the method rpcMethod
+// for the request of state, or nil if the method is not registered in
+// protoregistry.GlobalFiles. Only the messages of the proto subtype can be
+// decoded, so the calls of other subtypes fail if the messages of the method
+// have fields or enum values with a visibility restriction.
+func newGRPCWebFields(state *visibilityState, rpcMethod, subtype string) (*grpcWebFields, error) {
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(fullMethodName(rpcMethod)))
+	if err != nil {
+		return nil, nil
+	}
+	md, ok := d.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, nil
+	}
+	if subtype != "proto" {
+		seen := make(map[protoreflect.FullName]bool)
+		if state.config.hasRestrictedFields(md.Input(), seen) || state.config.hasRestrictedFields(md.Output(), seen) {
+			return nil, status.Errorf(codes.Unimplemented, "%s only supports the proto subtype of gRPC-Web", rpcMethod)
+		}
+		return nil, nil
+	}
+	return &grpcWebFields{
+		state:    state,
+		request:  grpcWebMessageType(md.Input()),
+		response: grpcWebMessageType(md.Output()),
+	}, nil
+}
+
+func grpcWebMessageType(md protoreflect.MessageDescriptor) protoreflect.MessageType {
+	if typ, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err == nil {
+		return typ
+	}
+	return dynamicpb.NewMessageType(md)
+}
+
+// checkRequest rejects or clears the fields of the request message data which
+// are not visible, and returns the message to forward.
+func (f *grpcWebFields) checkRequest(data []byte) ([]byte, error) {
+	msg := f.request.New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "decoding request message: %v", err)
+	}
+	if err := f.state.checkRequest(msg); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return proto.Marshal(msg)
+}
+
+// stripResponse clears the fields of the response message data which are not
+// visible, and returns the message to send.
+func (f *grpcWebFields) stripResponse(data []byte) ([]byte, error) {
+	msg := f.response.New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, status.Errorf(codes.Internal, "decoding response message: %v", err)
+	}
+	f.state.stripResponse(msg)
+	return proto.Marshal(msg)
+}
+
+// rawCodec passes the messages of gRPC-Web requests to the gRPC server as is.
+// Its name is the content subtype of the requests, like "proto".
+type rawCodec struct {
+	name string
+}
+
+func (c rawCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("rawCodec: unexpected message type %T", v)
+	}
+	return *msg, nil
+}
+
+func (c rawCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("rawCodec: unexpected message type %T", v)
+	}
+	*msg = append([]byte(nil), data...)
+	return nil
+}
+
+func (c rawCodec) Name() string {
+	return c.name
+}
+
+// grpcWebResponseWriter writes the frames of a gRPC-Web response body,
+// encoded in base64 for application/grpc-web-text requests.
+type grpcWebResponseWriter struct {
+	w           http.ResponseWriter
+	text        bool
+	wroteHeader bool
+}
+
+// writeHeader sends the header metadata of the call as response headers.
+func (gw *grpcWebResponseWriter) writeHeader(md metadata.MD) {
+	if gw.wroteHeader {
+		return
+	}
+	gw.wroteHeader = true
+	exposed := []string{"grpc-status", "grpc-message"}
+	for key, values := range md {
+		// The content type and the headers of the gRPC transport do not
+		// apply to the gRPC-Web response.
+		if key == "content-type" || strings.HasPrefix(key, "grpc-") {
+			continue
+		}
+		for _, v := range values {
+			if strings.HasSuffix(key, grpcWebBinarySuffix) {
+				v = base64.StdEncoding.EncodeToString([]byte(v))
+			}
+			gw.w.Header().Add(key, v)
+		}
+		exposed = append(exposed, key)
+	}
+	if gw.w.Header().Get("Access-Control-Allow-Origin") != "" {
+		sort.Strings(exposed[2:])
+		gw.w.Header().Set("Access-Control-Expose-Headers", strings.Join(exposed, ", "))
+	}
+	gw.w.WriteHeader(http.StatusOK)
+}
+
+func (gw *grpcWebResponseWriter) writeFrame(flags byte, payload []byte) error {
+	frame := make([]byte, 5+len(payload))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(payload)))
+	copy(frame[5:], payload)
+	if gw.text {
+		frame = []byte(base64.StdEncoding.EncodeToString(frame))
+	}
+	if _, err := gw.w.Write(frame); err != nil {
+		return err
+	}
+	return http.NewResponseController(gw.w).Flush()
+}
+
+// writeTrailers ends the response with a frame holding the status of the
+// call, err, and its trailer metadata.
+func (gw *grpcWebResponseWriter) writeTrailers(err error, trailer metadata.MD) {
+	gw.writeHeader(nil)
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.FromContextError(err)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "grpc-status: %d\r\n", st.Code())
+	if st.Message() != "" {
+		fmt.Fprintf(&b, "grpc-message: %s\r\n", encodeGRPCMessage(st.Message()))
+	}
+	keys := make([]string, 0, len(trailer))
+	for key := range trailer {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, v := range trailer[key] {
+			if strings.HasSuffix(key, grpcWebBinarySuffix) {
+				v = base64.StdEncoding.EncodeToString([]byte(v))
+			}
+			fmt.Fprintf(&b, "%s: %s\r\n", key, v)
+		}
+	}
+	if err := gw.writeFrame(grpcWebTrailerFlag, []byte(b.String())); err != nil {
+		grpclog.Errorf("Failed to write gRPC-Web trailers: %v", err)
+	}
+}
+
+// recoverGRPCWeb recovers from a panic while serving the gRPC-Web request r,
+// which then ends with an Internal error.
+func (s *ServeMux) recoverGRPCWeb(gw *grpcWebResponseWriter, r *http.Request) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	if p == http.ErrAbortHandler {
+		panic(p)
+	}
+	s.recoveryHandler(r.Context(), r, p, debug.Stack())
+	gw.writeTrailers(errRecovered, nil)
+}
+
+// encodeGRPCMessage percent-encodes the status message of a call, as gRPC
+// does for the grpc-message trailer.
+func encodeGRPCMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,379 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// startGRPCWebBackend serves a grpc health server in-process, recording the
+// metadata of its calls in md, and returns a connection to it.
+func startGRPCWebBackend(t *testing.T, md *metadata.MD) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		*md, _ = metadata.FromIncomingContext(ctx)
+		if err := grpc.SetHeader(ctx, metadata.Pairs("x-backend", "health")); err != nil {
+			return nil, err
+		}
+		grpc.SetTrailer(ctx, metadata.Pairs("x-checked", "1"))
+		return handler(ctx, req)
+	}))
+	hs := health.NewServer()
+	hs.SetServingStatus("books", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() failed with %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// grpcWebFrame returns msg framed for a gRPC-Web request body.
+func grpcWebFrame(t *testing.T, msg proto.Message) []byte {
+	t.Helper()
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("proto.Marshal(%v) failed with %v", msg, err)
+	}
+	frame := make([]byte, 5, 5+len(b))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(b)))
+	return append(frame, b...)
+}
+
+// parseGRPCWebResponse returns the messages and the trailers of a gRPC-Web
+// response body.
+func parseGRPCWebResponse(t *testing.T, body []byte, text bool) ([][]byte, string) {
+	t.Helper()
+	if text {
+		var decoded []byte
+		for _, chunk := range strings.SplitAfter(string(body), "=") {
+			chunk = strings.TrimLeft(chunk, "=")
+			if chunk == "" {
+				continue
+			}
+			b, err := base64.StdEncoding.DecodeString(chunk + strings.Repeat("=", (4-len(chunk)%4)%4))
+			if err != nil {
+				t.Fatalf("decoding %q failed with %v", body, err)
+			}
+			decoded = append(decoded, b...)
+		}
+		body = decoded
+	}
+	var (
+		messages [][]byte
+		trailers string
+	)
+	for len(body) >= 5 {
+		length := binary.BigEndian.Uint32(body[1:5])
+		payload := body[5 : 5+length]
+		if body[0]&0x80 != 0 {
+			trailers = string(payload)
+		} else {
+			messages = append(messages, payload)
+		}
+		body = body[5+length:]
+	}
+	return messages, trailers
+}
+
+func TestGRPCWeb(t *testing.T) {
+	var md metadata.MD
+	mux := runtime.NewServeMux(
+		runtime.WithGRPCWeb(runtime.GRPCWebOptions{AllowedOrigins: []string{"https://app.example.com"}}),
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			if key == "X-Api-Key" {
+				return "x-api-key", true
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
+		runtime.WithMetadata(func(context.Context, *http.Request) metadata.MD {
+			return metadata.Pairs("x-annotated", "1")
+		}),
+	)
+	mux.RegisterServiceConn("grpc.health.v1.Health", startGRPCWebBackend(t, &md), runtime.RegisterOptions{})
+
+	for _, spec := range []struct {
+		name         string
+		contentType  string
+		service      string
+		wantStatus   grpc_health_v1.HealthCheckResponse_ServingStatus
+		wantTrailers string
+	}{
+		{
+			name:         "binary",
+			contentType:  "application/grpc-web+proto",
+			wantStatus:   grpc_health_v1.HealthCheckResponse_SERVING,
+			wantTrailers: "grpc-status: 0\r\nx-checked: 1\r\n",
+		},
+		{
+			name:         "text",
+			contentType:  "application/grpc-web-text",
+			service:      "books",
+			wantStatus:   grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+			wantTrailers: "grpc-status: 0\r\nx-checked: 1\r\n",
+		},
+		{
+			name:         "error",
+			contentType:  "application/grpc-web",
+			service:      "unknown",
+			wantTrailers: "grpc-status: 5\r\ngrpc-message: unknown service\r\nx-checked: 1\r\n",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			text := strings.HasPrefix(spec.contentType, "application/grpc-web-text")
+			body := grpcWebFrame(t, &grpc_health_v1.HealthCheckRequest{Service: spec.service})
+			if text {
+				body = []byte(base64.StdEncoding.EncodeToString(body))
+			}
+			r := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Check", bytes.NewReader(body))
+			r.Header.Set("Content-Type", spec.contentType)
+			r.Header.Set("Origin", "https://app.example.com")
+			r.Header.Set("X-Grpc-Web", "1")
+			r.Header.Set("X-Api-Key", "secret")
+			r.Header.Set("X-Rejected", "1")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d; want %d", w.Code, http.StatusOK)
+			}
+			for name, want := range map[string]string{
+				"Content-Type":                  spec.contentType,
+				"Access-Control-Allow-Origin":   "https://app.example.com",
+				"Access-Control-Expose-Headers": "grpc-status, grpc-message, x-backend",
+				"X-Backend":                     "health",
+			} {
+				if got := w.Header().Values(name); len(got) != 1 || got[0] != want {
+					t.Errorf("%s = %q; want %q", name, got, want)
+				}
+			}
+			messages, trailers := parseGRPCWebResponse(t, w.Body.Bytes(), text)
+			if trailers != spec.wantTrailers {
+				t.Errorf("trailers = %q; want %q", trailers, spec.wantTrailers)
+			}
+			if spec.wantStatus != grpc_health_v1.HealthCheckResponse_UNKNOWN {
+				if len(messages) != 1 {
+					t.Fatalf("got %d messages; want 1", len(messages))
+				}
+				resp := &grpc_health_v1.HealthCheckResponse{}
+				if err := proto.Unmarshal(messages[0], resp); err != nil {
+					t.Fatalf("proto.Unmarshal(...) failed with %v", err)
+				}
+				if resp.GetStatus() != spec.wantStatus {
+					t.Errorf("status = %v; want %v", resp.GetStatus(), spec.wantStatus)
+				}
+			}
+			if got := md.Get("x-api-key"); len(got) != 1 || got[0] != "secret" {
+				t.Errorf("metadata x-api-key = %q; want [secret]", got)
+			}
+			if got := md.Get("x-annotated"); len(got) != 1 || got[0] != "1" {
+				t.Errorf("metadata x-annotated = %q; want [1]", got)
+			}
+			// The headers rejected by the header matcher are not forwarded.
+			for _, key := range []string{"x-grpc-web", "x-rejected"} {
+				if got := md.Get(key); len(got) != 0 {
+					t.Errorf("metadata %s = %q; want none", key, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGRPCWebErrors(t *testing.T) {
+	var md metadata.MD
+	mux := runtime.NewServeMux(runtime.WithGRPCWeb(runtime.GRPCWebOptions{}))
+	mux.RegisterServiceConn("grpc.health.v1.Health", startGRPCWebBackend(t, &md), runtime.RegisterOptions{Methods: []string{"grpc.health.v1.Health.Check"}})
+
+	for _, spec := range []struct {
+		name         string
+		path         string
+		body         []byte
+		wantTrailers string
+	}{
+		{
+			name:         "unknown service",
+			path:         "/example.Books/Get",
+			wantTrailers: "grpc-status: 12\r\ngrpc-message: unknown method /example.Books/Get\r\n",
+		},
+		{
+			name:         "method not registered",
+			path:         "/grpc.health.v1.Health/Watch",
+			wantTrailers: "grpc-status: 12\r\ngrpc-message: unknown method /grpc.health.v1.Health/Watch\r\n",
+		},
+		{
+			name:         "truncated frame",
+			path:         "/grpc.health.v1.Health/Check",
+			body:         []byte{0, 0, 0, 0, 10, 1},
+			wantTrailers: "grpc-status: 3\r\ngrpc-message: truncated gRPC-Web frame\r\n",
+		},
+		{
+			name:         "compressed frame",
+			path:         "/grpc.health.v1.Health/Check",
+			body:         []byte{1, 0, 0, 0, 0},
+			wantTrailers: "grpc-status: 12\r\ngrpc-message: compressed gRPC-Web messages are not supported\r\n",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, spec.path, bytes.NewReader(spec.body))
+			r.Header.Set("Content-Type", "application/grpc-web+proto")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d; want %d", w.Code, http.StatusOK)
+			}
+			if _, trailers := parseGRPCWebResponse(t, w.Body.Bytes(), false); trailers != spec.wantTrailers {
+				t.Errorf("trailers = %q; want %q", trailers, spec.wantTrailers)
+			}
+		})
+	}
+}
+
+// serveGRPCWebCheck sends a gRPC-Web health check request to mux, and returns
+// the response and its trailers.
+func serveGRPCWebCheck(t *testing.T, mux *runtime.ServeMux) (*httptest.ResponseRecorder, string) {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Check", bytes.NewReader(grpcWebFrame(t, &grpc_health_v1.HealthCheckRequest{})))
+	r.Header.Set("Content-Type", "application/grpc-web+proto")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d; want %d", w.Code, http.StatusOK)
+	}
+	_, trailers := parseGRPCWebResponse(t, w.Body.Bytes(), false)
+	return w, trailers
+}
+
+func TestGRPCWebRateLimit(t *testing.T) {
+	var md metadata.MD
+	mux := runtime.NewServeMux(
+		runtime.WithGRPCWeb(runtime.GRPCWebOptions{}),
+		runtime.WithRateLimit(runtime.NewMemoryRateLimiter(), runtime.RateLimitPolicy{
+			Selector: "grpc.health.v1.Health.*",
+			Limit:    runtime.RateLimit{Rate: 0.001, Burst: 1},
+		}),
+	)
+	mux.RegisterServiceConn("grpc.health.v1.Health", startGRPCWebBackend(t, &md), runtime.RegisterOptions{})
+
+	w, trailers := serveGRPCWebCheck(t, mux)
+	if want := "grpc-status: 0\r\nx-checked: 1\r\n"; trailers != want {
+		t.Errorf("trailers of the first request = %q; want %q", trailers, want)
+	}
+	if got := w.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("RateLimit-Remaining = %q; want %q", got, "0")
+	}
+
+	md = nil
+	w, trailers = serveGRPCWebCheck(t, mux)
+	if want := "grpc-status: 8\r\ngrpc-message: rate limit exceeded for /grpc.health.v1.Health/Check\r\n"; trailers != want {
+		t.Errorf("trailers of the second request = %q; want %q", trailers, want)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Errorf("Retry-After is not set on the rejected request")
+	}
+	if md != nil {
+		t.Errorf("the rejected request was forwarded with metadata %v", md)
+	}
+}
+
+func TestGRPCWebRecovery(t *testing.T) {
+	var (
+		md        metadata.MD
+		recovered interface{}
+	)
+	mux := runtime.NewServeMux(
+		runtime.WithGRPCWeb(runtime.GRPCWebOptions{}),
+		runtime.WithMetadata(func(context.Context, *http.Request) metadata.MD {
+			panic("annotator failed")
+		}),
+		runtime.WithRecovery(func(_ context.Context, _ *http.Request, p interface{}, _ []byte) {
+			recovered = p
+		}),
+	)
+	mux.RegisterServiceConn("grpc.health.v1.Health", startGRPCWebBackend(t, &md), runtime.RegisterOptions{})
+
+	_, trailers := serveGRPCWebCheck(t, mux)
+	if want := "grpc-status: 13\r\ngrpc-message: internal error\r\n"; trailers != want {
+		t.Errorf("trailers = %q; want %q", trailers, want)
+	}
+	if recovered != "annotator failed" {
+		t.Errorf("recovered %v; want %q", recovered, "annotator failed")
+	}
+}
+
+func TestGRPCWebCORS(t *testing.T) {
+	var md metadata.MD
+	mux := runtime.NewServeMux(runtime.WithGRPCWeb(runtime.GRPCWebOptions{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowCredentials: true,
+	}))
+	mux.RegisterServiceConn("grpc.health.v1.Health", startGRPCWebBackend(t, &md), runtime.RegisterOptions{})
+
+	for _, spec := range []struct {
+		name        string
+		method      string
+		origin      string
+		wantCode    int
+		wantAllowed bool
+	}{
+		{name: "preflight", method: http.MethodOptions, origin: "https://app.example.com", wantCode: http.StatusNoContent, wantAllowed: true},
+		{name: "same origin preflight", method: http.MethodOptions, origin: "http://example.com", wantCode: http.StatusNoContent, wantAllowed: true},
+		{name: "disallowed preflight", method: http.MethodOptions, origin: "https://evil.example.com", wantCode: http.StatusForbidden},
+		{name: "disallowed request", method: http.MethodPost, origin: "https://evil.example.com", wantCode: http.StatusForbidden},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			r := httptest.NewRequest(spec.method, "/grpc.health.v1.Health/Check", nil)
+			r.Header.Set("Origin", spec.origin)
+			if spec.method == http.MethodOptions {
+				r.Header.Set("Access-Control-Request-Method", "POST")
+				r.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,x-api-key")
+			} else {
+				r.Header.Set("Content-Type", "application/grpc-web")
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+			if w.Code != spec.wantCode {
+				t.Errorf("status = %d; want %d", w.Code, spec.wantCode)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); (got == spec.origin) != spec.wantAllowed {
+				t.Errorf("Access-Control-Allow-Origin = %q; want it only for allowed origins", got)
+			}
+			if !spec.wantAllowed {
+				return
+			}
+			for name, want := range map[string]string{
+				"Access-Control-Allow-Methods":     "POST, OPTIONS",
+				"Access-Control-Allow-Headers":     "content-type,x-grpc-web,x-api-key",
+				"Access-Control-Allow-Credentials": "true",
+				"Vary":                             "Origin",
+			} {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("%s = %q; want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/textproto"
 	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
@@ -27,6 +28,8 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 		return
 	}
 	handleForwardResponseServerMetadata(w, mux, md)
+	routeStatsFromContext(ctx).forwarded(true)
+	defer mux.drain.startStream(ctx)()
 
 	w.Header().Set("Transfer-Encoding", "chunked")
 	if err := handleForwardResponseOptions(ctx, w, nil, opts); err != nil {
@@ -56,6 +59,7 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 			return
 		}
 
+		visibilityStateFromContext(ctx).stripResponse(resp)
 		respRw, err := mux.forwardResponseRewriter(ctx, resp)
 		if err != nil {
 			grpclog.Errorf("Rewrite error: %v", err)
@@ -74,6 +78,7 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 		}
 
 		var buf []byte
+		marshalStart := time.Now()
 		httpBody, isHTTPBody := respRw.(*httpbody.HttpBody)
 		switch {
 		case respRw == nil:
@@ -81,13 +86,14 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 		case isHTTPBody:
 			buf = httpBody.GetData()
 		default:
-			result := map[string]interface{}{"result": respRw}
+			result := map[string]interface{}{"result": visibilityStateFromContext(ctx).responseView(respRw)}
 			if rb, ok := respRw.(responseBody); ok {
-				result["result"] = rb.XXX_ResponseBody()
+				result["result"] = visibilityStateFromContext(ctx).responseView(rb.XXX_ResponseBody())
 			}
 
 			buf, err = marshaler.Marshal(result)
 		}
+		requestStatsFromContext(ctx).responseMarshaled(ctx, marshalStart, len(buf), err)
 
 		if err != nil {
 			grpclog.Errorf("Failed to marshal response chunk: %v", err)
@@ -103,6 +109,7 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 			grpclog.Errorf("Failed to send delimiter chunk: %v", err)
 			return
 		}
+		requestStatsFromContext(ctx).streamMessageSent(ctx, len(buf))
 		err = rc.Flush()
 		if err != nil {
 			if errors.Is(err, http.ErrNotSupported) {
@@ -176,6 +183,9 @@ func ForwardResponseMessage(ctx context.Context, mux *ServeMux, marshaler Marsha
 		HTTPError(ctx, mux, marshaler, w, req, err)
 		return
 	}
+	responseCacheStateFromContext(ctx).responseForwarded(md)
+	routeStatsFromContext(ctx).forwarded(false)
+	visibilityStateFromContext(ctx).stripResponse(resp)
 	respRw, err := mux.forwardResponseRewriter(ctx, resp)
 	if err != nil {
 		grpclog.Errorf("Rewrite error: %v", err)
@@ -183,11 +193,13 @@ func ForwardResponseMessage(ctx context.Context, mux *ServeMux, marshaler Marsha
 		return
 	}
 	var buf []byte
+	marshalStart := time.Now()
 	if rb, ok := respRw.(responseBody); ok {
-		buf, err = marshaler.Marshal(rb.XXX_ResponseBody())
+		buf, err = marshaler.Marshal(visibilityStateFromContext(ctx).responseView(rb.XXX_ResponseBody()))
 	} else {
-		buf, err = marshaler.Marshal(respRw)
+		buf, err = marshaler.Marshal(visibilityStateFromContext(ctx).responseView(respRw))
 	}
+	requestStatsFromContext(ctx).responseMarshaled(ctx, marshalStart, len(buf), err)
 	if err != nil {
 		grpclog.Errorf("Marshal error: %v", err)
 		HTTPError(ctx, mux, marshaler, w, req, err)
@@ -225,8 +237,10 @@ func handleForwardResponseOptions(ctx context.Context, w http.ResponseWriter, re
 }
 
 func handleForwardResponseStreamError(ctx context.Context, wroteHeader bool, marshaler Marshaler, w http.ResponseWriter, req *http.Request, mux *ServeMux, err error, delimiter []byte) {
-	st := mux.streamErrorHandler(ctx, err)
-	msg := errorChunk(st)
+	st := mux.streamErrorHandler(ctx, mux.drain.convert(ctx, bodyLimitStreamError(ctx, err)))
+	requestStatsFromContext(ctx).setCode(st.Code())
+	routeStatsFromContext(ctx).recordError(st.Err())
+	msg := errorChunk(withRequestInfo(ctx, st))
 	if !wroteHeader {
 		w.Header().Set("Content-Type", marshaler.ContentType(msg))
 		w.WriteHeader(HTTPStatusFromCode(st.Code()))
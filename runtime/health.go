@@ -0,0 +1,366 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultHealthCheckTimeout = time.Second
+	healthWatchRetryDelay     = time.Second
+)
+
+// HealthComponent is a backend whose health is checked by a HealthChecker.
+type HealthComponent struct {
+	// Name identifies the component in health reports.
+	Name string
+	// Client is used to check the health of the component.
+	Client grpc_health_v1.HealthClient
+	// Service is sent as the service name of the health check requests.
+	// An empty service checks the health of the server as a whole.
+	Service string
+}
+
+// HealthComponentStatus is the result of the last health check of a HealthComponent.
+type HealthComponentStatus struct {
+	Name    string `json:"name"`
+	Service string `json:"service,omitempty"`
+	// Status is the name of the grpc_health_v1.HealthCheckResponse_ServingStatus of the component.
+	Status string `json:"status"`
+	// Error is set if the component could not be checked.
+	Error     string    `json:"error,omitempty"`
+	Latency   string    `json:"latency,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Serving reports whether the component was serving at the time of the check.
+func (s HealthComponentStatus) Serving() bool {
+	return s.Status == grpc_health_v1.HealthCheckResponse_SERVING.String()
+}
+
+// HealthReport is the aggregated health of the components of a HealthChecker.
+type HealthReport struct {
+	// Status is SERVING if the components satisfy the HealthPolicy, and NOT_SERVING otherwise.
+	Status     string                  `json:"status"`
+	Components []HealthComponentStatus `json:"components"`
+}
+
+// Serving reports whether the components satisfied the HealthPolicy.
+func (r HealthReport) Serving() bool {
+	return r.Status == grpc_health_v1.HealthCheckResponse_SERVING.String()
+}
+
+// HealthPolicy decides whether a set of component statuses is healthy.
+type HealthPolicy func(components []HealthComponentStatus) bool
+
+// HealthPolicyAll is a HealthPolicy requiring every component to be serving.
+func HealthPolicyAll(components []HealthComponentStatus) bool {
+	for _, c := range components {
+		if !c.Serving() {
+			return false
+		}
+	}
+	return true
+}
+
+// HealthPolicyQuorum returns a HealthPolicy requiring at least n components to be serving.
+func HealthPolicyQuorum(n int) HealthPolicy {
+	return func(components []HealthComponentStatus) bool {
+		serving := 0
+		for _, c := range components {
+			if c.Serving() {
+				serving++
+			}
+		}
+		return serving >= n
+	}
+}
+
+// HealthCheckerOption is an option that can be given to NewHealthChecker.
+type HealthCheckerOption func(*HealthChecker)
+
+// WithHealthCheckTimeout sets the timeout of each component health check.
+// The default is one second.
+func WithHealthCheckTimeout(timeout time.Duration) HealthCheckerOption {
+	return func(h *HealthChecker) {
+		h.timeout = timeout
+	}
+}
+
+// WithHealthPolicy sets the policy used to aggregate the component statuses.
+// The default is HealthPolicyAll.
+func WithHealthPolicy(policy HealthPolicy) HealthCheckerOption {
+	return func(h *HealthChecker) {
+		h.policy = policy
+	}
+}
+
+// WithHealthCacheInterval makes the HealthChecker reuse the results of a
+// check for the given interval instead of checking the components on every
+// probe. By default, results are not cached.
+func WithHealthCacheInterval(interval time.Duration) HealthCheckerOption {
+	return func(h *HealthChecker) {
+		h.cacheInterval = interval
+	}
+}
+
+// WithHealthWatch makes the HealthChecker keep the status of each component
+// current with the Watch streaming RPC instead of calling Check on each probe.
+// Components whose server does not implement Watch are polled with Check.
+// The streams are closed by HealthChecker.Close.
+func WithHealthWatch() HealthCheckerOption {
+	return func(h *HealthChecker) {
+		h.watch = true
+	}
+}
+
+// HealthChecker aggregates the health of several gRPC backends, for example to
+// implement Kubernetes readiness and liveness probes.
+//
+// Components are checked concurrently with the gRPC Health Checking Protocol.
+// Use WithHealthCheckerEndpointAt to serve its report from a ServeMux.
+type HealthChecker struct {
+	components    []HealthComponent
+	timeout       time.Duration
+	policy        HealthPolicy
+	cacheInterval time.Duration
+	watch         bool
+
+	// mu guards the cached statuses and serializes checks, so that concurrent
+	// probes share the results of a single round of checks.
+	mu        sync.Mutex
+	statuses  []HealthComponentStatus
+	checkedAt time.Time
+
+	// watchMu guards watched, which holds the last status received from the
+	// Watch stream of each component, or nil if the component is polled.
+	watchMu sync.Mutex
+	watched []*HealthComponentStatus
+	cancel  context.CancelFunc
+	done    sync.WaitGroup
+}
+
+// NewHealthChecker returns a HealthChecker for the given components.
+// A HealthChecker without components always reports SERVING, which is
+// suitable for liveness probes of the gateway itself.
+func NewHealthChecker(components []HealthComponent, opts ...HealthCheckerOption) *HealthChecker {
+	h := &HealthChecker{
+		components: components,
+		timeout:    defaultHealthCheckTimeout,
+		policy:     HealthPolicyAll,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.watch {
+		ctx, cancel := context.WithCancel(context.Background())
+		h.cancel = cancel
+		h.watched = make([]*HealthComponentStatus, len(components))
+		for i, c := range components {
+			h.watched[i] = &HealthComponentStatus{
+				Name:    c.Name,
+				Service: c.Service,
+				Status:  grpc_health_v1.HealthCheckResponse_UNKNOWN.String(),
+			}
+			h.done.Add(1)
+			go h.watchComponent(ctx, i)
+		}
+	}
+
+	return h
+}
+
+// Close stops the Watch streams started by WithHealthWatch.
+func (h *HealthChecker) Close() {
+	if h.cancel != nil {
+		h.cancel()
+	}
+	h.done.Wait()
+}
+
+// Check returns the health of the components, checking them if needed.
+func (h *HealthChecker) Check(ctx context.Context) HealthReport {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.statuses == nil || h.cacheInterval <= 0 || time.Since(h.checkedAt) >= h.cacheInterval {
+		// The results may be shared with other probes, so they must not be
+		// cut short if the caller goes away.
+		h.statuses = h.checkComponents(context.WithoutCancel(ctx))
+		h.checkedAt = time.Now()
+	}
+
+	report := HealthReport{
+		Status:     grpc_health_v1.HealthCheckResponse_NOT_SERVING.String(),
+		Components: append(make([]HealthComponentStatus, 0, len(h.statuses)), h.statuses...),
+	}
+	if h.policy(report.Components) {
+		report.Status = grpc_health_v1.HealthCheckResponse_SERVING.String()
+	}
+	return report
+}
+
+func (h *HealthChecker) checkComponents(ctx context.Context) []HealthComponentStatus {
+	statuses := make([]HealthComponentStatus, len(h.components))
+	var wg sync.WaitGroup
+	for i := range h.components {
+		if s, ok := h.watchedStatus(i); ok {
+			statuses[i] = s
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statuses[i] = h.checkComponent(ctx, h.components[i])
+		}(i)
+	}
+	wg.Wait()
+	return statuses
+}
+
+func (h *HealthChecker) checkComponent(ctx context.Context, c HealthComponent) HealthComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := c.Client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: c.Service})
+	s := HealthComponentStatus{
+		Name:      c.Name,
+		Service:   c.Service,
+		Status:    resp.GetStatus().String(),
+		Latency:   time.Since(start).String(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		s.Error = err.Error()
+		if status.Code(err) == codes.NotFound {
+			s.Status = grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN.String()
+		}
+	}
+	return s
+}
+
+func (h *HealthChecker) watchedStatus(i int) (HealthComponentStatus, bool) {
+	h.watchMu.Lock()
+	defer h.watchMu.Unlock()
+	if h.watched == nil || h.watched[i] == nil {
+		return HealthComponentStatus{}, false
+	}
+	return *h.watched[i], true
+}
+
+func (h *HealthChecker) setWatchedStatus(i int, s *HealthComponentStatus) {
+	h.watchMu.Lock()
+	defer h.watchMu.Unlock()
+	h.watched[i] = s
+}
+
+// watchComponent keeps the status of the i-th component current until ctx is
+// canceled, reconnecting the Watch stream when it fails.
+func (h *HealthChecker) watchComponent(ctx context.Context, i int) {
+	defer h.done.Done()
+	c := h.components[i]
+	for {
+		err := h.watchStream(ctx, i, c)
+		if ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			grpclog.Infof("health watch of %q is unimplemented, falling back to polling", c.Name)
+			h.setWatchedStatus(i, nil)
+			return
+		}
+		h.setWatchedStatus(i, &HealthComponentStatus{
+			Name:      c.Name,
+			Service:   c.Service,
+			Status:    grpc_health_v1.HealthCheckResponse_UNKNOWN.String(),
+			Error:     err.Error(),
+			CheckedAt: time.Now(),
+		})
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(healthWatchRetryDelay):
+		}
+	}
+}
+
+func (h *HealthChecker) watchStream(ctx context.Context, i int, c HealthComponent) error {
+	stream, err := c.Client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: c.Service})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return status.Error(codes.Unavailable, "health watch stream closed")
+			}
+			return err
+		}
+		h.setWatchedStatus(i, &HealthComponentStatus{
+			Name:      c.Name,
+			Service:   c.Service,
+			Status:    resp.GetStatus().String(),
+			CheckedAt: time.Now(),
+		})
+	}
+}
+
+// ServeHTTP writes the JSON HealthReport of the components, with a 200 status
+// code if it is SERVING and a 503 status code otherwise.
+func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := h.Check(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Serving() {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		grpclog.Errorf("Failed to write health report: %v", err)
+	}
+}
+
+// WithHealthCheckerEndpointAt returns a ServeMuxOption that serves the
+// aggregated HealthReport of checker at endpointPath.
+//
+// See here https://grpc-ecosystem.github.io/grpc-gateway/docs/operations/health_check/ for more information.
+func WithHealthCheckerEndpointAt(checker *HealthChecker, endpointPath string) ServeMuxOption {
+	return func(s *ServeMux) {
+		// error can be ignored since pattern is definitely valid
+		_ = s.HandlePath(
+			http.MethodGet, endpointPath, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+				checker.ServeHTTP(w, r)
+			})
+	}
+}
+
+// WithReadyzEndpoint returns a ServeMuxOption that serves the report of
+// checker at /readyz, for use as a readiness probe.
+//
+// See WithHealthCheckerEndpointAt for the general implementation.
+func WithReadyzEndpoint(checker *HealthChecker) ServeMuxOption {
+	return WithHealthCheckerEndpointAt(checker, "/readyz")
+}
+
+// WithLivezEndpoint returns a ServeMuxOption that serves the report of
+// checker at /livez, for use as a liveness probe.
+//
+// See WithHealthCheckerEndpointAt for the general implementation.
+func WithLivezEndpoint(checker *HealthChecker) ServeMuxOption {
+	return WithHealthCheckerEndpointAt(checker, "/livez")
+}
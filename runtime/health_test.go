@@ -0,0 +1,231 @@
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startHealthServer serves a grpc health server in-process and returns it
+// with a client connected to it.
+func startHealthServer(t *testing.T) (*health.Server, grpc_health_v1.HealthClient) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	hs := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() failed with %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return hs, grpc_health_v1.NewHealthClient(conn)
+}
+
+type countingHealthClient struct {
+	grpc_health_v1.HealthClient
+	checks atomic.Int32
+	delay  time.Duration
+}
+
+func (c *countingHealthClient) Check(ctx context.Context, r *grpc_health_v1.HealthCheckRequest, opts ...grpc.CallOption) (*grpc_health_v1.HealthCheckResponse, error) {
+	c.checks.Add(1)
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func TestHealthChecker_policies(t *testing.T) {
+	hs, client := startHealthServer(t)
+	hs.SetServingStatus("a", grpc_health_v1.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("b", grpc_health_v1.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("c", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	components := []runtime.HealthComponent{
+		{Name: "a", Client: client, Service: "a"},
+		{Name: "b", Client: client, Service: "b"},
+		{Name: "c", Client: client, Service: "c"},
+		{Name: "d", Client: client, Service: "d"},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		policy  runtime.HealthPolicy
+		serving bool
+	}{
+		{"all", runtime.HealthPolicyAll, false},
+		{"quorum of 2", runtime.HealthPolicyQuorum(2), true},
+		{"quorum of 3", runtime.HealthPolicyQuorum(3), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			report := runtime.NewHealthChecker(components, runtime.WithHealthPolicy(tt.policy)).Check(context.Background())
+			if report.Serving() != tt.serving {
+				t.Errorf("report.Serving() = %v; want %v", report.Serving(), tt.serving)
+			}
+			want := []string{"SERVING", "SERVING", "NOT_SERVING", "SERVICE_UNKNOWN"}
+			for i, c := range report.Components {
+				if c.Name != components[i].Name || c.Status != want[i] {
+					t.Errorf("report.Components[%d] = %+v; want name %q and status %q", i, c, components[i].Name, want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHealthChecker_timeout(t *testing.T) {
+	slow := &countingHealthClient{delay: time.Minute}
+	fast := &countingHealthClient{}
+	checker := runtime.NewHealthChecker([]runtime.HealthComponent{
+		{Name: "slow", Client: slow},
+		{Name: "fast", Client: fast},
+	}, runtime.WithHealthCheckTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	report := checker.Check(context.Background())
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Check() took %v", elapsed)
+	}
+	if report.Serving() {
+		t.Errorf("report.Serving() = true; want false")
+	}
+	if s := report.Components[0]; s.Serving() || s.Error == "" {
+		t.Errorf("slow component = %+v; want a timeout error", s)
+	}
+	if s := report.Components[1]; !s.Serving() {
+		t.Errorf("fast component = %+v; want SERVING", s)
+	}
+}
+
+func TestHealthChecker_cacheInterval(t *testing.T) {
+	client := &countingHealthClient{}
+	components := []runtime.HealthComponent{{Name: "a", Client: client}}
+
+	uncached := runtime.NewHealthChecker(components)
+	uncached.Check(context.Background())
+	uncached.Check(context.Background())
+	if got := client.checks.Load(); got != 2 {
+		t.Errorf("uncached checker called Check %d times; want 2", got)
+	}
+
+	client.checks.Store(0)
+	cached := runtime.NewHealthChecker(components, runtime.WithHealthCacheInterval(time.Hour))
+	cached.Check(context.Background())
+	cached.Check(context.Background())
+	if got := client.checks.Load(); got != 1 {
+		t.Errorf("cached checker called Check %d times; want 1", got)
+	}
+}
+
+func TestHealthChecker_watch(t *testing.T) {
+	hs, client := startHealthServer(t)
+	hs.SetServingStatus("a", grpc_health_v1.HealthCheckResponse_SERVING)
+	checker := runtime.NewHealthChecker([]runtime.HealthComponent{{Name: "a", Client: client, Service: "a"}}, runtime.WithHealthWatch())
+	defer checker.Close()
+
+	waitFor := func(serving bool) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for checker.Check(context.Background()).Serving() != serving {
+			if time.Now().After(deadline) {
+				t.Fatalf("checker did not report serving = %v", serving)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor(true)
+	hs.SetServingStatus("a", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	waitFor(false)
+}
+
+func TestHealthChecker_watchUnimplemented(t *testing.T) {
+	client := &dummyHealthCheckClient{status: grpc_health_v1.HealthCheckResponse_SERVING, code: codes.OK}
+	checker := runtime.NewHealthChecker([]runtime.HealthComponent{{Name: "a", Client: client}}, runtime.WithHealthWatch())
+	defer checker.Close()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !checker.Check(context.Background()).Serving() {
+		if time.Now().After(deadline) {
+			t.Fatalf("checker did not fall back to polling")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWithHealthCheckerEndpointAt(t *testing.T) {
+	ready := &dummyHealthCheckClient{status: grpc_health_v1.HealthCheckResponse_SERVING, code: codes.OK}
+	notReady := &dummyHealthCheckClient{status: grpc_health_v1.HealthCheckResponse_NOT_SERVING, code: codes.OK}
+	mux := runtime.NewServeMux(
+		runtime.WithLivezEndpoint(runtime.NewHealthChecker(nil)),
+		runtime.WithReadyzEndpoint(runtime.NewHealthChecker([]runtime.HealthComponent{
+			{Name: "ready", Client: ready},
+			{Name: "not-ready", Client: notReady, Service: "svc"},
+		})),
+	)
+
+	for _, tt := range []struct {
+		path       string
+		statusCode int
+		report     runtime.HealthReport
+	}{
+		{
+			path:       "/livez",
+			statusCode: http.StatusOK,
+			report:     runtime.HealthReport{Status: "SERVING", Components: []runtime.HealthComponentStatus{}},
+		},
+		{
+			path:       "/readyz",
+			statusCode: http.StatusServiceUnavailable,
+			report: runtime.HealthReport{Status: "NOT_SERVING", Components: []runtime.HealthComponentStatus{
+				{Name: "ready", Status: "SERVING"},
+				{Name: "not-ready", Service: "svc", Status: "NOT_SERVING"},
+			}},
+		},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.statusCode {
+				t.Errorf("w.Code = %d; want %d", w.Code, tt.statusCode)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q; want application/json", got)
+			}
+			var report runtime.HealthReport
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("json.Unmarshal(%q) failed with %v", w.Body.String(), err)
+			}
+			if report.Status != tt.report.Status || len(report.Components) != len(tt.report.Components) {
+				t.Fatalf("report = %+v; want %+v", report, tt.report)
+			}
+			for i, c := range report.Components {
+				want := tt.report.Components[i]
+				if c.Name != want.Name || c.Service != want.Service || c.Status != want.Status || c.CheckedAt.IsZero() {
+					t.Errorf("report.Components[%d] = %+v; want %+v", i, c, want)
+				}
+			}
+		})
+	}
+}
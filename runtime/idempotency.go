@@ -0,0 +1,322 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header holding the idempotency key
+	// of POST and PATCH requests, as specified by the IETF Idempotency-Key
+	// HTTP header field draft.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set to "true" on the responses replayed for
+	// a request with an idempotency key which was already used.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength is the maximum length of the idempotency keys.
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyRecord is the record of a request with an idempotency key in an
+// IdempotencyStore.
+type IdempotencyRecord struct {
+	// Fingerprint identifies the request, its path parameters, query and body,
+	// so that a key used for another request is detected.
+	Fingerprint string
+	// Response is the response to the request, or nil while it is in flight.
+	Response *CachedResponse
+}
+
+// IdempotencyStore stores the responses of the requests with an idempotency
+// key. It may be backed by a store shared by several gateways.
+type IdempotencyStore interface {
+	// Begin records that the request identified by fingerprint is in flight
+	// for key and returns nil, unless key already has a record, which is
+	// returned instead. The record expires after ttl.
+	Begin(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, error)
+	// Complete stores the response of the request in flight for key, for ttl.
+	Complete(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error
+	// Release deletes the record of key, so that the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyPolicy configures the idempotency keys of a ServeMux.
+type IdempotencyPolicy struct {
+	// TTL is the time the responses are kept for. The default is 24 hours.
+	TTL time.Duration
+	// Client returns the identity of the client of a request, which has its
+	// own keys. The default is the value of the Authorization header, or the
+	// IP address of the client if there is none.
+	Client func(r *http.Request) string
+}
+
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// WithIdempotencyKeys returns a ServeMuxOption supporting the Idempotency-Key
+// header on the POST and PATCH routes of the ServeMux, so that clients can
+// retry these requests without executing them twice.
+//
+// The response to the first request with a key, its status, headers and body,
+// is stored in store, and replayed with an Idempotent-Replayed header to the
+// later requests with the same key, client and route. Requests with a key
+// which is in flight fail with a 409 Conflict, and requests with a key which
+// was used for a request with other path parameters, query or body fail with a
+// 422 Unprocessable Entity. The responses to the requests which were rate
+// limited, failed with a server error or were canceled are not stored, so that
+// they can be retried.
+func WithIdempotencyKeys(store IdempotencyStore, policy IdempotencyPolicy) ServeMuxOption {
+	if policy.TTL <= 0 {
+		policy.TTL = defaultIdempotencyTTL
+	}
+	return func(serveMux *ServeMux) {
+		serveMux.idempotency = &idempotencyConfig{store: store, policy: policy}
+	}
+}
+
+type idempotencyConfig struct {
+	store  IdempotencyStore
+	policy IdempotencyPolicy
+}
+
+// applies reports whether the request r uses an idempotency key.
+func (c *idempotencyConfig) applies(r *http.Request) bool {
+	return (r.Method == http.MethodPost || r.Method == http.MethodPatch) && r.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// client returns the identity of the client of r.
+func (c *idempotencyConfig) client(r *http.Request) string {
+	if c.policy.Client != nil {
+		return c.policy.Client(r)
+	}
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		return authorization
+	}
+	return clientIP(r, nil)
+}
+
+// parseIdempotencyKey returns the idempotency key of a request, which is a
+// structured header string, although unquoted keys are accepted too.
+func parseIdempotencyKey(value string) (string, error) {
+	key := strings.TrimSpace(value)
+	if len(key) >= 2 && strings.HasPrefix(key, `"`) && strings.HasSuffix(key, `"`) {
+		key = key[1 : len(key)-1]
+	}
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return "", status.Errorf(codes.InvalidArgument, "%s must hold between 1 and %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength)
+	}
+	return key, nil
+}
+
+// hashStrings returns the hex-encoded SHA-256 hash of values, which are
+// quoted so that their boundaries are part of the hash.
+func hashStrings(values ...string) string {
+	h := sha256.New()
+	for _, v := range values {
+		h.Write([]byte(strconv.Quote(v)))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// requestFingerprint identifies the request r to the route, with its path
+// parameters, query and body.
+func requestFingerprint(r *http.Request, pathParams map[string]string, body []byte) string {
+	values := make([]string, 0, 2*len(pathParams)+2)
+	names := make([]string, 0, len(pathParams))
+	for name := range pathParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values = append(values, name, pathParams[name])
+	}
+	values = append(values, r.URL.Query().Encode(), string(body))
+	return hashStrings(values...)
+}
+
+// serve serves the request r with the handler h, replaying the response to
+// the first request with its idempotency key.
+func (c *idempotencyConfig) serve(s *ServeMux, h handler, w http.ResponseWriter, r *http.Request, pathParams map[string]string, next func(http.ResponseWriter, *http.Request)) {
+	ctx := r.Context()
+	_, outboundMarshaler := MarshalerForRequest(s, r)
+	idempotencyKey, err := parseIdempotencyKey(r.Header.Get(IdempotencyKeyHeader))
+	if err != nil {
+		HTTPError(ctx, s, outboundMarshaler, w, r, err)
+		return
+	}
+	// The body is read under the limits of the gRPC method of the route,
+	// which WithBodyLimits selected when the route matched.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		// Let the handler report the error like it would have without
+		// idempotency keys.
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), &errorReader{err: err}))
+		next(w, r)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	key := hashStrings(c.client(r), r.Method, h.pat.String(), idempotencyKey)
+	fingerprint := requestFingerprint(r, pathParams, body)
+	record, err := c.store.Begin(ctx, key, fingerprint, c.policy.TTL)
+	if err != nil {
+		HTTPError(ctx, s, outboundMarshaler, w, r, status.Errorf(codes.Unavailable, "idempotency store: %v", err))
+		return
+	}
+	switch {
+	case record == nil:
+	case record.Fingerprint != fingerprint:
+		HTTPError(ctx, s, outboundMarshaler, w, r, &HTTPStatusError{
+			HTTPStatus: http.StatusUnprocessableEntity,
+			Err:        status.Errorf(codes.InvalidArgument, "%s was used for another request", IdempotencyKeyHeader),
+		})
+		return
+	case record.Response == nil:
+		HTTPError(ctx, s, outboundMarshaler, w, r, status.Errorf(codes.Aborted, "a request with the same %s is in flight", IdempotencyKeyHeader))
+		return
+	default:
+		for name, values := range record.Response.Header {
+			w.Header()[name] = append([]string(nil), values...)
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(record.Response.StatusCode)
+		if _, err := w.Write(record.Response.Body); err != nil {
+			grpclog.Errorf("Failed to write response: %v", err)
+		}
+		return
+	}
+
+	before := make(map[string]bool, len(w.Header()))
+	for name := range w.Header() {
+		before[name] = true
+	}
+	rw := &cacheResponseWriter{ResponseWriter: w, writeHeader: func() {}}
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		// The handler panicked, or its response is not stored.
+		if err := c.store.Release(context.WithoutCancel(ctx), key); err != nil {
+			grpclog.Errorf("Failed to release idempotency key: %v", err)
+		}
+	}()
+	next(rw, r)
+	if rw.discard || rw.status == http.StatusTooManyRequests || rw.status >= http.StatusInternalServerError || ctx.Err() != nil {
+		return
+	}
+
+	header := w.Header().Clone()
+	for name := range before {
+		header.Del(name)
+	}
+	for _, name := range uncachedHeaders {
+		header.Del(name)
+	}
+	resp := &CachedResponse{
+		StatusCode: rw.status,
+		Header:     header,
+		Body:       rw.body.Bytes(),
+		StoredAt:   time.Now(),
+	}
+	if err := c.store.Complete(context.WithoutCancel(ctx), key, resp, c.policy.TTL); err != nil {
+		grpclog.Errorf("Failed to store response of idempotent request: %v", err)
+		return
+	}
+	completed = true
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore keeping the records in
+// memory. Expired records are discarded every minute to bound its memory use.
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	records   map[string]*memoryIdempotencyRecord
+	lastSweep time.Time
+}
+
+type memoryIdempotencyRecord struct {
+	record  IdempotencyRecord
+	expires time.Time
+}
+
+const memoryIdempotencyStoreSweepInterval = time.Minute
+
+// NewMemoryIdempotencyStore returns a new MemoryIdempotencyStore.
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		records:   make(map[string]*memoryIdempotencyRecord),
+		lastSweep: time.Now(),
+	}
+}
+
+// Begin implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Begin(_ context.Context, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= memoryIdempotencyStoreSweepInterval {
+		s.sweep(now)
+	}
+	if r, ok := s.records[key]; ok && now.Before(r.expires) {
+		record := r.record
+		return &record, nil
+	}
+	s.records[key] = &memoryIdempotencyRecord{
+		record:  IdempotencyRecord{Fingerprint: fingerprint},
+		expires: now.Add(ttl),
+	}
+	return nil, nil
+}
+
+// Complete implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Complete(_ context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[key]
+	if !ok {
+		return status.Errorf(codes.NotFound, "no request in flight for key %q", key)
+	}
+	r.record.Response = resp
+	r.expires = time.Now().Add(ttl)
+	return nil
+}
+
+// Release implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// Len returns the number of records in the store, including the expired
+// records which were not discarded yet.
+func (s *MemoryIdempotencyStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	for key, r := range s.records {
+		if !now.Before(r.expires) {
+			delete(s.records, key)
+		}
+	}
+	s.lastSweep = now
+}
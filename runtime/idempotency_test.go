@@ -0,0 +1,247 @@
+package runtime_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// handleCounting registers a handler replying with the number of calls of the
+// route and the request body, or failing with the status given by the fail
+// query parameter.
+func handleCounting(t *testing.T, mux *runtime.ServeMux, meth, pattern string, calls *atomic.Int32) {
+	t.Helper()
+	err := mux.HandlePath(meth, pattern, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		n := calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		if code, err := strconv.Atoi(r.URL.Query().Get("fail")); err == nil {
+			w.WriteHeader(code)
+			return
+		}
+		w.Header().Set("X-Call", strconv.Itoa(int(n)))
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, string(body))
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath(%q) failed with %v", pattern, err)
+	}
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithIdempotencyKeys(runtime.NewMemoryIdempotencyStore(), runtime.IdempotencyPolicy{}))
+	var calls atomic.Int32
+	handleCounting(t, mux, http.MethodPost, "/v1/payments/{id}", &calls)
+	handleCounting(t, mux, http.MethodGet, "/v1/payments/{id}", &calls)
+
+	for _, spec := range []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		header       []string
+		wantCode     int
+		wantCalls    int32
+		wantReplayed bool
+	}{
+		{name: "first request", path: "/v1/payments/1", body: "a", header: []string{"Idempotency-Key", `"k1"`}, wantCode: http.StatusCreated, wantCalls: 1},
+		{name: "retry", path: "/v1/payments/1", body: "a", header: []string{"Idempotency-Key", `"k1"`}, wantCode: http.StatusCreated, wantCalls: 1, wantReplayed: true},
+		{name: "unquoted key", path: "/v1/payments/1", body: "a", header: []string{"Idempotency-Key", "k1"}, wantCode: http.StatusCreated, wantCalls: 1, wantReplayed: true},
+		{name: "other body", path: "/v1/payments/1", body: "b", header: []string{"Idempotency-Key", "k1"}, wantCode: http.StatusUnprocessableEntity, wantCalls: 1},
+		{name: "other path parameter", path: "/v1/payments/2", body: "a", header: []string{"Idempotency-Key", "k1"}, wantCode: http.StatusUnprocessableEntity, wantCalls: 1},
+		{name: "other client", path: "/v1/payments/1", body: "a", header: []string{"Idempotency-Key", "k1", "Authorization", "Bearer token"}, wantCode: http.StatusCreated, wantCalls: 2},
+		{name: "other key", path: "/v1/payments/1", body: "a", header: []string{"Idempotency-Key", "k2"}, wantCode: http.StatusCreated, wantCalls: 3},
+		{name: "no key", path: "/v1/payments/1", body: "a", wantCode: http.StatusCreated, wantCalls: 4},
+		{name: "no key again", path: "/v1/payments/1", body: "a", wantCode: http.StatusCreated, wantCalls: 5},
+		{name: "client error", path: "/v1/payments/1?fail=400", header: []string{"Idempotency-Key", "k3"}, wantCode: http.StatusBadRequest, wantCalls: 6},
+		{name: "client error is stored", path: "/v1/payments/1?fail=400", header: []string{"Idempotency-Key", "k3"}, wantCode: http.StatusBadRequest, wantCalls: 6, wantReplayed: true},
+		{name: "server error", path: "/v1/payments/1?fail=503", header: []string{"Idempotency-Key", "k4"}, wantCode: http.StatusServiceUnavailable, wantCalls: 7},
+		{name: "server error is not stored", path: "/v1/payments/1?fail=503", header: []string{"Idempotency-Key", "k4"}, wantCode: http.StatusServiceUnavailable, wantCalls: 8},
+		{name: "GET", method: http.MethodGet, path: "/v1/payments/1", header: []string{"Idempotency-Key", "k1"}, wantCode: http.StatusCreated, wantCalls: 9},
+		{name: "invalid key", path: "/v1/payments/1", header: []string{"Idempotency-Key", strings.Repeat("k", 256)}, wantCode: http.StatusBadRequest, wantCalls: 9},
+	} {
+		method := spec.method
+		if method == "" {
+			method = http.MethodPost
+		}
+		r := httptest.NewRequest(method, spec.path, strings.NewReader(spec.body))
+		for i := 0; i < len(spec.header); i += 2 {
+			r.Header.Set(spec.header[i], spec.header[i+1])
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != spec.wantCode {
+			t.Errorf("%s: status = %d; want %d", spec.name, w.Code, spec.wantCode)
+		}
+		if got := calls.Load(); got != spec.wantCalls {
+			t.Errorf("%s: calls = %d; want %d", spec.name, got, spec.wantCalls)
+		}
+		if got := w.Header().Get("Idempotent-Replayed") == "true"; got != spec.wantReplayed {
+			t.Errorf("%s: Idempotent-Replayed = %q; want it only for replayed responses", spec.name, w.Header().Get("Idempotent-Replayed"))
+		}
+		if spec.wantReplayed && spec.wantCode == http.StatusCreated {
+			if got, want := w.Header().Get("X-Call"), "1"; got != want {
+				t.Errorf("%s: X-Call = %q; want %q", spec.name, got, want)
+			}
+			if got := w.Body.String(); got != spec.body {
+				t.Errorf("%s: body = %q; want %q", spec.name, got, spec.body)
+			}
+		}
+	}
+}
+
+func TestIdempotencyKeysInFlight(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithIdempotencyKeys(runtime.NewMemoryIdempotencyStore(), runtime.IdempotencyPolicy{}))
+	started, release := make(chan struct{}), make(chan struct{})
+	err := mux.HandlePath(http.MethodPost, "/v1/payments", func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath(...) failed with %v", err)
+	}
+	serve := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1/payments", strings.NewReader("a"))
+		r.Header.Set("Idempotency-Key", "k1")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- serve() }()
+	<-started
+	if w := serve(); w.Code != http.StatusConflict {
+		t.Errorf("status of the request in flight = %d; want %d", w.Code, http.StatusConflict)
+	}
+	close(release)
+	if w := <-done; w.Code != http.StatusCreated {
+		t.Errorf("status = %d; want %d", w.Code, http.StatusCreated)
+	}
+	if w := serve(); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("retry = %d %v; want a replayed %d response", w.Code, w.Header(), http.StatusCreated)
+	}
+}
+
+func TestIdempotencyKeysBodyLimits(t *testing.T) {
+	mux := runtime.NewServeMux(
+		runtime.WithIdempotencyKeys(runtime.NewMemoryIdempotencyStore(), runtime.IdempotencyPolicy{}),
+		runtime.WithBodyLimits(
+			runtime.BodyLimits{MaxBytes: 50},
+			runtime.BodyLimitPolicy{Selector: "example.Echo.Large", Limits: runtime.BodyLimits{MaxBytes: 1000}},
+			runtime.BodyLimitPolicy{Selector: "example.Echo.Small", Limits: runtime.BodyLimits{MaxBytes: 10}},
+		),
+	)
+	for _, name := range []string{"Large", "Small"} {
+		rpcMethodName := "/example.Echo/" + name
+		pat := runtime.MustPattern(runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{strings.ToLower(name)}, ""))
+		mux.HandleRPC(http.MethodPost, pat, rpcMethodName, func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+			_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+			ctx, err := runtime.AnnotateContext(req.Context(), mux, req, rpcMethodName)
+			if err != nil {
+				runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+				return
+			}
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+				return
+			}
+			runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, req, wrapperspb.Int64(int64(len(body))))
+		})
+	}
+
+	for _, spec := range []struct {
+		name     string
+		path     string
+		key      string
+		wantCode int
+	}{
+		{name: "limit above the default", path: "/large", wantCode: http.StatusOK},
+		{name: "limit above the default with a key", path: "/large", key: "k1", wantCode: http.StatusOK},
+		{name: "limit below the default", path: "/small", wantCode: http.StatusRequestEntityTooLarge},
+		{name: "limit below the default with a key", path: "/small", key: "k2", wantCode: http.StatusRequestEntityTooLarge},
+	} {
+		// The body has no Content-Length, so that only the bytes read count.
+		body := &countingReader{r: strings.NewReader(strings.Repeat("a", 100))}
+		r := httptest.NewRequest(http.MethodPost, spec.path, body)
+		if spec.key != "" {
+			r.Header.Set("Idempotency-Key", spec.key)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if w.Code != spec.wantCode {
+			t.Errorf("%s: status = %d; want %d: %s", spec.name, w.Code, spec.wantCode, w.Body.String())
+		}
+		if spec.wantCode == http.StatusRequestEntityTooLarge && body.n > 11 {
+			t.Errorf("%s: %d bytes of the body were read; want 11 at most", spec.name, body.n)
+		}
+	}
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	store := runtime.NewMemoryIdempotencyStore()
+	begin := func(key, fingerprint string, ttl time.Duration) *runtime.IdempotencyRecord {
+		record, err := store.Begin(ctx, key, fingerprint, ttl)
+		if err != nil {
+			t.Fatalf("store.Begin(%q) failed with %v", key, err)
+		}
+		return record
+	}
+
+	if record := begin("a", "f1", time.Minute); record != nil {
+		t.Errorf("store.Begin(a) = %v; want nil", record)
+	}
+	if record := begin("a", "f2", time.Minute); record == nil || record.Fingerprint != "f1" || record.Response != nil {
+		t.Errorf("store.Begin(a) = %v; want the in-flight record of f1", record)
+	}
+	if err := store.Complete(ctx, "a", &runtime.CachedResponse{StatusCode: http.StatusCreated}, time.Minute); err != nil {
+		t.Fatalf("store.Complete(a) failed with %v", err)
+	}
+	if record := begin("a", "f1", time.Minute); record == nil || record.Response == nil || record.Response.StatusCode != http.StatusCreated {
+		t.Errorf("store.Begin(a) = %v; want the completed record", record)
+	}
+
+	begin("b", "f1", time.Minute)
+	if err := store.Release(ctx, "b"); err != nil {
+		t.Fatalf("store.Release(b) failed with %v", err)
+	}
+	if record := begin("b", "f1", time.Minute); record != nil {
+		t.Errorf("store.Begin(b) after Release = %v; want nil", record)
+	}
+
+	begin("c", "f1", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if record := begin("c", "f1", time.Minute); record != nil {
+		t.Errorf("store.Begin(c) = %v; want nil once expired", record)
+	}
+	if err := store.Complete(ctx, "d", &runtime.CachedResponse{}, time.Minute); err == nil {
+		t.Errorf("store.Complete(d) succeeded; want an error without Begin")
+	}
+	if got := store.Len(); got != 3 {
+		t.Errorf("store.Len() = %d; want 3", got)
+	}
+}
@@ -61,6 +61,16 @@ func MarshalerForRequest(mux *ServeMux, r *http.Request) (inbound Marshaler, out
 	if outbound == nil {
 		outbound = inbound
 	}
+	inbound = requestStatsFromContext(r.Context()).marshalerChosen(r.Context(), inbound, outbound)
+	if state := bodyLimitStateFromContext(r.Context()); state != nil {
+		inbound = &bodyLimitMarshaler{Marshaler: inbound, state: state}
+	}
+	if state := visibilityStateFromContext(r.Context()); state != nil {
+		inbound = &visibilityMarshaler{Marshaler: inbound, state: state}
+	}
+	if mux.recoveryHandler != nil {
+		inbound = &recoveryMarshaler{Marshaler: inbound, mux: mux, req: r}
+	}
 
 	return inbound, outbound
 }
@@ -0,0 +1,302 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestMetrics describes a request which was handled by a route of a ServeMux.
+type RequestMetrics struct {
+	// HTTPMethod is the HTTP method of the route.
+	HTTPMethod string
+	// HTTPPathPattern is the path template of the route, as passed to
+	// WithHTTPPathPattern by generated handlers or as registered with the ServeMux.
+	HTTPPathPattern string
+	// RPCMethod is the full gRPC method name, in the format "/package.service/method".
+	// It is empty for routes which do not call a gRPC method, like those added by HandlePath.
+	RPCMethod string
+	// HTTPStatus is the status code of the HTTP response.
+	HTTPStatus int
+	// Code is the gRPC code of the response.
+	Code codes.Code
+	// Duration is the time spent handling the request, from routing to the
+	// last byte of the response.
+	Duration time.Duration
+	// UpstreamDuration is the time spent waiting for the gRPC method to reply,
+	// or to start its response stream for server streaming methods.
+	UpstreamDuration time.Duration
+	// MarshalDuration is the time spent marshaling response messages.
+	MarshalDuration time.Duration
+	// StreamMessagesSent is the number of messages forwarded by ForwardResponseStream.
+	StreamMessagesSent int
+	// Retries is the number of times the gRPC method was called again, as
+	// configured by WithRetryPolicy.
+	Retries int
+	// Cache is the status of the request in the response cache configured by
+	// WithResponseCache, or empty if it was not looked up.
+	Cache CacheStatus
+	// Coalesced reports whether the request was served the response of an
+	// identical request, as configured by WithRequestCoalescing.
+	Coalesced bool
+	// ResponseBytes is the size of the response body.
+	ResponseBytes int64
+}
+
+// MetricsCollector receives the metrics of the requests handled by a ServeMux.
+//
+// ObserveRequest is called once for each request which matches a route, after
+// the handler of the route has returned. It may be called concurrently.
+type MetricsCollector interface {
+	ObserveRequest(ctx context.Context, m RequestMetrics)
+}
+
+// WithMetricsCollector returns a ServeMuxOption that reports the rate, errors
+// and duration of the requests handled by each route to collector.
+//
+// The metrics are gathered by the runtime functions called by generated
+// handlers: AnnotateContext, NewServerMetadataContext, HTTPError,
+// ForwardResponseMessage and ForwardResponseStream.
+//
+// See PrometheusCollector for a collector exposing the metrics to Prometheus.
+func WithMetricsCollector(collector MetricsCollector) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.metricsCollector = collector
+	}
+}
+
+type requestStatsKey struct{}
+
+// requestStats accumulates the metrics of a request while it is handled and
+// dispatches its events to the GatewayStatsHandlers of the mux.
+type requestStats struct {
+	handlers []GatewayStatsHandler
+
+	mu            sync.Mutex
+	metrics       RequestMetrics
+	upstreamStart time.Time
+	upstreamBegin time.Time
+	hasCode       bool
+}
+
+func requestStatsFromContext(ctx context.Context) *requestStats {
+	if ctx == nil {
+		return nil
+	}
+	m, _ := ctx.Value(requestStatsKey{}).(*requestStats)
+	return m
+}
+
+// startUpstream records the route of the request and the start of the
+// upstream call once the context of a generated handler is annotated.
+func (m *requestStats) startUpstream(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if method, ok := RPCMethod(ctx); ok {
+		m.metrics.RPCMethod = method
+	}
+	if pattern, ok := HTTPPathPattern(ctx); ok {
+		m.metrics.HTTPPathPattern = pattern
+	}
+	m.upstreamStart = time.Now()
+}
+
+// endUpstream records the end of the upstream call started by startUpstream.
+func (m *requestStats) endUpstream() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.upstreamStart.IsZero() {
+		m.metrics.UpstreamDuration = time.Since(m.upstreamStart)
+		m.upstreamStart = time.Time{}
+	}
+}
+
+func (m *requestStats) setError(err error) {
+	if m == nil {
+		return
+	}
+	var customStatus *HTTPStatusError
+	if errors.As(err, &customStatus) {
+		err = customStatus.Err
+	}
+	m.setCode(status.Code(err))
+}
+
+func (m *requestStats) setCode(code codes.Code) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.hasCode {
+		m.metrics.Code = code
+		m.hasCode = true
+	}
+}
+
+func (m *requestStats) addMarshalDuration(d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics.MarshalDuration += d
+}
+
+// incStreamMessagesSent counts a forwarded stream message and returns the
+// number of messages sent so far.
+func (m *requestStats) incStreamMessagesSent() int {
+	if m == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics.StreamMessagesSent++
+	return m.metrics.StreamMessagesSent
+}
+
+// setRPCMethod records the gRPC method of a request which does not call it,
+// like requests served from the response cache.
+func (m *requestStats) setRPCMethod(rpcMethod string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics.RPCMethod = rpcMethod
+}
+
+// cacheLookup records the status of the request in the response cache.
+func (m *requestStats) cacheLookup(ctx context.Context, status CacheStatus) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.metrics.Cache = status
+	m.mu.Unlock()
+	m.emit(ctx, &StatsCacheLookup{Status: status})
+}
+
+// coalesced records that the request was served the response of an identical
+// request.
+func (m *requestStats) coalesced() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics.Coalesced = true
+}
+
+// retried counts a new attempt of the gRPC call.
+func (m *requestStats) retried() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics.Retries++
+}
+
+// finish completes the metrics once the handler of the route has returned.
+func (m *requestStats) finish(start time.Time, w *metricsResponseWriter) RequestMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics.Duration = time.Since(start)
+	m.metrics.HTTPStatus = w.status
+	if m.metrics.HTTPStatus == 0 {
+		m.metrics.HTTPStatus = http.StatusOK
+	}
+	m.metrics.ResponseBytes = w.bytes
+	if !m.hasCode && m.metrics.HTTPStatus >= http.StatusBadRequest {
+		m.metrics.Code = codes.Unknown
+	}
+	return m.metrics
+}
+
+// observeRequest calls h, reporting the metrics of the request to the
+// collector of the mux and its events to the stats handlers of the mux.
+func (s *ServeMux) observeRequest(h handler, w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	start := time.Now()
+	m := &requestStats{
+		handlers: s.statsHandlers,
+		metrics: RequestMetrics{
+			HTTPMethod:      r.Method,
+			HTTPPathPattern: h.pat.String(),
+		},
+	}
+	mw := &metricsResponseWriter{ResponseWriter: w}
+	ctx := context.WithValue(r.Context(), requestStatsKey{}, m)
+
+	if len(m.handlers) > 0 {
+		matched := &StatsRouteMatched{
+			HTTPMethod:      r.Method,
+			HTTPPathPattern: h.pat.String(),
+			PathParams:      pathParams,
+			BeginTime:       start,
+			Request:         r,
+		}
+		for _, sh := range m.handlers {
+			ctx = sh.TagRequest(ctx, matched)
+		}
+		m.emit(ctx, matched)
+	}
+
+	s.serveHandler(h, mw, r.WithContext(ctx), pathParams)
+
+	metrics := m.finish(start, mw)
+	if s.metricsCollector != nil {
+		s.metricsCollector.ObserveRequest(ctx, metrics)
+	}
+	m.emit(ctx, &StatsRequestFinished{RequestMetrics: metrics, EndTime: start.Add(metrics.Duration)})
+}
+
+// metricsResponseWriter records the status code and the size of a response.
+type metricsResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *metricsResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *metricsResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// FlushError flushes the underlying ResponseWriter, so that streaming works
+// through http.ResponseController.
+func (w *metricsResponseWriter) FlushError() error {
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Flush implements http.Flusher.
+func (w *metricsResponseWriter) Flush() {
+	_ = w.FlushError()
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (w *metricsResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,204 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type recordingMetricsCollector struct {
+	mu      sync.Mutex
+	metrics []runtime.RequestMetrics
+}
+
+func (c *recordingMetricsCollector) ObserveRequest(_ context.Context, m runtime.RequestMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metrics = append(c.metrics, m)
+}
+
+// upstreamFunc is the gRPC call of a route registered with handleGenerated. It
+// is given the context and the request of each attempt, and the value of the
+// StringValue request body, and returns the response and the header metadata
+// of the call.
+type upstreamFunc func(ctx context.Context, req *http.Request, body string) (proto.Message, metadata.MD, error)
+
+// replyWith returns the upstreamFunc replying with f, whatever the request.
+func replyWith(f func() (proto.Message, error)) upstreamFunc {
+	return func(context.Context, *http.Request, string) (proto.Message, metadata.MD, error) {
+		resp, err := f()
+		return resp, nil, err
+	}
+}
+
+// handleGenerated registers a route which behaves like a generated handler,
+// calling the upstream function through RetryUnary and forwarding its
+// response, or forwarding the messages of stream if it is not nil, a nil
+// message aborting the stream.
+func handleGenerated(t *testing.T, mux *runtime.ServeMux, meth, pattern, rpcMethod string, upstream upstreamFunc, stream []proto.Message) {
+	t.Helper()
+	err := mux.HandlePath(meth, pattern, func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		ctx, err := runtime.AnnotateContext(req.Context(), mux, req, rpcMethod, runtime.WithHTTPPathPattern(pattern))
+		if err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, err)
+			return
+		}
+		call := func(ctx context.Context, req *http.Request) (proto.Message, runtime.ServerMetadata, error) {
+			body := &wrapperspb.StringValue{}
+			if req.ContentLength > 0 {
+				if err := inboundMarshaler.NewDecoder(req.Body).Decode(body); err != nil {
+					return nil, runtime.ServerMetadata{}, status.Errorf(codes.InvalidArgument, "%v", err)
+				}
+			}
+			runtime.ReportUpstreamBegin(ctx)
+			resp, md, err := upstream(ctx, req, body.GetValue())
+			return resp, runtime.ServerMetadata{HeaderMD: metadata.Join(md, metadata.Pairs("x-upstream", "1"))}, err
+		}
+		var (
+			resp proto.Message
+			md   runtime.ServerMetadata
+		)
+		if stream == nil {
+			resp, md, err = runtime.RetryUnary(ctx, req, call)
+		} else {
+			resp, md, err = call(ctx, req)
+		}
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		runtime.ReportUpstreamEnd(ctx, err)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		if stream == nil {
+			runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, req, resp)
+			return
+		}
+		msgs := stream
+		runtime.ForwardResponseStream(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) {
+			if len(msgs) == 0 {
+				return nil, io.EOF
+			}
+			msg := msgs[0]
+			msgs = msgs[1:]
+			if msg == nil {
+				return nil, status.Error(codes.Aborted, "stream aborted")
+			}
+			return msg, nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath(%q, %q) failed with %v", meth, pattern, err)
+	}
+}
+
+func TestWithMetricsCollector(t *testing.T) {
+	collector := &recordingMetricsCollector{}
+	mux := runtime.NewServeMux(runtime.WithMetricsCollector(collector))
+	handleGenerated(t, mux, http.MethodGet, "/v1/echo/{id}", "/example.Echo/Echo", replyWith(func() (proto.Message, error) {
+		return wrapperspb.String("hello"), nil
+	}), nil)
+	handleGenerated(t, mux, http.MethodDelete, "/v1/echo/{id}", "/example.Echo/Delete", replyWith(func() (proto.Message, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}), nil)
+	handleGenerated(t, mux, http.MethodGet, "/v1/stream", "/example.Echo/Stream", replyWith(func() (proto.Message, error) {
+		return nil, nil
+	}), []proto.Message{wrapperspb.String("a"), wrapperspb.String("b"), nil})
+	if err := mux.HandlePath(http.MethodGet, "/plain", func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.WriteHeader(http.StatusTeapot)
+	}); err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v", err)
+	}
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/v1/echo/1", nil),
+		httptest.NewRequest(http.MethodDelete, "/v1/echo/1", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/stream", nil),
+		httptest.NewRequest(http.MethodGet, "/plain", nil),
+		httptest.NewRequest(http.MethodGet, "/unknown", nil),
+	} {
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if len(collector.metrics) != 4 {
+		t.Fatalf("got %d observations; want 4: %+v", len(collector.metrics), collector.metrics)
+	}
+	for i, want := range []runtime.RequestMetrics{
+		{HTTPMethod: "GET", HTTPPathPattern: "/v1/echo/{id}", RPCMethod: "/example.Echo/Echo", HTTPStatus: http.StatusOK, Code: codes.OK},
+		{HTTPMethod: "DELETE", HTTPPathPattern: "/v1/echo/{id}", RPCMethod: "/example.Echo/Delete", HTTPStatus: http.StatusNotFound, Code: codes.NotFound},
+		{HTTPMethod: "GET", HTTPPathPattern: "/v1/stream", RPCMethod: "/example.Echo/Stream", HTTPStatus: http.StatusOK, Code: codes.Aborted, StreamMessagesSent: 2},
+		{HTTPMethod: "GET", HTTPPathPattern: "/plain", HTTPStatus: http.StatusTeapot, Code: codes.Unknown},
+	} {
+		got := collector.metrics[i]
+		if got.HTTPMethod != want.HTTPMethod || got.HTTPPathPattern != want.HTTPPathPattern || got.RPCMethod != want.RPCMethod ||
+			got.HTTPStatus != want.HTTPStatus || got.Code != want.Code || got.StreamMessagesSent != want.StreamMessagesSent {
+			t.Errorf("observation %d = %+v; want %+v", i, got, want)
+		}
+		if got.Duration <= 0 {
+			t.Errorf("observation %d has no duration", i)
+		}
+		if want.RPCMethod != "" && got.UpstreamDuration <= 0 {
+			t.Errorf("observation %d has no upstream duration", i)
+		}
+		if want.HTTPStatus != http.StatusTeapot && got.ResponseBytes == 0 {
+			t.Errorf("observation %d has no response bytes", i)
+		}
+		if want.HTTPStatus == http.StatusOK && got.MarshalDuration <= 0 {
+			t.Errorf("observation %d has no marshal duration", i)
+		}
+	}
+}
+
+func TestPrometheusCollector(t *testing.T) {
+	collector := runtime.NewPrometheusCollector(runtime.WithPrometheusBuckets([]float64{1, 0.1}))
+	mux := runtime.NewServeMux(runtime.WithMetricsCollector(collector))
+	handleGenerated(t, mux, http.MethodGet, "/v1/echo/{id}", "/example.Echo/Echo", replyWith(func() (proto.Message, error) {
+		return wrapperspb.String("hello"), nil
+	}), nil)
+	handleGenerated(t, mux, http.MethodPost, "/v1/echo", "/example.Echo/Create", replyWith(func() (proto.Message, error) {
+		return nil, errors.New("boom")
+	}), nil)
+	if err := mux.HandlePath(http.MethodGet, "/metrics", collector.Handler()); err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v", err)
+	}
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/echo/1", nil))
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/echo/2", nil))
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/echo", nil))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if got, want := w.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8"; got != want {
+		t.Errorf("Content-Type = %q; want %q", got, want)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"# TYPE grpc_gateway_requests_total counter\n",
+		`grpc_gateway_requests_total{method="GET",pattern="/v1/echo/{id}",rpc_method="/example.Echo/Echo",status="200",code="OK"} 2` + "\n",
+		`grpc_gateway_requests_total{method="POST",pattern="/v1/echo",rpc_method="/example.Echo/Create",status="500",code="Unknown"} 1` + "\n",
+		"# TYPE grpc_gateway_request_duration_seconds histogram\n",
+		`grpc_gateway_request_duration_seconds_bucket{method="GET",pattern="/v1/echo/{id}",rpc_method="/example.Echo/Echo",le="0.1"} 2` + "\n",
+		`grpc_gateway_request_duration_seconds_bucket{method="GET",pattern="/v1/echo/{id}",rpc_method="/example.Echo/Echo",le="1"} 2` + "\n",
+		`grpc_gateway_request_duration_seconds_bucket{method="GET",pattern="/v1/echo/{id}",rpc_method="/example.Echo/Echo",le="+Inf"} 2` + "\n",
+		`grpc_gateway_request_duration_seconds_count{method="GET",pattern="/v1/echo/{id}",rpc_method="/example.Echo/Echo"} 2` + "\n",
+		`grpc_gateway_upstream_duration_seconds_count{method="POST",pattern="/v1/echo",rpc_method="/example.Echo/Create"} 1` + "\n",
+		`grpc_gateway_marshal_duration_seconds_count{method="GET",pattern="/v1/echo/{id}",rpc_method="/example.Echo/Echo"} 2` + "\n",
+		`grpc_gateway_stream_messages_sent_total{method="GET",pattern="/v1/echo/{id}",rpc_method="/example.Echo/Echo"} 0` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+}
@@ -72,6 +72,22 @@ type ServeMux struct {
 	disablePathLengthFallback bool
 	unescapingMode            UnescapingMode
 	writeContentLength        bool
+	queryParameterParser      QueryParameterParser
+	metricsCollector          MetricsCollector
+	statsHandlers             []GatewayStatsHandler
+	requestID                 *requestIDConfig
+	rateLimit                 *rateLimitConfig
+	drain                     *drainer
+	recoveryHandler           RecoveryHandlerFunc
+	bodyLimits                *bodyLimitConfig
+	visibility                *visibilityConfig
+	fieldBehavior             *fieldBehaviorConfig
+	retry                     *retryConfig
+	responseCache             *responseCacheConfig
+	coalescing                *coalescingConfig
+	idempotency               *idempotencyConfig
+	grpcWeb                   *grpcWebConfig
+	serviceConns              map[string]serviceConn
 }
 
 // ServeMuxOption is an option that can be given to a ServeMux on construction.
@@ -127,8 +143,13 @@ func WithMiddlewares(middlewares ...Middleware) ServeMuxOption {
 // SetQueryParameterParser sets the query parameter parser, used to populate message from query parameters.
 // Configuring this will mean the generated OpenAPI output is no longer correct, and it should be
 // done with careful consideration.
+//
+// The parser is scoped to the ServeMux and is made available to handlers through the request context,
+// see PopulateQueryParametersContext. It is also installed as the parser used by PopulateQueryParameters
+// so that code generated by older versions of protoc-gen-grpc-gateway keeps working.
 func SetQueryParameterParser(queryParameterParser QueryParameterParser) ServeMuxOption {
 	return func(serveMux *ServeMux) {
+		serveMux.queryParameterParser = queryParameterParser
 		currentQueryParser = queryParameterParser
 	}
 }
@@ -327,6 +348,8 @@ func NewServeMux(opts ...ServeMuxOption) *ServeMux {
 		streamErrorHandler:      DefaultStreamErrorHandler,
 		routingErrorHandler:     DefaultRoutingErrorHandler,
 		unescapingMode:          UnescapingModeDefault,
+		queryParameterParser:    &DefaultQueryParser{},
+		drain:                   newDrainer(),
 	}
 
 	for _, opt := range opts {
@@ -348,10 +371,17 @@ func NewServeMux(opts ...ServeMuxOption) *ServeMux {
 
 // Handle associates "h" to the pair of HTTP method and path pattern.
 func (s *ServeMux) Handle(meth string, pat Pattern, h HandlerFunc) {
+	s.HandleRPC(meth, pat, "", h)
+}
+
+// HandleRPC is like Handle, but also records the full name of the gRPC method
+// called by h, like "/package.Service/Method", which the admin handler lists
+// with the route. The generated handlers are registered with HandleRPC.
+func (s *ServeMux) HandleRPC(meth string, pat Pattern, rpcMethod string, h HandlerFunc) {
 	if len(s.middlewares) > 0 {
 		h = chainMiddlewares(s.middlewares)(h)
 	}
-	s.handlers[meth] = append([]handler{{pat: pat, h: h}}, s.handlers[meth]...)
+	s.handlers[meth] = append([]handler{{pat: pat, h: h, stats: &routeStats{rpcMethod: rpcMethod}}}, s.handlers[meth]...)
 }
 
 // HandlePath allows users to configure custom path handlers.
@@ -373,6 +403,18 @@ func (s *ServeMux) HandlePath(meth string, pathPattern string, h HandlerFunc) er
 // ServeHTTP dispatches the request to the first handler whose pattern matches to r.Method and r.URL.Path.
 func (s *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	if s.requestID != nil {
+		ctx = s.requestID.annotate(ctx, w, r)
+		r = r.WithContext(ctx)
+	}
+	if s.drain.draining.Load() {
+		s.rejectDraining(ctx, w, r)
+		return
+	}
+	if s.grpcWeb != nil && s.grpcWeb.handles(s, r) {
+		s.grpcWeb.serve(s, w, r)
+		return
+	}
 
 	path := r.URL.Path
 	if !strings.HasPrefix(path, "/") {
@@ -527,12 +569,47 @@ func (s *ServeMux) isPathLengthFallback(r *http.Request) bool {
 }
 
 type handler struct {
-	pat Pattern
-	h   HandlerFunc
+	pat   Pattern
+	h     HandlerFunc
+	stats *routeStats
 }
 
 func (s *ServeMux) handleHandler(h handler, w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
-	h.h(w, r.WithContext(withHTTPPattern(r.Context(), h.pat)), pathParams)
+	ctx, inFlight, ok := s.drain.track(r.Context())
+	if !ok {
+		s.rejectDraining(ctx, w, r)
+		return
+	}
+	defer s.drain.done(inFlight)
+	h.stats.inFlight.Add(1)
+	defer h.stats.inFlight.Add(-1)
+
+	ctx = withHTTPPattern(ctx, h.pat)
+	ctx = withQueryParameterParser(ctx, s.queryParameterParser)
+	ctx = withRouteStats(ctx, h.stats)
+	if s.rateLimit != nil || s.retry != nil {
+		ctx = withResponseHeader(ctx, w.Header())
+	}
+	if s.visibility != nil {
+		ctx = withVisibilityState(ctx, s.visibility.newState(ctx, r))
+	}
+	if s.fieldBehavior != nil {
+		ctx = withFieldBehaviorConfig(ctx, s.fieldBehavior)
+	}
+	if s.retry != nil {
+		ctx = withRetryConfig(ctx, s.retry)
+	}
+	r = r.WithContext(ctx)
+	if s.bodyLimits != nil {
+		var done func()
+		r, done = s.bodyLimits.limitBody(w, r, h.stats.method())
+		defer done()
+	}
+	if s.metricsCollector != nil || len(s.statsHandlers) > 0 {
+		s.observeRequest(h, w, r, pathParams)
+		return
+	}
+	s.serveHandler(h, w, r, pathParams)
 }
 
 func chainMiddlewares(mws []Middleware) Middleware {
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
@@ -17,6 +18,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestMuxServeHTTP(t *testing.T) {
@@ -930,3 +933,54 @@ func TestServeMux_InjectPattern(t *testing.T) {
 		t.Errorf("request not processed")
 	}
 }
+
+type recordingQueryParser struct {
+	name  string
+	calls []string
+}
+
+func (p *recordingQueryParser) Parse(_ proto.Message, values url.Values, _ *utilities.DoubleArray) error {
+	p.calls = append(p.calls, values.Encode())
+	return nil
+}
+
+func TestServeMux_QueryParameterParserPerMux(t *testing.T) {
+	// SetQueryParameterParser still installs the parser for the legacy PopulateQueryParameters.
+	t.Cleanup(func() { runtime.NewServeMux(runtime.SetQueryParameterParser(&runtime.DefaultQueryParser{})) })
+
+	public := &recordingQueryParser{name: "public"}
+	internal := &recordingQueryParser{name: "internal"}
+
+	var muxes []*runtime.ServeMux
+	for _, parser := range []*recordingQueryParser{public, internal} {
+		mux := runtime.NewServeMux(runtime.SetQueryParameterParser(parser))
+		err := mux.HandlePath("GET", "/test", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			if err := runtime.PopulateQueryParametersContext(r.Context(), &emptypb.Empty{}, r.URL.Query(), utilities.NewDoubleArray(nil)); err != nil {
+				t.Errorf("runtime.PopulateQueryParametersContext() failed with %v; want success", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("The route test with method GET and path /test invalid, got %v", err)
+		}
+		muxes = append(muxes, mux)
+	}
+
+	muxes[0].ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test?a=public", nil))
+	muxes[1].ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test?a=internal", nil))
+
+	for _, parser := range []*recordingQueryParser{public, internal} {
+		if want := []string{"a=" + parser.name}; !reflect.DeepEqual(parser.calls, want) {
+			t.Errorf("%s parser calls = %v; want %v", parser.name, parser.calls, want)
+		}
+	}
+}
+
+func TestPopulateQueryParametersContext_withoutServeMux(t *testing.T) {
+	msg := &emptypb.Empty{}
+	if err := runtime.PopulateQueryParametersContext(context.Background(), msg, url.Values{"foo": {"bar"}}, utilities.NewDoubleArray(nil)); err != nil {
+		t.Errorf("runtime.PopulateQueryParametersContext() failed with %v; want success", err)
+	}
+	if _, ok := runtime.QueryParameterParserFromContext(context.Background()); ok {
+		t.Errorf("runtime.QueryParameterParserFromContext() returned a parser for a bare context")
+	}
+}
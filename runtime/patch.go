@@ -0,0 +1,183 @@
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	field_mask "google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	// MIMEMergePatch is the media type of a JSON Merge Patch (RFC 7386) request body.
+	// Merge patches are decoded like any other JSON body by FieldMaskFromRequestBody.
+	MIMEMergePatch = "application/merge-patch+json"
+
+	// MIMEJSONPatch is the media type of a JSON Patch (RFC 6902) request body.
+	MIMEJSONPatch = "application/json-patch+json"
+)
+
+// IsJSONPatch reports whether the request body is a JSON Patch document,
+// as indicated by its Content-Type header.
+func IsJSONPatch(req *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && mediaType == MIMEJSONPatch
+}
+
+// jsonPatchOperation is a single operation of a JSON Patch document.
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// DecodeJSONPatch applies the JSON Patch (RFC 6902) document read from r to
+// the update message v and returns the FieldMask of the fields it touches.
+//
+// v is either a proto.Message or a pointer to a (possibly nil) message
+// pointer, which is allocated as needed. The supported operations are "add",
+// "replace" and "remove". Paths are JSON Pointers to message fields, using
+// either their proto or their JSON names. A removed field is left unset in
+// the update message, but its path is included in the mask so that it is
+// cleared. Repeated and map fields can only be patched as a whole.
+func DecodeJSONPatch(r io.Reader, v interface{}) (*field_mask.FieldMask, error) {
+	msg, err := patchTarget(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []jsonPatchOperation
+	if err := json.NewDecoder(r).Decode(&ops); err != nil {
+		if errors.Is(err, io.EOF) {
+			return &field_mask.FieldMask{}, nil
+		}
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	fm := &field_mask.FieldMask{}
+	for i, op := range ops {
+		path, err := applyJSONPatchOperation(msg.ProtoReflect(), op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		if !seen[path] {
+			seen[path] = true
+			fm.Paths = append(fm.Paths, path)
+		}
+	}
+
+	sort.Strings(fm.Paths)
+
+	return fm, nil
+}
+
+// patchTarget returns the message designated by v, allocating it if v
+// points to a nil message pointer.
+func patchTarget(v interface{}) (proto.Message, error) {
+	if msg, ok := v.(proto.Message); ok {
+		return msg, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Ptr || !rv.Elem().Type().Implements(typeProtoMessage) {
+		return nil, fmt.Errorf("%T is not a pointer to a proto message", v)
+	}
+	if rv.Elem().IsNil() {
+		rv.Elem().Set(reflect.New(rv.Elem().Type().Elem()))
+	}
+	return rv.Elem().Interface().(proto.Message), nil
+}
+
+// applyJSONPatchOperation applies op to msg and returns the field mask path
+// of the patched field.
+func applyJSONPatchOperation(msg protoreflect.Message, op jsonPatchOperation) (string, error) {
+	switch op.Op {
+	case "add", "replace", "remove":
+	default:
+		return "", fmt.Errorf("path %q: unsupported operation %q", op.Path, op.Op)
+	}
+
+	segments, err := parseJSONPointer(op.Path)
+	if err != nil {
+		return "", err
+	}
+
+	parent := msg
+	var names []string
+	var fd protoreflect.FieldDescriptor
+	for i, segment := range segments {
+		if i > 0 {
+			switch {
+			case fd.IsList(), fd.IsMap():
+				return "", fmt.Errorf("path %q: elements of field %q cannot be patched individually", op.Path, strings.Join(names, "."))
+			case fd.Message() == nil, isDynamicProtoMessage(fd.Message()), isProtobufAnyMessage(fd.Message()):
+				return "", fmt.Errorf("path %q: field %q has no subfields", op.Path, strings.Join(names, "."))
+			}
+			parent = parent.Mutable(fd).Message()
+		}
+		fd = getFieldByName(parent.Descriptor().Fields(), segment)
+		if fd == nil {
+			return "", fmt.Errorf("path %q: could not find field %q in %q", op.Path, segment, parent.Descriptor().FullName())
+		}
+		names = append(names, string(fd.Name()))
+	}
+
+	if op.Op == "remove" {
+		parent.Clear(fd)
+	} else {
+		if len(op.Value) == 0 {
+			return "", fmt.Errorf("path %q: missing value for %q operation", op.Path, op.Op)
+		}
+		if err := setFieldFromJSON(parent, fd, op.Value); err != nil {
+			return "", fmt.Errorf("path %q: %w", op.Path, err)
+		}
+	}
+
+	return strings.Join(names, "."), nil
+}
+
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// parseJSONPointer splits a JSON Pointer (RFC 6901) into its unescaped
+// reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" || pointer == "/" {
+		return nil, errors.New("path must reference a field")
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q is not a JSON pointer", pointer)
+	}
+	segments := strings.Split(pointer[1:], "/")
+	for i, segment := range segments {
+		segments[i] = jsonPointerUnescaper.Replace(segment)
+	}
+	return segments, nil
+}
+
+// setFieldFromJSON sets the field fd of msg to the JSON value raw, using the
+// protojson mapping of the field.
+func setFieldFromJSON(msg protoreflect.Message, fd protoreflect.FieldDescriptor, raw json.RawMessage) error {
+	b, err := json.Marshal(map[string]json.RawMessage{fd.JSONName(): raw})
+	if err != nil {
+		return err
+	}
+	tmp := msg.New()
+	if err := protojson.Unmarshal(b, tmp.Interface()); err != nil {
+		return err
+	}
+	if !tmp.Has(fd) {
+		msg.Clear(fd)
+		return nil
+	}
+	msg.Set(fd, tmp.Get(fd))
+	return nil
+}
@@ -0,0 +1,187 @@
+package runtime_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	field_mask "google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestIsJSONPatch(t *testing.T) {
+	for _, tc := range []struct {
+		contentType string
+		want        bool
+	}{
+		{contentType: "application/json-patch+json", want: true},
+		{contentType: "application/json-patch+json; charset=utf-8", want: true},
+		{contentType: "application/merge-patch+json", want: false},
+		{contentType: "application/json", want: false},
+		{contentType: "", want: false},
+	} {
+		req, _ := http.NewRequest(http.MethodPatch, "/", nil)
+		req.Header.Set("Content-Type", tc.contentType)
+		if got := runtime.IsJSONPatch(req); got != tc.want {
+			t.Errorf("runtime.IsJSONPatch(%q) = %v; want %v", tc.contentType, got, tc.want)
+		}
+	}
+}
+
+func TestDecodeJSONPatch(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		msg      *examplepb.ABitOfEverything
+		want     *examplepb.ABitOfEverything
+		wantMask []string
+	}{
+		{
+			name:     "empty body",
+			input:    "",
+			want:     &examplepb.ABitOfEverything{},
+			wantMask: nil,
+		},
+		{
+			name:  "replace scalars",
+			input: `[{"op": "replace", "path": "/uuid", "value": "1234"}, {"op": "add", "path": "/int64Value", "value": "42"}]`,
+			want: &examplepb.ABitOfEverything{
+				Uuid:       "1234",
+				Int64Value: 42,
+			},
+			wantMask: []string{"int64_value", "uuid"},
+		},
+		{
+			name:  "nested field",
+			input: `[{"op": "replace", "path": "/single_nested/name", "value": "foo"}]`,
+			want: &examplepb.ABitOfEverything{
+				SingleNested: &examplepb.ABitOfEverything_Nested{Name: "foo"},
+			},
+			wantMask: []string{"single_nested.name"},
+		},
+		{
+			name:  "whole repeated and well-known fields",
+			input: `[{"op": "add", "path": "/repeated_string_value", "value": ["a", "b"]}, {"op": "replace", "path": "/timestamp_value", "value": "1970-01-01T00:00:01Z"}]`,
+			want: &examplepb.ABitOfEverything{
+				RepeatedStringValue: []string{"a", "b"},
+				TimestampValue:      &timestamppb.Timestamp{Seconds: 1},
+			},
+			wantMask: []string{"repeated_string_value", "timestamp_value"},
+		},
+		{
+			name:     "remove",
+			input:    `[{"op": "remove", "path": "/uuid"}]`,
+			msg:      &examplepb.ABitOfEverything{Uuid: "1234"},
+			want:     &examplepb.ABitOfEverything{},
+			wantMask: []string{"uuid"},
+		},
+		{
+			name:     "null value clears the field",
+			input:    `[{"op": "replace", "path": "/single_nested", "value": null}]`,
+			msg:      &examplepb.ABitOfEverything{SingleNested: &examplepb.ABitOfEverything_Nested{Name: "foo"}},
+			want:     &examplepb.ABitOfEverything{},
+			wantMask: []string{"single_nested"},
+		},
+		{
+			name:     "duplicate paths",
+			input:    `[{"op": "replace", "path": "/uuid", "value": "a"}, {"op": "replace", "path": "/uuid", "value": "b"}]`,
+			want:     &examplepb.ABitOfEverything{Uuid: "b"},
+			wantMask: []string{"uuid"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := tc.msg
+			if msg == nil {
+				msg = &examplepb.ABitOfEverything{}
+			}
+			mask, err := runtime.DecodeJSONPatch(strings.NewReader(tc.input), msg)
+			if err != nil {
+				t.Fatalf("runtime.DecodeJSONPatch() failed with %v", err)
+			}
+			if diff := cmp.Diff(tc.want, msg, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected message (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(&field_mask.FieldMask{Paths: tc.wantMask}, mask, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected field mask (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONPatchAllocatesTarget(t *testing.T) {
+	var body *examplepb.ABitOfEverything_Nested
+	mask, err := runtime.DecodeJSONPatch(strings.NewReader(`[{"op": "add", "path": "/name", "value": "foo"}]`), &body)
+	if err != nil {
+		t.Fatalf("runtime.DecodeJSONPatch() failed with %v", err)
+	}
+	if want := (&examplepb.ABitOfEverything_Nested{Name: "foo"}); !proto.Equal(body, want) {
+		t.Errorf("body = %v; want %v", body, want)
+	}
+	if got, want := mask.GetPaths(), []string{"name"}; !cmp.Equal(got, want) {
+		t.Errorf("mask.GetPaths() = %v; want %v", got, want)
+	}
+}
+
+func TestDecodeJSONPatchErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "not an array",
+			input:   `{"op": "add"}`,
+			wantErr: "json: cannot unmarshal object",
+		},
+		{
+			name:    "unsupported operation",
+			input:   `[{"op": "replace", "path": "/uuid", "value": "a"}, {"op": "move", "from": "/uuid", "path": "/string_value"}]`,
+			wantErr: `operation 1: path "/string_value": unsupported operation "move"`,
+		},
+		{
+			name:    "unknown field",
+			input:   `[{"op": "replace", "path": "/single_nested/unknown", "value": "a"}]`,
+			wantErr: `operation 0: path "/single_nested/unknown": could not find field "unknown" in "grpc.gateway.runtime.internal.examplepb.ABitOfEverything.Nested"`,
+		},
+		{
+			name:    "missing value",
+			input:   `[{"op": "add", "path": "/uuid"}]`,
+			wantErr: `operation 0: path "/uuid": missing value for "add" operation`,
+		},
+		{
+			name:    "list element",
+			input:   `[{"op": "replace", "path": "/nested/0/name", "value": "a"}]`,
+			wantErr: `operation 0: path "/nested/0/name": elements of field "nested" cannot be patched individually`,
+		},
+		{
+			name:    "scalar subfield",
+			input:   `[{"op": "replace", "path": "/uuid/a", "value": "a"}]`,
+			wantErr: `operation 0: path "/uuid/a": field "uuid" has no subfields`,
+		},
+		{
+			name:    "root path",
+			input:   `[{"op": "replace", "path": "", "value": {}}]`,
+			wantErr: `operation 0: path must reference a field`,
+		},
+		{
+			name:    "invalid value",
+			input:   `[{"op": "replace", "path": "/int32_value", "value": "abc"}]`,
+			wantErr: `operation 0: path "/int32_value": `,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := runtime.DecodeJSONPatch(strings.NewReader(tc.input), &examplepb.ABitOfEverything{})
+			if err == nil {
+				t.Fatalf("runtime.DecodeJSONPatch() succeeded; want error containing %q", tc.wantErr)
+			}
+			if !strings.HasPrefix(err.Error(), tc.wantErr) {
+				t.Errorf("runtime.DecodeJSONPatch() failed with %q; want prefix %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+const defaultPrometheusNamespace = "grpc_gateway"
+
+// defaultPrometheusBuckets are the default buckets of the duration histograms, in seconds.
+var defaultPrometheusBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// PrometheusCollectorOption is an option that can be given to NewPrometheusCollector.
+type PrometheusCollectorOption func(*PrometheusCollector)
+
+// WithPrometheusNamespace sets the prefix of the metric names. The default is "grpc_gateway".
+func WithPrometheusNamespace(namespace string) PrometheusCollectorOption {
+	return func(c *PrometheusCollector) {
+		c.namespace = namespace
+	}
+}
+
+// WithPrometheusBuckets sets the upper bounds, in seconds, of the buckets of
+// the duration histograms.
+func WithPrometheusBuckets(buckets []float64) PrometheusCollectorOption {
+	return func(c *PrometheusCollector) {
+		c.buckets = append([]float64(nil), buckets...)
+		sort.Float64s(c.buckets)
+	}
+}
+
+// PrometheusCollector is a MetricsCollector which aggregates the metrics of
+// each route in memory and serves them in the Prometheus text exposition
+// format. It does not depend on the Prometheus client libraries.
+//
+// The following metrics are exposed, prefixed with the namespace:
+//
+//	requests_total                 counter by method, pattern, rpc_method, status and code
+//	request_duration_seconds       histogram by method, pattern and rpc_method
+//	upstream_duration_seconds      histogram by method, pattern and rpc_method
+//	marshal_duration_seconds       histogram by method, pattern and rpc_method
+//	stream_messages_sent_total     counter by method, pattern and rpc_method
+//	response_bytes_total           counter by method, pattern and rpc_method
+//	upstream_retries_total         counter by method, pattern and rpc_method
+//	cache_hits_total               counter by method, pattern and rpc_method
+//	cache_misses_total             counter by method, pattern and rpc_method
+//	coalesced_requests_total       counter by method, pattern and rpc_method
+//
+// Mount it on the ServeMux with HandlePath:
+//
+//	collector := runtime.NewPrometheusCollector()
+//	mux := runtime.NewServeMux(runtime.WithMetricsCollector(collector))
+//	_ = mux.HandlePath(http.MethodGet, "/metrics", collector.Handler())
+type PrometheusCollector struct {
+	namespace string
+	buckets   []float64
+
+	mu       sync.Mutex
+	requests map[prometheusRequestKey]uint64
+	routes   map[prometheusRouteKey]*prometheusRouteMetrics
+}
+
+type prometheusRouteKey struct {
+	method, pattern, rpcMethod string
+}
+
+type prometheusRequestKey struct {
+	prometheusRouteKey
+	status int
+	code   string
+}
+
+type prometheusRouteMetrics struct {
+	duration, upstream, marshal *prometheusHistogram
+	streamMessagesSent          uint64
+	responseBytes               uint64
+	retries                     uint64
+	cacheHits, cacheMisses      uint64
+	coalesced                   uint64
+}
+
+type prometheusHistogram struct {
+	// counts holds the number of observations in each bucket, the last one
+	// being the +Inf bucket.
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func (h *prometheusHistogram) observe(buckets []float64, d time.Duration) {
+	v := d.Seconds()
+	i := sort.SearchFloat64s(buckets, v)
+	h.counts[i]++
+	h.sum += v
+	h.count++
+}
+
+// NewPrometheusCollector returns a new PrometheusCollector.
+func NewPrometheusCollector(opts ...PrometheusCollectorOption) *PrometheusCollector {
+	c := &PrometheusCollector{
+		namespace: defaultPrometheusNamespace,
+		buckets:   defaultPrometheusBuckets,
+		requests:  make(map[prometheusRequestKey]uint64),
+		routes:    make(map[prometheusRouteKey]*prometheusRouteMetrics),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *PrometheusCollector) newHistogram() *prometheusHistogram {
+	return &prometheusHistogram{counts: make([]uint64, len(c.buckets)+1)}
+}
+
+// ObserveRequest implements MetricsCollector.
+func (c *PrometheusCollector) ObserveRequest(_ context.Context, m RequestMetrics) {
+	route := prometheusRouteKey{method: m.HTTPMethod, pattern: m.HTTPPathPattern, rpcMethod: m.RPCMethod}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests[prometheusRequestKey{prometheusRouteKey: route, status: m.HTTPStatus, code: m.Code.String()}]++
+
+	rm, ok := c.routes[route]
+	if !ok {
+		rm = &prometheusRouteMetrics{
+			duration: c.newHistogram(),
+			upstream: c.newHistogram(),
+			marshal:  c.newHistogram(),
+		}
+		c.routes[route] = rm
+	}
+	rm.duration.observe(c.buckets, m.Duration)
+	if m.UpstreamDuration > 0 {
+		rm.upstream.observe(c.buckets, m.UpstreamDuration)
+	}
+	if m.MarshalDuration > 0 {
+		rm.marshal.observe(c.buckets, m.MarshalDuration)
+	}
+	rm.streamMessagesSent += uint64(m.StreamMessagesSent)
+	rm.responseBytes += uint64(m.ResponseBytes)
+	rm.retries += uint64(m.Retries)
+	switch m.Cache {
+	case CacheHit:
+		rm.cacheHits++
+	case CacheMiss:
+		rm.cacheMisses++
+	}
+	if m.Coalesced {
+		rm.coalesced++
+	}
+}
+
+// Handler returns a HandlerFunc serving the metrics, suitable for HandlePath.
+func (c *PrometheusCollector) Handler() HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		c.ServeHTTP(w, r)
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (c *PrometheusCollector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	c.writeTo(bw)
+	if err := bw.Flush(); err != nil {
+		grpclog.Errorf("Failed to write metrics: %v", err)
+	}
+}
+
+func (c *PrometheusCollector) writeTo(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	requests := make([]prometheusRequestKey, 0, len(c.requests))
+	for k := range c.requests {
+		requests = append(requests, k)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		a, b := requests[i], requests[j]
+		if a.prometheusRouteKey != b.prometheusRouteKey {
+			return a.prometheusRouteKey.less(b.prometheusRouteKey)
+		}
+		if a.status != b.status {
+			return a.status < b.status
+		}
+		return a.code < b.code
+	})
+	routes := make([]prometheusRouteKey, 0, len(c.routes))
+	for k := range c.routes {
+		routes = append(routes, k)
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].less(routes[j]) })
+
+	name := c.namespace + "_requests_total"
+	writePrometheusHeader(w, name, "counter", "Total number of requests handled by each route.")
+	for _, k := range requests {
+		fmt.Fprintf(w, "%s{%s,status=\"%d\",code=%s} %d\n", name, k.labels(), k.status, quotePrometheusLabel(k.code), c.requests[k])
+	}
+
+	for _, h := range []struct {
+		name, help string
+		get        func(*prometheusRouteMetrics) *prometheusHistogram
+	}{
+		{"request_duration_seconds", "Time spent handling requests, from routing to the end of the response.", func(m *prometheusRouteMetrics) *prometheusHistogram { return m.duration }},
+		{"upstream_duration_seconds", "Time spent waiting for the gRPC method to reply.", func(m *prometheusRouteMetrics) *prometheusHistogram { return m.upstream }},
+		{"marshal_duration_seconds", "Time spent marshaling response messages.", func(m *prometheusRouteMetrics) *prometheusHistogram { return m.marshal }},
+	} {
+		name := c.namespace + "_" + h.name
+		writePrometheusHeader(w, name, "histogram", h.help)
+		for _, k := range routes {
+			hist := h.get(c.routes[k])
+			if hist.count == 0 {
+				continue
+			}
+			labels := k.labels()
+			var cumulative uint64
+			for i, le := range c.buckets {
+				cumulative += hist.counts[i]
+				fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, formatPrometheusFloat(le), cumulative)
+			}
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, hist.count)
+			fmt.Fprintf(w, "%s_sum{%s} %s\n", name, labels, formatPrometheusFloat(hist.sum))
+			fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, hist.count)
+		}
+	}
+
+	for _, counter := range []struct {
+		name, help string
+		get        func(*prometheusRouteMetrics) uint64
+	}{
+		{"stream_messages_sent_total", "Total number of messages sent by response streams.", func(m *prometheusRouteMetrics) uint64 { return m.streamMessagesSent }},
+		{"response_bytes_total", "Total size of the response bodies, in bytes.", func(m *prometheusRouteMetrics) uint64 { return m.responseBytes }},
+		{"upstream_retries_total", "Total number of retried calls to the gRPC methods.", func(m *prometheusRouteMetrics) uint64 { return m.retries }},
+		{"cache_hits_total", "Total number of requests served from the response cache.", func(m *prometheusRouteMetrics) uint64 { return m.cacheHits }},
+		{"cache_misses_total", "Total number of requests which were not found in the response cache.", func(m *prometheusRouteMetrics) uint64 { return m.cacheMisses }},
+		{"coalesced_requests_total", "Total number of requests served the response of an identical request.", func(m *prometheusRouteMetrics) uint64 { return m.coalesced }},
+	} {
+		name := c.namespace + "_" + counter.name
+		writePrometheusHeader(w, name, "counter", counter.help)
+		for _, k := range routes {
+			fmt.Fprintf(w, "%s{%s} %d\n", name, k.labels(), counter.get(c.routes[k]))
+		}
+	}
+}
+
+func (k prometheusRouteKey) less(o prometheusRouteKey) bool {
+	if k.pattern != o.pattern {
+		return k.pattern < o.pattern
+	}
+	if k.method != o.method {
+		return k.method < o.method
+	}
+	return k.rpcMethod < o.rpcMethod
+}
+
+func (k prometheusRouteKey) labels() string {
+	return "method=" + quotePrometheusLabel(k.method) +
+		",pattern=" + quotePrometheusLabel(k.pattern) +
+		",rpc_method=" + quotePrometheusLabel(k.rpcMethod)
+}
+
+func writePrometheusHeader(w *bufio.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quotePrometheusLabel quotes a label value as required by the text exposition format.
+func quotePrometheusLabel(v string) string {
+	return `"` + prometheusLabelEscaper.Replace(v) + `"`
+}
+
+func formatPrometheusFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
@@ -1,10 +1,11 @@
 package runtime
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
-	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	field_mask "google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -22,10 +24,11 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-var valuesKeyRegexp = regexp.MustCompile(`^(.*)\[(.*)\]$`)
-
+// currentQueryParser is the parser used by PopulateQueryParameters, which has no access to the ServeMux.
 var currentQueryParser QueryParameterParser = &DefaultQueryParser{}
 
+type queryParameterParserKey struct{}
+
 // QueryParameterParser defines interface for all query parameter parsers
 type QueryParameterParser interface {
 	Parse(msg proto.Message, values url.Values, filter *utilities.DoubleArray) error
@@ -33,73 +36,448 @@ type QueryParameterParser interface {
 
 // PopulateQueryParameters parses query parameters
 // into "msg" using current query parser
+//
+// Deprecated: use PopulateQueryParametersContext, which honors the parser configured on the ServeMux
+// handling the request.
 func PopulateQueryParameters(msg proto.Message, values url.Values, filter *utilities.DoubleArray) error {
 	return currentQueryParser.Parse(msg, values, filter)
 }
 
+// PopulateQueryParametersContext parses query parameters into "msg" using the query parser of
+// the ServeMux handling the request in ctx. If ctx was not created by a ServeMux, it falls back
+// to the parser used by PopulateQueryParameters.
+func PopulateQueryParametersContext(ctx context.Context, msg proto.Message, values url.Values, filter *utilities.DoubleArray) error {
+	parser, ok := QueryParameterParserFromContext(ctx)
+	if !ok {
+		parser = currentQueryParser
+	}
+	state := visibilityStateFromContext(ctx)
+	if state == nil {
+		return parser.Parse(msg, values, filter)
+	}
+	// Only the fields set by the query parameters are checked.
+	query := msg.ProtoReflect().New().Interface()
+	if err := parser.Parse(query, values, filter); err != nil {
+		return err
+	}
+	if err := state.checkRequest(query); err != nil {
+		return err
+	}
+	if err := checkOneofsNotSet(msg.ProtoReflect(), query.ProtoReflect()); err != nil {
+		return err
+	}
+	proto.Merge(msg, query)
+	return nil
+}
+
+// checkOneofsNotSet returns the error the parser would return when setting
+// the fields of src into dst, if they set a member of a oneof of which dst
+// already has one, like a field of the request path or body.
+func checkOneofsNotSet(dst, src protoreflect.Message) error {
+	var err error
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsList() || fd.IsMap() {
+			return true
+		}
+		if of := fd.ContainingOneof(); of != nil && !of.IsSynthetic() {
+			if f := dst.WhichOneof(of); f != nil && (fd.Message() == nil || fd.FullName() != f.FullName()) {
+				err = fmt.Errorf("field already set for oneof %q", of.FullName().Name())
+				return false
+			}
+		}
+		if fd.Message() != nil && dst.Has(fd) {
+			err = checkOneofsNotSet(dst.Get(fd).Message(), v.Message())
+		}
+		return err == nil
+	})
+	return err
+}
+
+// QueryParameterParserFromContext returns the query parameter parser of the ServeMux handling
+// the request in ctx, if one exists.
+func QueryParameterParserFromContext(ctx context.Context) (QueryParameterParser, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	p, ok := ctx.Value(queryParameterParserKey{}).(QueryParameterParser)
+	return p, ok && p != nil
+}
+
+func withQueryParameterParser(ctx context.Context, parser QueryParameterParser) context.Context {
+	if parser == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, queryParameterParserKey{}, parser)
+}
+
+const (
+	// defaultQueryMaxRepeatedIndex is used when DefaultQueryParser.MaxRepeatedIndex is not set.
+	defaultQueryMaxRepeatedIndex = 1000
+	// defaultQueryMaxDepth is used when DefaultQueryParser.MaxDepth is not set.
+	defaultQueryMaxDepth = 32
+)
+
+// queryLimits bounds the size of the messages built from query parameters.
+type queryLimits struct {
+	maxIndex int
+	maxDepth int
+}
+
+var defaultQueryLimits = queryLimits{maxIndex: defaultQueryMaxRepeatedIndex, maxDepth: defaultQueryMaxDepth}
+
 // DefaultQueryParser is a QueryParameterParser which implements the default
 // query parameters parsing behavior.
 //
 // See https://github.com/grpc-ecosystem/grpc-gateway/issues/2632 for more context.
-type DefaultQueryParser struct{}
+type DefaultQueryParser struct {
+	// Strict makes Parse reject query parameters which do not match a field of the
+	// request message, parameters which set more than one member of the same oneof,
+	// and parameters which set the same non-repeated field more than once, for example
+	// through both its proto and JSON name. By default, unknown parameters are ignored.
+	//
+	// Use SetQueryParameterParser(&DefaultQueryParser{Strict: true}) to enable it for a ServeMux.
+	Strict bool
+
+	// MaxRepeatedIndex is the largest index accepted for an element of a repeated
+	// field, as in "filters[3].field". It defaults to 1000.
+	MaxRepeatedIndex int
+
+	// MaxDepth is the deepest message nesting a parameter key or a JSON-encoded
+	// message value may reach. It defaults to 32.
+	MaxDepth int
+}
 
 // Parse populates "values" into "msg".
 // A value is ignored if its key starts with one of the elements in "filter".
-func (*DefaultQueryParser) Parse(msg proto.Message, values url.Values, filter *utilities.DoubleArray) error {
-	for key, values := range values {
-		if match := valuesKeyRegexp.FindStringSubmatch(key); len(match) == 3 {
-			key = match[1]
-			values = append([]string{match[2]}, values...)
+//
+// A key is a path of fields separated by dots, each field being named by either
+// its proto or its JSON name. A field of a message may also be named in brackets,
+// and an element of a repeated field or an entry of a map is addressed by its
+// index or key in brackets:
+//
+//	filters[0].field=a&filters[0].op=EQ
+//	filters[0][field]=a
+//	map_value[key]=value
+//
+// The value of a message field which is not a well-known type is parsed as JSON,
+// as in filter={"field":"a"}. Repeating such a parameter appends to a repeated field.
+func (p *DefaultQueryParser) Parse(msg proto.Message, values url.Values, filter *utilities.DoubleArray) error {
+	limits := defaultQueryLimits
+	var strict *strictQueryState
+	if p != nil {
+		if p.MaxRepeatedIndex > 0 {
+			limits.maxIndex = p.MaxRepeatedIndex
+		}
+		if p.MaxDepth > 0 {
+			limits.maxDepth = p.MaxDepth
+		}
+		if p.Strict {
+			strict = &strictQueryState{
+				fields: make(map[string]string),
+				oneofs: make(map[string]oneofMember),
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	if strict != nil {
+		// Process parameters in a deterministic order so that errors are reproducible.
+		sort.Strings(keys)
+	}
+
+	msgValue := msg.ProtoReflect()
+	for _, key := range keys {
+		segs, unknown, err := parseQueryKey(msgValue.Descriptor(), key, limits)
+		if err != nil {
+			return err
 		}
 
-		msgValue := msg.ProtoReflect()
-		fieldPath := normalizeFieldPath(msgValue, strings.Split(key, "."))
+		fieldPath := make([]string, 0, len(segs)+1)
+		for _, seg := range segs {
+			fieldPath = append(fieldPath, string(seg.fd.Name()))
+		}
+		if unknown != "" {
+			fieldPath = append(fieldPath, unknown)
+		}
 		if filter.HasCommonPrefix(fieldPath) {
 			continue
 		}
-		if err := populateFieldValueFromPath(msgValue, fieldPath, values); err != nil {
+
+		// Keys which address no field, like the empty key, are unknown too.
+		if unknown != "" || len(segs) == 0 {
+			if strict != nil {
+				strict.unknown = append(strict.unknown, key)
+				continue
+			}
+			// We're not returning an error here because this could just be
+			// an extra query parameter that isn't part of the request.
+			grpclog.Infof("field not found in %q: %q", msgValue.Descriptor().FullName(), key)
+			continue
+		}
+		if strict != nil {
+			if err := strict.check(key, segs); err != nil {
+				return err
+			}
+		}
+		if err := populateQueryParameter(msgValue, segs, values[key], limits); err != nil {
 			return err
 		}
 	}
+	if strict != nil {
+		return strict.err()
+	}
 	return nil
 }
 
-// PopulateFieldFromPath sets a value in a nested Protobuf structure.
-func PopulateFieldFromPath(msg proto.Message, fieldPathString string, value string) error {
-	fieldPath := strings.Split(fieldPathString, ".")
-	return populateFieldValueFromPath(msg.ProtoReflect(), fieldPath, []string{value})
+// querySegment is a field addressed by an element of a query parameter key.
+type querySegment struct {
+	fd protoreflect.FieldDescriptor
+	// index addresses an element of a repeated field, or is -1.
+	index int
+	// mapKey addresses an entry of a map field if hasMapKey is set.
+	mapKey    string
+	hasMapKey bool
 }
 
-func normalizeFieldPath(msgValue protoreflect.Message, fieldPath []string) []string {
-	newFieldPath := make([]string, 0, len(fieldPath))
-	for i, fieldName := range fieldPath {
-		fields := msgValue.Descriptor().Fields()
-		fieldDesc := fields.ByTextName(fieldName)
-		if fieldDesc == nil {
-			fieldDesc = fields.ByJSONName(fieldName)
+// addressesMessage reports whether the segment resolves to a single message,
+// which the key can continue into.
+func (s querySegment) addressesMessage() bool {
+	switch {
+	case s.fd.IsMap():
+		return s.hasMapKey && s.fd.MapValue().Message() != nil
+	case s.fd.IsList():
+		return s.index >= 0 && s.fd.Message() != nil
+	default:
+		return s.fd.Message() != nil
+	}
+}
+
+func (s querySegment) messageDescriptor() protoreflect.MessageDescriptor {
+	if s.fd.IsMap() {
+		return s.fd.MapValue().Message()
+	}
+	return s.fd.Message()
+}
+
+// parseQueryKey splits "key" into the fields it addresses, starting at "md".
+// If an element of the key does not name a field, the segments resolved so far
+// are returned together with the unknown name.
+func parseQueryKey(md protoreflect.MessageDescriptor, key string, limits queryLimits) ([]querySegment, string, error) {
+	var segs []querySegment
+	pos := 0
+	for {
+		var name string
+		if len(segs) > 0 && key[pos] == '[' {
+			end := strings.IndexByte(key[pos:], ']')
+			if end < 0 {
+				return nil, "", fmt.Errorf("invalid query parameter %q: missing %q", key, "]")
+			}
+			name, pos = key[pos+1:pos+end], pos+end+1
+		} else {
+			end := strings.IndexAny(key[pos:], ".[")
+			if end < 0 {
+				end = len(key) - pos
+			}
+			name, pos = key[pos:pos+end], pos+end
 		}
-		if fieldDesc == nil {
-			// return initial field path values if no matching  message field was found
-			return fieldPath
+		if name == "" {
+			return segs, key, nil
 		}
 
-		newFieldPath = append(newFieldPath, string(fieldDesc.Name()))
+		fd := getFieldByName(md.Fields(), name)
+		if fd == nil {
+			return segs, name, nil
+		}
+		seg := querySegment{fd: fd, index: -1}
+		if pos < len(key) && key[pos] == '[' && (fd.IsMap() || fd.IsList()) {
+			end := strings.IndexByte(key[pos:], ']')
+			if end < 0 {
+				return nil, "", fmt.Errorf("invalid query parameter %q: missing %q", key, "]")
+			}
+			end += pos
+			switch {
+			case fd.IsMap():
+				if fd.MapValue().Message() == nil {
+					// Keys of maps with scalar values extend to the last bracket,
+					// so that they may contain brackets themselves.
+					end = strings.LastIndexByte(key, ']')
+				}
+				seg.mapKey, seg.hasMapKey = key[pos+1:end], true
+			default:
+				index, err := strconv.Atoi(key[pos+1 : end])
+				if err != nil || index < 0 {
+					return nil, "", fmt.Errorf("invalid index %q for repeated field %q", key[pos+1:end], fd.Name())
+				}
+				if index > limits.maxIndex {
+					return nil, "", fmt.Errorf("index %d for repeated field %q exceeds the maximum of %d", index, fd.Name(), limits.maxIndex)
+				}
+				seg.index = index
+			}
+			pos = end + 1
+		}
 
-		// If this is the last element, we're done
-		if i == len(fieldPath)-1 {
+		segs = append(segs, seg)
+		if len(segs) > limits.maxDepth {
+			return nil, "", fmt.Errorf("query parameter %q exceeds the maximum depth of %d", key, limits.maxDepth)
+		}
+		if pos == len(key) {
+			return segs, "", nil
+		}
+		if !seg.addressesMessage() {
+			return nil, "", fmt.Errorf("invalid path: %q is not a message", fd.Name())
+		}
+		md = seg.messageDescriptor()
+		if key[pos] == '.' {
+			pos++
+		}
+		if pos == len(key) {
+			return segs, key, nil
+		}
+	}
+}
+
+// populateQueryParameter sets "values" into the field addressed by "segs".
+func populateQueryParameter(msgValue protoreflect.Message, segs []querySegment, values []string, limits queryLimits) error {
+	if len(values) < 1 {
+		return errors.New("no value provided")
+	}
+
+	for i, seg := range segs {
+		fd := seg.fd
+
+		// Check if oneof already set
+		if of := fd.ContainingOneof(); of != nil && !of.IsSynthetic() {
+			if f := msgValue.WhichOneof(of); f != nil {
+				if fd.Message() == nil || fd.FullName() != f.FullName() {
+					return fmt.Errorf("field already set for oneof %q", of.FullName().Name())
+				}
+			}
+		}
+
+		if i == len(segs)-1 {
 			break
 		}
 
-		// Only singular message fields are allowed
-		if fieldDesc.Message() == nil || fieldDesc.Cardinality() == protoreflect.Repeated {
-			return fieldPath
+		switch {
+		case seg.index >= 0:
+			list := msgValue.Mutable(fd).List()
+			growList(list, seg.index)
+			msgValue = list.Get(seg.index).Message()
+		case seg.hasMapKey:
+			key, err := parseField(fd.MapKey(), seg.mapKey, limits)
+			if err != nil {
+				return fmt.Errorf("parsing map key %q: %w", fd.FullName().Name(), err)
+			}
+			msgValue = msgValue.Mutable(fd).Map().Mutable(key.MapKey()).Message()
+		default:
+			msgValue = msgValue.Mutable(fd).Message()
 		}
+	}
 
-		// Get the nested message
-		msgValue = msgValue.Get(fieldDesc).Message()
+	last := segs[len(segs)-1]
+	fd := last.fd
+	switch {
+	case last.index >= 0:
+		if len(values) > 1 {
+			return fmt.Errorf("too many values for element %d of field %q: %s", last.index, fd.FullName().Name(), strings.Join(values, ", "))
+		}
+		v, err := parseField(fd, values[0], limits)
+		if err != nil {
+			return fmt.Errorf("parsing list %q: %w", fd.FullName().Name(), err)
+		}
+		list := msgValue.Mutable(fd).List()
+		growList(list, last.index)
+		list.Set(last.index, v)
+		return nil
+	case fd.IsList():
+		return populateRepeatedField(fd, msgValue.Mutable(fd).List(), values, limits)
+	case fd.IsMap():
+		if last.hasMapKey {
+			values = append([]string{last.mapKey}, values...)
+		}
+		return populateMapField(fd, msgValue.Mutable(fd).Map(), values, limits)
 	}
 
-	return newFieldPath
+	if len(values) > 1 {
+		return fmt.Errorf("too many values for field %q: %s", fd.FullName().Name(), strings.Join(values, ", "))
+	}
+
+	return populateField(fd, msgValue, values[0], limits)
+}
+
+// growList appends empty elements to "list" until "index" is valid.
+func growList(list protoreflect.List, index int) {
+	for list.Len() <= index {
+		list.Append(list.NewElement())
+	}
+}
+
+// strictQueryState tracks the parameters seen by a strict DefaultQueryParser.
+type strictQueryState struct {
+	unknown []string
+	// fields maps the path of every non-repeated field set so far to the parameter which set it.
+	fields map[string]string
+	// oneofs maps a oneof, qualified by the path of its containing message, to the member set so far.
+	oneofs map[string]oneofMember
+}
+
+type oneofMember struct {
+	field protoreflect.Name
+	param string
+}
+
+func (s *strictQueryState) check(param string, segs []querySegment) error {
+	var prefix string
+	for _, seg := range segs {
+		if of := seg.fd.ContainingOneof(); of != nil && !of.IsSynthetic() {
+			key := prefix + string(of.Name())
+			if m, ok := s.oneofs[key]; ok && m.field != seg.fd.Name() {
+				return fmt.Errorf("parameters %q and %q set multiple members of oneof %q", m.param, param, of.Name())
+			}
+			s.oneofs[key] = oneofMember{field: seg.fd.Name(), param: param}
+		}
+		prefix += string(seg.fd.Name())
+		switch {
+		case seg.index >= 0:
+			prefix += "[" + strconv.Itoa(seg.index) + "]"
+		case seg.hasMapKey:
+			prefix += "[" + seg.mapKey + "]"
+		}
+		prefix += "."
+	}
+
+	last := segs[len(segs)-1]
+	if (last.fd.IsList() && last.index < 0) || (last.fd.IsMap() && !last.hasMapKey) {
+		return nil
+	}
+	path := strings.TrimSuffix(prefix, ".")
+	for other, otherParam := range s.fields {
+		if other == path || strings.HasPrefix(other, path+".") || strings.HasPrefix(path, other+".") {
+			return fmt.Errorf("parameters %q and %q both set field %q", otherParam, param, other)
+		}
+	}
+	s.fields[path] = param
+	return nil
+}
+
+func (s *strictQueryState) err() error {
+	if len(s.unknown) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(s.unknown))
+	for i, u := range s.unknown {
+		quoted[i] = strconv.Quote(u)
+	}
+	return fmt.Errorf("unknown query parameters: %s", strings.Join(quoted, ", "))
+}
+
+// PopulateFieldFromPath sets a value in a nested Protobuf structure.
+func PopulateFieldFromPath(msg proto.Message, fieldPathString string, value string) error {
+	fieldPath := strings.Split(fieldPathString, ".")
+	return populateFieldValueFromPath(msg.ProtoReflect(), fieldPath, []string{value})
 }
 
 func populateFieldValueFromPath(msgValue protoreflect.Message, fieldPath []string, values []string) error {
@@ -151,20 +529,20 @@ func populateFieldValueFromPath(msgValue protoreflect.Message, fieldPath []strin
 
 	switch {
 	case fieldDescriptor.IsList():
-		return populateRepeatedField(fieldDescriptor, msgValue.Mutable(fieldDescriptor).List(), values)
+		return populateRepeatedField(fieldDescriptor, msgValue.Mutable(fieldDescriptor).List(), values, defaultQueryLimits)
 	case fieldDescriptor.IsMap():
-		return populateMapField(fieldDescriptor, msgValue.Mutable(fieldDescriptor).Map(), values)
+		return populateMapField(fieldDescriptor, msgValue.Mutable(fieldDescriptor).Map(), values, defaultQueryLimits)
 	}
 
 	if len(values) > 1 {
 		return fmt.Errorf("too many values for field %q: %s", fieldDescriptor.FullName().Name(), strings.Join(values, ", "))
 	}
 
-	return populateField(fieldDescriptor, msgValue, values[0])
+	return populateField(fieldDescriptor, msgValue, values[0], defaultQueryLimits)
 }
 
-func populateField(fieldDescriptor protoreflect.FieldDescriptor, msgValue protoreflect.Message, value string) error {
-	v, err := parseField(fieldDescriptor, value)
+func populateField(fieldDescriptor protoreflect.FieldDescriptor, msgValue protoreflect.Message, value string, limits queryLimits) error {
+	v, err := parseField(fieldDescriptor, value, limits)
 	if err != nil {
 		return fmt.Errorf("parsing field %q: %w", fieldDescriptor.FullName().Name(), err)
 	}
@@ -173,9 +551,9 @@ func populateField(fieldDescriptor protoreflect.FieldDescriptor, msgValue protor
 	return nil
 }
 
-func populateRepeatedField(fieldDescriptor protoreflect.FieldDescriptor, list protoreflect.List, values []string) error {
+func populateRepeatedField(fieldDescriptor protoreflect.FieldDescriptor, list protoreflect.List, values []string, limits queryLimits) error {
 	for _, value := range values {
-		v, err := parseField(fieldDescriptor, value)
+		v, err := parseField(fieldDescriptor, value, limits)
 		if err != nil {
 			return fmt.Errorf("parsing list %q: %w", fieldDescriptor.FullName().Name(), err)
 		}
@@ -185,17 +563,17 @@ func populateRepeatedField(fieldDescriptor protoreflect.FieldDescriptor, list pr
 	return nil
 }
 
-func populateMapField(fieldDescriptor protoreflect.FieldDescriptor, mp protoreflect.Map, values []string) error {
+func populateMapField(fieldDescriptor protoreflect.FieldDescriptor, mp protoreflect.Map, values []string, limits queryLimits) error {
 	if len(values) != 2 {
 		return fmt.Errorf("more than one value provided for key %q in map %q", values[0], fieldDescriptor.FullName())
 	}
 
-	key, err := parseField(fieldDescriptor.MapKey(), values[0])
+	key, err := parseField(fieldDescriptor.MapKey(), values[0], limits)
 	if err != nil {
 		return fmt.Errorf("parsing map key %q: %w", fieldDescriptor.FullName().Name(), err)
 	}
 
-	value, err := parseField(fieldDescriptor.MapValue(), values[1])
+	value, err := parseField(fieldDescriptor.MapValue(), values[1], limits)
 	if err != nil {
 		return fmt.Errorf("parsing map value %q: %w", fieldDescriptor.FullName().Name(), err)
 	}
@@ -205,7 +583,7 @@ func populateMapField(fieldDescriptor protoreflect.FieldDescriptor, mp protorefl
 	return nil
 }
 
-func parseField(fieldDescriptor protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+func parseField(fieldDescriptor protoreflect.FieldDescriptor, value string, limits queryLimits) (protoreflect.Value, error) {
 	switch fieldDescriptor.Kind() {
 	case protoreflect.BoolKind:
 		v, err := strconv.ParseBool(value)
@@ -279,13 +657,13 @@ func parseField(fieldDescriptor protoreflect.FieldDescriptor, value string) (pro
 		}
 		return protoreflect.ValueOfBytes(v), nil
 	case protoreflect.MessageKind, protoreflect.GroupKind:
-		return parseMessage(fieldDescriptor.Message(), value)
+		return parseMessage(fieldDescriptor.Message(), value, limits)
 	default:
 		panic(fmt.Sprintf("unknown field kind: %v", fieldDescriptor.Kind()))
 	}
 }
 
-func parseMessage(msgDescriptor protoreflect.MessageDescriptor, value string) (protoreflect.Value, error) {
+func parseMessage(msgDescriptor protoreflect.MessageDescriptor, value string, limits queryLimits) (protoreflect.Value, error) {
 	var msg proto.Message
 	switch msgDescriptor.FullName() {
 	case "google.protobuf.Timestamp":
@@ -371,7 +749,21 @@ func parseMessage(msgDescriptor protoreflect.MessageDescriptor, value string) (p
 		}
 		msg = &v
 	default:
-		return protoreflect.Value{}, fmt.Errorf("unsupported message type: %q", string(msgDescriptor.FullName()))
+		var m protoreflect.Message
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(msgDescriptor.FullName())
+		switch {
+		case err == nil:
+			m = mt.New()
+		case errors.Is(err, protoregistry.NotFound):
+			m = dynamicpb.NewMessage(msgDescriptor)
+		default:
+			return protoreflect.Value{}, fmt.Errorf("failed to look up message: %w", err)
+		}
+		opts := protojson.UnmarshalOptions{RecursionLimit: limits.maxDepth}
+		if err := opts.Unmarshal([]byte(value), m.Interface()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		msg = m.Interface()
 	}
 
 	return protoreflect.ValueOfMessage(msg.ProtoReflect()), nil
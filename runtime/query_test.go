@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -526,6 +527,14 @@ func TestPopulateParameters(t *testing.T) {
 			want:    &examplepb.Proto3Message{},
 			wanterr: errors.New(`parsing field "timestamp_value": 0000-01-01T00:00:00.00Z before 0001-01-01`),
 		},
+		{
+			values: url.Values{
+				"":             {"x"},
+				"string_value": {"str"},
+			},
+			filter: utilities.NewDoubleArray(nil),
+			want:   &examplepb.Proto3Message{StringValue: "str"},
+		},
 	} {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
 			msg := spec.want.ProtoReflect().New().Interface()
@@ -729,3 +738,303 @@ func TestPopulateQueryParametersWithInvalidNestedParameters(t *testing.T) {
 		}
 	}
 }
+
+func TestPopulateQueryParametersStrict(t *testing.T) {
+	parser := &runtime.DefaultQueryParser{Strict: true}
+	for _, spec := range []struct {
+		name    string
+		values  url.Values
+		filter  *utilities.DoubleArray
+		want    proto.Message
+		wantErr string
+	}{
+		{
+			name: "known parameters",
+			values: url.Values{
+				"int32_value":       {"1"},
+				"stringValue":       {"str"},
+				"repeated_value":    {"a", "b"},
+				"map_value[key]":    {"value"},
+				"nested.bool_value": {"true"},
+			},
+			filter: utilities.NewDoubleArray(nil),
+			want: &examplepb.Proto3Message{
+				Int32Value:    1,
+				StringValue:   "str",
+				RepeatedValue: []string{"a", "b"},
+				MapValue:      map[string]string{"key": "value"},
+				Nested:        &examplepb.Proto3Message{BoolValue: true},
+			},
+		},
+		{
+			name: "filtered parameters are not unknown",
+			values: url.Values{
+				"int32_value":  {"1"},
+				"string_value": {"str"},
+			},
+			filter: utilities.NewDoubleArray([][]string{{"string_value"}}),
+			want:   &examplepb.Proto3Message{Int32Value: 1},
+		},
+		{
+			name: "unknown parameters",
+			values: url.Values{
+				"int32_value":    {"1"},
+				"pageSize":       {"10"},
+				"nested.unknown": {"x"},
+			},
+			filter:  utilities.NewDoubleArray(nil),
+			wantErr: `unknown query parameters: "nested.unknown", "pageSize"`,
+		},
+		{
+			name: "empty key",
+			values: url.Values{
+				"":            {"x"},
+				"int32_value": {"1"},
+			},
+			filter:  utilities.NewDoubleArray(nil),
+			wantErr: `unknown query parameters: ""`,
+		},
+		{
+			name: "multiple oneof members",
+			values: url.Values{
+				"oneof_bool_value":   {"true"},
+				"oneof_string_value": {"str"},
+			},
+			filter:  utilities.NewDoubleArray(nil),
+			wantErr: `parameters "oneof_bool_value" and "oneof_string_value" set multiple members of oneof "oneof_value"`,
+		},
+		{
+			name: "multiple oneof members through nested field",
+			values: url.Values{
+				"nested_oneof_int32_value":           {"1"},
+				"nested_oneof_value_one.int32_value": {"2"},
+			},
+			filter:  utilities.NewDoubleArray(nil),
+			wantErr: `parameters "nested_oneof_int32_value" and "nested_oneof_value_one.int32_value" set multiple members of oneof "nested_oneof_value"`,
+		},
+		{
+			name: "same field through proto and JSON name",
+			values: url.Values{
+				"int32Value":  {"1"},
+				"int32_value": {"2"},
+			},
+			filter:  utilities.NewDoubleArray(nil),
+			wantErr: `parameters "int32Value" and "int32_value" both set field "int32_value"`,
+		},
+		{
+			name: "message field and its sub-field",
+			values: url.Values{
+				"timestamp_value":         {"2016-12-15T12:23:32Z"},
+				"timestamp_value.seconds": {"1"},
+			},
+			filter:  utilities.NewDoubleArray(nil),
+			wantErr: `parameters "timestamp_value" and "timestamp_value.seconds" both set field "timestamp_value"`,
+		},
+		{
+			name: "repeated values for non-repeated field",
+			values: url.Values{
+				"int32_value": {"1", "2"},
+			},
+			filter:  utilities.NewDoubleArray(nil),
+			wantErr: `too many values for field "int32_value": 1, 2`,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			msg := &examplepb.Proto3Message{}
+			err := parser.Parse(msg, spec.values, spec.filter)
+			if spec.wantErr != "" {
+				if err == nil || err.Error() != spec.wantErr {
+					t.Fatalf("parser.Parse(msg, %v, %v) = %v; want error %q", spec.values, spec.filter, err, spec.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parser.Parse(msg, %v, %v) failed with %v; want success", spec.values, spec.filter, err)
+			}
+			if diff := cmp.Diff(spec.want, msg, protocmp.Transform()); diff != "" {
+				t.Errorf("parser.Parse(msg, %v, %v) mismatch (-want +got):\n%s", spec.values, spec.filter, diff)
+			}
+		})
+	}
+}
+
+func TestPopulateQueryParametersNestedMessages(t *testing.T) {
+	for _, spec := range []struct {
+		name   string
+		parser *runtime.DefaultQueryParser
+		values url.Values
+		want   proto.Message
+	}{
+		{
+			name: "indexed repeated message",
+			values: url.Values{
+				"nested[0].name":   {"a"},
+				"nested[0].amount": {"1"},
+				"nested[1].name":   {"b"},
+			},
+			want: &examplepb.ABitOfEverything{
+				Nested: []*examplepb.ABitOfEverything_Nested{
+					{Name: "a", Amount: 1},
+					{Name: "b"},
+				},
+			},
+		},
+		{
+			name: "bracket field names",
+			values: url.Values{
+				"nested[0][name]":     {"a"},
+				"nested[1][ok]":       {"TRUE"},
+				"single_nested[name]": {"c"},
+			},
+			want: &examplepb.ABitOfEverything{
+				Nested: []*examplepb.ABitOfEverything_Nested{
+					{Name: "a"},
+					{Ok: examplepb.ABitOfEverything_Nested_TRUE},
+				},
+				SingleNested: &examplepb.ABitOfEverything_Nested{Name: "c"},
+			},
+		},
+		{
+			name: "indexed repeated scalar",
+			values: url.Values{
+				"repeated_string_value[1]": {"b"},
+				"repeated_string_value[0]": {"a"},
+			},
+			want: &examplepb.ABitOfEverything{
+				RepeatedStringValue: []string{"a", "b"},
+			},
+		},
+		{
+			name: "map of messages",
+			values: url.Values{
+				"mapped_nested_value[key].name": {"a"},
+				"mappedNestedValue[key].amount": {"2"},
+			},
+			want: &examplepb.ABitOfEverything{
+				MappedNestedValue: map[string]*examplepb.ABitOfEverything_Nested{
+					"key": {Name: "a", Amount: 2},
+				},
+			},
+		},
+		{
+			name: "JSON encoded messages",
+			values: url.Values{
+				"single_nested": {`{"name":"a","amount":1}`},
+				"nested":        {`{"name":"b"}`, `{"name":"c","ok":"TRUE"}`},
+			},
+			want: &examplepb.ABitOfEverything{
+				SingleNested: &examplepb.ABitOfEverything_Nested{Name: "a", Amount: 1},
+				Nested: []*examplepb.ABitOfEverything_Nested{
+					{Name: "b"},
+					{Name: "c", Ok: examplepb.ABitOfEverything_Nested_TRUE},
+				},
+			},
+		},
+		{
+			name: "JSON encoded repeated element",
+			values: url.Values{
+				"nested[1]": {`{"name":"b"}`},
+			},
+			want: &examplepb.ABitOfEverything{
+				Nested: []*examplepb.ABitOfEverything_Nested{
+					{},
+					{Name: "b"},
+				},
+			},
+		},
+		{
+			name:   "strict parser",
+			parser: &runtime.DefaultQueryParser{Strict: true},
+			values: url.Values{
+				"nested[0].name":                {"a"},
+				"mapped_nested_value[key].name": {"b"},
+			},
+			want: &examplepb.ABitOfEverything{
+				Nested:            []*examplepb.ABitOfEverything_Nested{{Name: "a"}},
+				MappedNestedValue: map[string]*examplepb.ABitOfEverything_Nested{"key": {Name: "b"}},
+			},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			parser := spec.parser
+			if parser == nil {
+				parser = &runtime.DefaultQueryParser{}
+			}
+			msg := spec.want.ProtoReflect().New().Interface()
+			if err := parser.Parse(msg, spec.values, utilities.NewDoubleArray(nil)); err != nil {
+				t.Fatalf("parser.Parse(msg, %v) failed with %v; want success", spec.values, err)
+			}
+			if diff := cmp.Diff(spec.want, msg, protocmp.Transform()); diff != "" {
+				t.Errorf("parser.Parse(msg, %v) mismatch (-want +got):\n%s", spec.values, diff)
+			}
+		})
+	}
+}
+
+func TestPopulateQueryParametersNestedMessagesErrors(t *testing.T) {
+	for _, spec := range []struct {
+		name    string
+		parser  *runtime.DefaultQueryParser
+		values  url.Values
+		wantErr string
+	}{
+		{
+			name:    "non-numeric index",
+			values:  url.Values{"nested[x].name": {"a"}},
+			wantErr: `invalid index "x" for repeated field "nested"`,
+		},
+		{
+			name:    "negative index",
+			values:  url.Values{"nested[-1].name": {"a"}},
+			wantErr: `invalid index "-1" for repeated field "nested"`,
+		},
+		{
+			name:    "index over the default limit",
+			values:  url.Values{"nested[1001].name": {"a"}},
+			wantErr: `index 1001 for repeated field "nested" exceeds the maximum of 1000`,
+		},
+		{
+			name:    "index over a configured limit",
+			parser:  &runtime.DefaultQueryParser{MaxRepeatedIndex: 2},
+			values:  url.Values{"nested[3].name": {"a"}},
+			wantErr: `index 3 for repeated field "nested" exceeds the maximum of 2`,
+		},
+		{
+			name:    "key over the depth limit",
+			parser:  &runtime.DefaultQueryParser{MaxDepth: 1},
+			values:  url.Values{"single_nested.name": {"a"}},
+			wantErr: `query parameter "single_nested.name" exceeds the maximum depth of 1`,
+		},
+		{
+			name:    "repeated message without index",
+			values:  url.Values{"nested.name": {"a"}},
+			wantErr: `invalid path: "nested" is not a message`,
+		},
+		{
+			name:    "missing bracket",
+			values:  url.Values{"nested[0.name": {"a"}},
+			wantErr: `invalid query parameter "nested[0.name": missing "]"`,
+		},
+		{
+			name:    "several values for an element",
+			values:  url.Values{"repeated_string_value[0]": {"a", "b"}},
+			wantErr: `too many values for element 0 of field "repeated_string_value": a, b`,
+		},
+		{
+			name:    "invalid JSON",
+			values:  url.Values{"single_nested": {`{"unknown":1}`}},
+			wantErr: `parsing field "single_nested": proto:`,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			parser := spec.parser
+			if parser == nil {
+				parser = &runtime.DefaultQueryParser{}
+			}
+			err := parser.Parse(&examplepb.ABitOfEverything{}, spec.values, utilities.NewDoubleArray(nil))
+			if err == nil || !strings.HasPrefix(err.Error(), spec.wantErr) {
+				t.Errorf("parser.Parse(msg, %v) = %v; want error %q", spec.values, err, spec.wantErr)
+			}
+		})
+	}
+}
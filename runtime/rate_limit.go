@@ -0,0 +1,383 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/netip"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// RateLimit is the configuration of a token bucket.
+type RateLimit struct {
+	// Rate is the number of tokens added to the bucket per second.
+	Rate float64
+	// Burst is the capacity of the bucket, which is full initially.
+	Burst int
+}
+
+// RateLimitResult is the outcome of taking a token from a bucket.
+type RateLimitResult struct {
+	// Allowed reports whether a token was taken.
+	Allowed bool
+	// Remaining is the number of tokens left in the bucket.
+	Remaining int
+	// RetryAfter is the time until a token is available, if none was taken.
+	RetryAfter time.Duration
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+}
+
+// RateLimiter takes tokens from token buckets identified by a key. It may be
+// backed by a store shared by several gateways.
+type RateLimiter interface {
+	// Allow takes a token from the bucket identified by key, creating it with
+	// the given limit if needed.
+	Allow(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
+
+// RateLimitKeyFunc returns the key identifying the client of a request, or
+// false if the policy does not apply to the request. md is the metadata that
+// the gateway sends to the gRPC server.
+type RateLimitKeyFunc func(ctx context.Context, r *http.Request, md metadata.MD) (string, bool)
+
+// RateLimitByMethod is a RateLimitKeyFunc sharing a bucket between all the
+// clients of a gRPC method.
+func RateLimitByMethod() RateLimitKeyFunc {
+	return func(ctx context.Context, _ *http.Request, _ metadata.MD) (string, bool) {
+		return RPCMethod(ctx)
+	}
+}
+
+// RateLimitByClientIP is a RateLimitKeyFunc giving a bucket to each client IP.
+// The X-Forwarded-For header is only trusted when the request comes from one
+// of trustedProxies.
+func RateLimitByClientIP(trustedProxies ...netip.Prefix) RateLimitKeyFunc {
+	return func(_ context.Context, r *http.Request, _ metadata.MD) (string, bool) {
+		return clientIP(r, trustedProxies), true
+	}
+}
+
+// RateLimitByHeader is a RateLimitKeyFunc giving a bucket to each value of a
+// request header, like an API key. Requests without the header are not limited.
+func RateLimitByHeader(header string) RateLimitKeyFunc {
+	header = textproto.CanonicalMIMEHeaderKey(header)
+	return func(_ context.Context, r *http.Request, _ metadata.MD) (string, bool) {
+		v := r.Header.Get(header)
+		return v, v != ""
+	}
+}
+
+// RateLimitByMetadata is a RateLimitKeyFunc giving a bucket to each value of a
+// metadata key sent to the gRPC server. Requests without the key are not limited.
+func RateLimitByMetadata(key string) RateLimitKeyFunc {
+	return func(_ context.Context, _ *http.Request, md metadata.MD) (string, bool) {
+		vs := md.Get(key)
+		if len(vs) == 0 || vs[0] == "" {
+			return "", false
+		}
+		return vs[0], true
+	}
+}
+
+// RateLimitPolicy limits the rate of the requests to some gRPC methods.
+type RateLimitPolicy struct {
+	// Selector is the full name of a gRPC method, like "package.Service.Method".
+	// It may end with ".*" to select all the methods of a service or package,
+	// or be "*" to select every method.
+	Selector string
+	// Key identifies the bucket of each request. The default is RateLimitByMethod.
+	Key RateLimitKeyFunc
+	// Limit configures the buckets.
+	Limit RateLimit
+}
+
+func (p RateLimitPolicy) matches(fullMethod string) bool {
+	return selectorMatches(p.Selector, fullMethod)
+}
+
+// selectorMatches reports whether the selector of a method policy matches the
+// full name of a gRPC method, like "package.Service.Method".
+func selectorMatches(selector, fullMethod string) bool {
+	switch {
+	case selector == "*":
+		return true
+	case strings.HasSuffix(selector, ".*"):
+		return strings.HasPrefix(fullMethod, strings.TrimSuffix(selector, "*"))
+	default:
+		return selector == fullMethod
+	}
+}
+
+// fullMethodName returns the full name of the gRPC method rpcMethodName,
+// like "package.Service.Method" for "/package.Service/Method".
+func fullMethodName(rpcMethodName string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(rpcMethodName, "/"), "/", ".")
+}
+
+type rateLimitConfig struct {
+	limiter  RateLimiter
+	policies []RateLimitPolicy
+}
+
+// WithRateLimit returns a ServeMuxOption that limits the rate of the requests
+// with token buckets taken from limiter.
+//
+// The limits are enforced by AnnotateContext and AnnotateIncomingContext,
+// once the gRPC method of the request is known, and for the requests served
+// by WithGRPCWeb. Every policy selecting the
+// method applies, each with its own buckets. Requests exceeding a limit are
+// rejected through the error handler of the mux with a ResourceExhausted
+// error and a Retry-After header. RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers describe the most constrained bucket.
+func WithRateLimit(limiter RateLimiter, policies ...RateLimitPolicy) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.rateLimit = &rateLimitConfig{limiter: limiter, policies: policies}
+	}
+}
+
+type responseHeaderKey struct{}
+
+// withResponseHeader makes the header of the response available to the
+// runtime functions which have no access to the ResponseWriter.
+func withResponseHeader(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, header)
+}
+
+func responseHeaderFromContext(ctx context.Context) (http.Header, bool) {
+	header, ok := ctx.Value(responseHeaderKey{}).(http.Header)
+	return header, ok
+}
+
+// check takes a token from the buckets of the policies selecting the method
+// of the request, and returns a ResourceExhausted error if one is empty.
+func (c *rateLimitConfig) check(ctx context.Context, r *http.Request, rpcMethodName string, md metadata.MD) error {
+	fullMethod := fullMethodName(rpcMethodName)
+
+	var (
+		limited    bool
+		tightest   RateLimitResult
+		tightLimit RateLimit
+		rejected   bool
+	)
+	for i, p := range c.policies {
+		if !p.matches(fullMethod) {
+			continue
+		}
+		keyFunc := p.Key
+		if keyFunc == nil {
+			keyFunc = RateLimitByMethod()
+		}
+		key, ok := keyFunc(ctx, r, md)
+		if !ok {
+			continue
+		}
+		res, err := c.limiter.Allow(ctx, strconv.Itoa(i)+"\x00"+p.Selector+"\x00"+key, p.Limit)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "rate limiter: %v", err)
+		}
+		if !limited || res.tighterThan(tightest) {
+			limited, tightest, tightLimit = true, res, p.Limit
+		}
+		rejected = rejected || !res.Allowed
+	}
+	if !limited {
+		return nil
+	}
+
+	if header, ok := responseHeaderFromContext(ctx); ok {
+		header.Set("RateLimit-Limit", strconv.Itoa(tightLimit.Burst))
+		header.Set("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(tightest.Reset)))
+		if rejected {
+			header.Set("Retry-After", strconv.Itoa(max(ceilSeconds(tightest.RetryAfter), 1)))
+		}
+	}
+	if rejected {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", rpcMethodName)
+	}
+	return nil
+}
+
+// tighterThan reports whether r describes a more constrained bucket than o.
+func (r RateLimitResult) tighterThan(o RateLimitResult) bool {
+	if r.Allowed != o.Allowed {
+		return !r.Allowed
+	}
+	if !r.Allowed {
+		return r.RetryAfter > o.RetryAfter
+	}
+	return r.Remaining < o.Remaining
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// MemoryRateLimiter is a RateLimiter keeping its token buckets in memory.
+// Full buckets are discarded every minute to bound its memory use.
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	limit  RateLimit
+}
+
+const memoryRateLimiterSweepInterval = time.Minute
+
+// NewMemoryRateLimiter returns a new MemoryRateLimiter.
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow implements RateLimiter.
+func (l *MemoryRateLimiter) Allow(_ context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= memoryRateLimiterSweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.limit = limit
+	b.refill(now)
+
+	var res RateLimitResult
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else if limit.Rate > 0 {
+		res.RetryAfter = secondsToDuration((1 - b.tokens) / limit.Rate)
+	} else {
+		res.RetryAfter = time.Duration(math.MaxInt64)
+	}
+	res.Remaining = int(b.tokens)
+	if limit.Rate > 0 {
+		res.Reset = secondsToDuration((float64(limit.Burst) - b.tokens) / limit.Rate)
+	}
+	return res, nil
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate)
+	b.last = now
+}
+
+// sweep discards the buckets which are full, as they would be recreated full.
+func (l *MemoryRateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// rateLimitYAML is the rate_limits section of a gRPC API Configuration file.
+type rateLimitYAML struct {
+	RateLimits []struct {
+		Selector          string   `yaml:"selector"`
+		Key               string   `yaml:"key"`
+		TrustedProxies    []string `yaml:"trusted_proxies"`
+		RequestsPerSecond float64  `yaml:"requests_per_second"`
+		Burst             int      `yaml:"burst"`
+	} `yaml:"rate_limits"`
+}
+
+// LoadRateLimitPoliciesFromYAML reads the rate_limits section of a gRPC API
+// Configuration file, the same file that is given to protoc-gen-grpc-gateway
+// with grpc_api_configuration. The other sections are ignored:
+//
+//	type: google.api.Service
+//	config_version: 3
+//	http:
+//	  rules: ...
+//	rate_limits:
+//	- selector: example.EchoService.Echo
+//	  key: client_ip         # method (default), client_ip, header:<name> or metadata:<key>
+//	  trusted_proxies: [10.0.0.0/8]
+//	  requests_per_second: 10
+//	  burst: 20
+//
+// A burst of zero defaults to the number of requests per second, rounded up.
+func LoadRateLimitPoliciesFromYAML(data []byte) ([]RateLimitPolicy, error) {
+	var config rateLimitYAML
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limits from YAML: %w", err)
+	}
+
+	policies := make([]RateLimitPolicy, 0, len(config.RateLimits))
+	for i, rl := range config.RateLimits {
+		if rl.Selector == "" {
+			return nil, fmt.Errorf("rate limit %d: missing selector", i)
+		}
+		if rl.RequestsPerSecond < 0 || rl.Burst < 0 {
+			return nil, fmt.Errorf("rate limit %d: negative limit", i)
+		}
+		p := RateLimitPolicy{
+			Selector: strings.TrimPrefix(rl.Selector, "."),
+			Limit:    RateLimit{Rate: rl.RequestsPerSecond, Burst: rl.Burst},
+		}
+		if p.Limit.Burst == 0 {
+			p.Limit.Burst = int(math.Ceil(rl.RequestsPerSecond))
+		}
+
+		var proxies []netip.Prefix
+		for _, cidr := range rl.TrustedProxies {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("rate limit %d: %w", i, err)
+			}
+			proxies = append(proxies, prefix)
+		}
+
+		kind, arg, _ := strings.Cut(rl.Key, ":")
+		switch kind {
+		case "", "method":
+			p.Key = RateLimitByMethod()
+		case "client_ip":
+			p.Key = RateLimitByClientIP(proxies...)
+		case "header":
+			p.Key = RateLimitByHeader(arg)
+		case "metadata":
+			p.Key = RateLimitByMetadata(arg)
+		default:
+			return nil, fmt.Errorf("rate limit %d: unknown key %s", i, strconv.Quote(rl.Key))
+		}
+		if (kind == "header" || kind == "metadata") && arg == "" {
+			return nil, fmt.Errorf("rate limit %d: key %s needs a name", i, strconv.Quote(rl.Key))
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
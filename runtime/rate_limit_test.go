@@ -0,0 +1,170 @@
+package runtime_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithRateLimit(runtime.NewMemoryRateLimiter(),
+		runtime.RateLimitPolicy{
+			Selector: "example.Echo.*",
+			Key:      runtime.RateLimitByHeader("X-API-Key"),
+			Limit:    runtime.RateLimit{Rate: 0.001, Burst: 2},
+		},
+		runtime.RateLimitPolicy{
+			Selector: "example.Echo.Tenant",
+			Key:      runtime.RateLimitByMetadata("tenant"),
+			Limit:    runtime.RateLimit{Rate: 0.001, Burst: 1},
+		},
+	))
+	for _, method := range []string{"Echo", "Tenant"} {
+		handleGenerated(t, mux, http.MethodGet, "/v1/"+strings.ToLower(method), "/example.Echo/"+method, replyWith(func() (proto.Message, error) {
+			return wrapperspb.String("hello"), nil
+		}), nil)
+	}
+
+	serve := func(path string, header ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		for i := 0; i < len(header); i += 2 {
+			r.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		w := serve("/v1/echo", "X-API-Key", "key-1")
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d; want %d", i, w.Code, http.StatusOK)
+		}
+		if got := w.Header().Get("RateLimit-Remaining"); got != wantRemaining {
+			t.Errorf("request %d: RateLimit-Remaining = %q; want %q", i, got, wantRemaining)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "2" {
+			t.Errorf("request %d: RateLimit-Limit = %q; want %q", i, got, "2")
+		}
+	}
+
+	w := serve("/v1/echo", "X-API-Key", "key-1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d; want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got == "" || got == "0" {
+		t.Errorf("Retry-After = %q; want a positive delay", got)
+	}
+	if !strings.Contains(w.Body.String(), `"code":8`) {
+		t.Errorf("body = %s; want a ResourceExhausted error", w.Body.String())
+	}
+
+	if w := serve("/v1/echo", "X-API-Key", "key-2"); w.Code != http.StatusOK {
+		t.Errorf("another API key: status = %d; want %d", w.Code, http.StatusOK)
+	}
+	if w := serve("/v1/echo"); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("no API key: status = %d, headers = %v; want an unlimited request", w.Code, w.Header())
+	}
+
+	if w := serve("/v1/tenant", "Grpc-Metadata-Tenant", "acme"); w.Code != http.StatusOK {
+		t.Errorf("first tenant request: status = %d; want %d", w.Code, http.StatusOK)
+	}
+	if w := serve("/v1/tenant", "Grpc-Metadata-Tenant", "acme"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("second tenant request: status = %d; want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestMemoryRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	limiter := runtime.NewMemoryRateLimiter()
+	limit := runtime.RateLimit{Rate: 100, Burst: 1}
+
+	res, err := limiter.Allow(ctx, "key", limit)
+	if err != nil || !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("limiter.Allow() = %+v, %v; want allowed with no remaining token", res, err)
+	}
+	res, err = limiter.Allow(ctx, "key", limit)
+	if err != nil || res.Allowed || res.RetryAfter <= 0 || res.RetryAfter > 10*time.Millisecond {
+		t.Fatalf("limiter.Allow() = %+v, %v; want rejected for at most 10ms", res, err)
+	}
+	if res, _ := limiter.Allow(ctx, "other", limit); !res.Allowed {
+		t.Errorf("limiter.Allow() of another key = %+v; want allowed", res)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if res, _ := limiter.Allow(ctx, "key", limit); !res.Allowed {
+		t.Errorf("limiter.Allow() after refill = %+v; want allowed", res)
+	}
+}
+
+func TestLoadRateLimitPoliciesFromYAML(t *testing.T) {
+	policies, err := runtime.LoadRateLimitPoliciesFromYAML([]byte(`
+type: google.api.Service
+config_version: 3
+http:
+  rules:
+  - selector: example.Echo.Echo
+    get: /v1/echo
+rate_limits:
+- selector: example.Echo.Echo
+  requests_per_second: 2.5
+- selector: example.Echo.*
+  key: client_ip
+  trusted_proxies: [10.0.0.0/8]
+  requests_per_second: 1
+  burst: 5
+- selector: "*"
+  key: header:X-API-Key
+  requests_per_second: 10
+`))
+	if err != nil {
+		t.Fatalf("runtime.LoadRateLimitPoliciesFromYAML() failed with %v", err)
+	}
+	if len(policies) != 3 {
+		t.Fatalf("got %d policies; want 3", len(policies))
+	}
+	for i, want := range []struct {
+		selector string
+		limit    runtime.RateLimit
+	}{
+		{"example.Echo.Echo", runtime.RateLimit{Rate: 2.5, Burst: 3}},
+		{"example.Echo.*", runtime.RateLimit{Rate: 1, Burst: 5}},
+		{"*", runtime.RateLimit{Rate: 10, Burst: 10}},
+	} {
+		if policies[i].Selector != want.selector || policies[i].Limit != want.limit || policies[i].Key == nil {
+			t.Errorf("policy %d = %+v; want selector %q and limit %+v", i, policies[i], want.selector, want.limit)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/echo", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Forwarded-For", "203.0.113.7")
+	r.Header.Set("X-API-Key", "key")
+	if key, ok := policies[1].Key(context.Background(), r, metadata.MD{}); !ok || key != "203.0.113.7" {
+		t.Errorf("client IP key = %q, %v; want %q", key, ok, "203.0.113.7")
+	}
+	if key, ok := policies[2].Key(context.Background(), r, metadata.MD{}); !ok || key != "key" {
+		t.Errorf("header key = %q, %v; want %q", key, ok, "key")
+	}
+
+	for _, spec := range []string{
+		"rate_limits:\n- requests_per_second: 1\n",
+		"rate_limits:\n- selector: a.B.C\n  key: cookie\n",
+		"rate_limits:\n- selector: a.B.C\n  key: 'header:'\n",
+		"rate_limits:\n- selector: a.B.C\n  requests_per_second: -1\n",
+		"rate_limits:\n- selector: a.B.C\n  trusted_proxies: [nope]\n",
+		"rate_limits: [",
+	} {
+		if _, err := runtime.LoadRateLimitPoliciesFromYAML([]byte(spec)); err == nil {
+			t.Errorf("runtime.LoadRateLimitPoliciesFromYAML(%q) succeeded; want an error", spec)
+		}
+	}
+}
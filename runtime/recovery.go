@@ -0,0 +1,204 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryHandlerFunc is called with the value and the stack trace of the
+// panics recovered by WithRecovery.
+type RecoveryHandlerFunc func(ctx context.Context, r *http.Request, p interface{}, stack []byte)
+
+// DefaultRecoveryHandler is the RecoveryHandlerFunc logging panics with grpclog.
+func DefaultRecoveryHandler(_ context.Context, r *http.Request, p interface{}, stack []byte) {
+	grpclog.Errorf("Recovered from a panic while handling %s %s: %v\n%s", r.Method, r.URL.Path, p, stack)
+}
+
+// WithRecovery returns a ServeMuxOption recovering from the panics of the
+// handlers of the ServeMux, including their middlewares, the marshalers, the
+// forward response options and rewriter, the loops forwarding streams, and
+// the requests served by WithGRPCWeb.
+//
+// The panic value and stack trace are given to handler, or to
+// DefaultRecoveryHandler if it is nil. The request then fails with an
+// Internal error, written by the configured error handler, or as an error
+// chunk if the response headers were already written. Panics with
+// http.ErrAbortHandler are not recovered.
+func WithRecovery(handler RecoveryHandlerFunc) ServeMuxOption {
+	if handler == nil {
+		handler = DefaultRecoveryHandler
+	}
+	return func(serveMux *ServeMux) {
+		serveMux.recoveryHandler = handler
+	}
+}
+
+// errRecovered is the error of the requests whose handler panicked.
+var errRecovered = status.Error(codes.Internal, "internal error")
+
+// serveHandler calls the handler h, or serves its response from the cache if
+// WithResponseCache is set, or shares its call with identical requests if
+// WithRequestCoalescing is set, or replays the response to the request with
+// the same idempotency key if WithIdempotencyKeys is set.
+func (s *ServeMux) serveHandler(h handler, w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	next := func(w http.ResponseWriter, r *http.Request) {
+		s.callHandler(h, w, r, pathParams)
+	}
+	if s.coalescing != nil && r.Method == http.MethodGet {
+		call := next
+		next = func(w http.ResponseWriter, r *http.Request) {
+			s.coalescing.serve(s, h, w, r, pathParams, call)
+		}
+	}
+	if s.idempotency != nil && s.idempotency.applies(r) {
+		s.idempotency.serve(s, h, w, r, pathParams, next)
+		return
+	}
+	if s.responseCache != nil && r.Method == http.MethodGet {
+		s.responseCache.serve(h, w, r, pathParams, next)
+		return
+	}
+	next(w, r)
+}
+
+// callHandler calls the handler h, recovering from its panics if WithRecovery is set.
+func (s *ServeMux) callHandler(h handler, w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	if s.recoveryHandler == nil {
+		h.h(w, r, pathParams)
+		return
+	}
+	rw := &recoveryResponseWriter{ResponseWriter: w}
+	r = r.WithContext(context.WithValue(r.Context(), recoveryStateKey{}, &recoveryState{}))
+	defer s.recoverHandler(rw, r)
+	h.h(rw, r, pathParams)
+}
+
+// recoveryState records the panics recovered by the decoders of a request.
+type recoveryState struct {
+	decoderPanicked atomic.Bool
+}
+
+type recoveryStateKey struct{}
+
+// recoveredError returns errRecovered if a decoder of the request of ctx
+// panicked, and err otherwise. The handlers report decoding errors as invalid
+// arguments, while a panic is an internal error.
+func recoveredError(ctx context.Context, err error) error {
+	if ctx == nil {
+		return err
+	}
+	if state, ok := ctx.Value(recoveryStateKey{}).(*recoveryState); ok && state.decoderPanicked.Load() {
+		return errRecovered
+	}
+	return err
+}
+
+func (s *ServeMux) recoverHandler(w *recoveryResponseWriter, r *http.Request) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	if p == http.ErrAbortHandler {
+		panic(p)
+	}
+	s.recoveryHandler(r.Context(), r, p, debug.Stack())
+	s.writeRecoveredError(w, r)
+}
+
+// writeRecoveredError writes the error of a request whose handler panicked.
+func (s *ServeMux) writeRecoveredError(w *recoveryResponseWriter, r *http.Request) {
+	defer func() {
+		// The error handler or the marshaler may panic again.
+		if p := recover(); p != nil {
+			grpclog.Errorf("Failed to write the error of a recovered panic: %v", p)
+			if !w.wroteHeader {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	}()
+
+	ctx := r.Context()
+	_, outboundMarshaler := MarshalerForRequest(s, r)
+	if !w.wroteHeader {
+		HTTPError(ctx, s, outboundMarshaler, w, r, errRecovered)
+		return
+	}
+	delimiter := []byte("\n")
+	if d, ok := outboundMarshaler.(Delimited); ok {
+		delimiter = d.Delimiter()
+	}
+	handleForwardResponseStreamError(ctx, true, outboundMarshaler, w, r, s, errRecovered, delimiter)
+}
+
+// recoveryResponseWriter records whether the response headers were written.
+type recoveryResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *recoveryResponseWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recoveryResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// FlushError flushes the underlying ResponseWriter, so that streaming works
+// through http.ResponseController.
+func (w *recoveryResponseWriter) FlushError() error {
+	w.wroteHeader = true
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Flush implements http.Flusher.
+func (w *recoveryResponseWriter) Flush() {
+	_ = w.FlushError()
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (w *recoveryResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// recoveryMarshaler wraps the inbound marshaler of a request to recover from
+// the panics of its decoders, which may run in the goroutines sending client
+// streams rather than in the handler.
+type recoveryMarshaler struct {
+	Marshaler
+	mux *ServeMux
+	req *http.Request
+}
+
+func (m *recoveryMarshaler) NewDecoder(r io.Reader) Decoder {
+	dec := m.Marshaler.NewDecoder(r)
+	return DecoderFunc(func(v interface{}) (err error) {
+		defer m.recoverDecode(&err)
+		return dec.Decode(v)
+	})
+}
+
+func (m *recoveryMarshaler) Unmarshal(data []byte, v interface{}) (err error) {
+	defer m.recoverDecode(&err)
+	return m.Marshaler.Unmarshal(data, v)
+}
+
+func (m *recoveryMarshaler) recoverDecode(err *error) {
+	if p := recover(); p != nil {
+		ctx := m.req.Context()
+		m.mux.recoveryHandler(ctx, m.req, p, debug.Stack())
+		if state, ok := ctx.Value(recoveryStateKey{}).(*recoveryState); ok {
+			state.decoderPanicked.Store(true)
+		}
+		*err = errRecovered
+	}
+}
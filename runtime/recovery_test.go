@@ -0,0 +1,148 @@
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type recoveredPanic struct {
+	value interface{}
+	stack string
+}
+
+func newRecoveryMux(t *testing.T, opts ...runtime.ServeMuxOption) (*runtime.ServeMux, *[]recoveredPanic) {
+	t.Helper()
+	var recovered []recoveredPanic
+	opts = append(opts, runtime.WithRecovery(func(_ context.Context, _ *http.Request, p interface{}, stack []byte) {
+		recovered = append(recovered, recoveredPanic{value: p, stack: string(stack)})
+	}))
+	return runtime.NewServeMux(opts...), &recovered
+}
+
+func checkRecovered(t *testing.T, recovered []recoveredPanic, want interface{}) {
+	t.Helper()
+	if len(recovered) != 1 {
+		t.Fatalf("recovered %d panics; want 1", len(recovered))
+	}
+	if recovered[0].value != want {
+		t.Errorf("recovered panic value = %v; want %v", recovered[0].value, want)
+	}
+	if !strings.Contains(recovered[0].stack, "recovery_test.go") {
+		t.Errorf("recovered stack does not contain the panic site:\n%s", recovered[0].stack)
+	}
+}
+
+func TestWithRecoveryMiddleware(t *testing.T) {
+	mux, recovered := newRecoveryMux(t, runtime.WithMiddlewares(func(next runtime.HandlerFunc) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			panic("middleware failure")
+		}
+	}))
+	handleGenerated(t, mux, http.MethodGet, "/v1/echo/{id}", "/example.Echo/Echo", replyWith(func() (proto.Message, error) {
+		return wrapperspb.String("hello"), nil
+	}), nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/echo/1", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d; want %d", w.Code, http.StatusInternalServerError)
+	}
+	var body struct{ Code codes.Code }
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed with %v", w.Body.String(), err)
+	}
+	if body.Code != codes.Internal {
+		t.Errorf("code = %v; want %v", body.Code, codes.Internal)
+	}
+	checkRecovered(t, *recovered, "middleware failure")
+}
+
+func TestWithRecoveryStream(t *testing.T) {
+	mux, recovered := newRecoveryMux(t)
+	err := mux.HandlePath(http.MethodGet, "/v1/stream", func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		ctx := runtime.NewServerMetadataContext(req.Context(), runtime.ServerMetadata{})
+		sent := false
+		runtime.ForwardResponseStream(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) {
+			if sent {
+				panic("stream failure")
+			}
+			sent = true
+			return wrapperspb.String("first"), nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath(...) failed with %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/stream", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d; want %d", w.Code, http.StatusOK)
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d chunks; want 2: %s", len(lines), w.Body.String())
+	}
+	var chunk struct{ Error struct{ Code codes.Code } }
+	if err := json.Unmarshal([]byte(lines[1]), &chunk); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed with %v", lines[1], err)
+	}
+	if chunk.Error.Code != codes.Internal {
+		t.Errorf("error chunk code = %v; want %v", chunk.Error.Code, codes.Internal)
+	}
+	checkRecovered(t, *recovered, "stream failure")
+}
+
+type panickingDecoderMarshaler struct {
+	runtime.JSONPb
+}
+
+func (*panickingDecoderMarshaler) NewDecoder(io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(interface{}) error {
+		panic("decoder failure")
+	})
+}
+
+func TestWithRecoveryDecoder(t *testing.T) {
+	mux, recovered := newRecoveryMux(t, runtime.WithMarshalerOption(runtime.MIMEWildcard, &panickingDecoderMarshaler{}))
+	handleGenerated(t, mux, http.MethodPost, "/v1/echo/{id}", "/example.Echo/Echo", replyWith(func() (proto.Message, error) {
+		return wrapperspb.String("hello"), nil
+	}), nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/echo/1", strings.NewReader(`"hi"`)))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d; want %d", w.Code, http.StatusInternalServerError)
+	}
+	checkRecovered(t, *recovered, "decoder failure")
+}
+
+func TestWithRecoveryAbortHandler(t *testing.T) {
+	mux, recovered := newRecoveryMux(t)
+	if err := mux.HandlePath(http.MethodGet, "/v1/abort", func(http.ResponseWriter, *http.Request, map[string]string) {
+		panic(http.ErrAbortHandler)
+	}); err != nil {
+		t.Fatalf("mux.HandlePath(...) failed with %v", err)
+	}
+
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler {
+			t.Errorf("panic = %v; want %v", p, http.ErrAbortHandler)
+		}
+		if len(*recovered) != 0 {
+			t.Errorf("recovered %d panics; want 0", len(*recovered))
+		}
+	}()
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/abort", nil))
+}
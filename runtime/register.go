@@ -0,0 +1,70 @@
+package runtime
+
+import (
+	"strings"
+)
+
+// RegisterOptions selects the methods whose handlers are registered by the
+// generated Register{Service}HandlerWithOptions and
+// Register{Service}HandlerClientWithOptions functions. The zero value selects
+// every method, like the Register{Service}Handler functions.
+type RegisterOptions struct {
+	// Methods are selectors of the methods to register: full method names,
+	// like "package.Service.Method", names ending with ".*" selecting the
+	// methods of a service or package, or "*". Every method is selected if
+	// empty.
+	Methods []string
+	// VisibilitySelectors are the google.api.VisibilityRule restriction labels
+	// of the methods to register, like the visibility_restriction_selectors
+	// option of protoc-gen-openapiv2. If set, methods without visibility
+	// restriction are always registered; methods with one, or whose service
+	// has one, are only registered if one of its labels is selected. Methods
+	// are not filtered by visibility if empty.
+	VisibilitySelectors []string
+}
+
+// Includes reports whether the method with the full name fullMethod, like
+// "package.Service.Method", and the visibility restrictions restrictions, comma
+// separated lists of labels, is selected by o. The method is only selected if
+// it is visible to every restriction, like the restriction of its service,
+// from google.api.api_visibility, and its own, from
+// google.api.method_visibility.
+func (o RegisterOptions) Includes(fullMethod string, restrictions ...string) bool {
+	if len(o.Methods) > 0 {
+		selected := false
+		for _, selector := range o.Methods {
+			if selectorMatches(selector, fullMethod) {
+				selected = true
+				break
+			}
+		}
+		if !selected {
+			return false
+		}
+	}
+	if len(o.VisibilitySelectors) == 0 {
+		return true
+	}
+	for _, restriction := range restrictions {
+		if !visibleTo(restriction, o.VisibilitySelectors) {
+			return false
+		}
+	}
+	return true
+}
+
+// visibleTo reports whether an element with the visibility restriction
+// restriction is visible to the given labels.
+func visibleTo(restriction string, labels []string) bool {
+	if strings.TrimSpace(restriction) == "" {
+		return true
+	}
+	for _, r := range strings.Split(restriction, ",") {
+		for _, label := range labels {
+			if strings.TrimSpace(r) == strings.TrimSpace(label) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestRegisterOptionsIncludes(t *testing.T) {
+	for _, spec := range []struct {
+		name               string
+		opts               runtime.RegisterOptions
+		method             string
+		serviceRestriction string
+		restriction        string
+		want               bool
+	}{
+		{
+			name:   "zero value",
+			method: "example.EchoService.Echo",
+			want:   true,
+		},
+		{
+			name:        "zero value with restriction",
+			method:      "example.EchoService.Echo",
+			restriction: "INTERNAL",
+			want:        true,
+		},
+		{
+			name:   "method",
+			opts:   runtime.RegisterOptions{Methods: []string{"example.EchoService.Other", "example.EchoService.Echo"}},
+			method: "example.EchoService.Echo",
+			want:   true,
+		},
+		{
+			name:   "other method",
+			opts:   runtime.RegisterOptions{Methods: []string{"example.EchoService.Other"}},
+			method: "example.EchoService.Echo",
+			want:   false,
+		},
+		{
+			name:   "service",
+			opts:   runtime.RegisterOptions{Methods: []string{"example.EchoService.*"}},
+			method: "example.EchoService.Echo",
+			want:   true,
+		},
+		{
+			name:   "other service",
+			opts:   runtime.RegisterOptions{Methods: []string{"example.Echo.*"}},
+			method: "example.EchoService.Echo",
+			want:   false,
+		},
+		{
+			name:        "visibility label",
+			opts:        runtime.RegisterOptions{VisibilitySelectors: []string{"PREVIEW"}},
+			method:      "example.EchoService.Echo",
+			restriction: "INTERNAL, PREVIEW",
+			want:        true,
+		},
+		{
+			name:        "other visibility label",
+			opts:        runtime.RegisterOptions{VisibilitySelectors: []string{"PREVIEW"}},
+			method:      "example.EchoService.Echo",
+			restriction: "INTERNAL",
+			want:        false,
+		},
+		{
+			name:        "method and visibility label",
+			opts:        runtime.RegisterOptions{Methods: []string{"*"}, VisibilitySelectors: []string{"INTERNAL"}},
+			method:      "example.EchoService.Echo",
+			restriction: "INTERNAL",
+			want:        true,
+		},
+		{
+			name:        "visibility label of other method",
+			opts:        runtime.RegisterOptions{Methods: []string{"example.EchoService.Other"}, VisibilitySelectors: []string{"INTERNAL"}},
+			method:      "example.EchoService.Echo",
+			restriction: "INTERNAL",
+			want:        false,
+		},
+		{
+			name:               "service visibility label",
+			opts:               runtime.RegisterOptions{VisibilitySelectors: []string{"INTERNAL"}},
+			method:             "example.EchoService.Echo",
+			serviceRestriction: "INTERNAL",
+			want:               true,
+		},
+		{
+			name:               "zero value with service restriction",
+			method:             "example.EchoService.Echo",
+			serviceRestriction: "INTERNAL",
+			want:               true,
+		},
+		{
+			name:               "hidden service",
+			opts:               runtime.RegisterOptions{VisibilitySelectors: []string{"PREVIEW"}},
+			method:             "example.EchoService.Echo",
+			serviceRestriction: "INTERNAL",
+			want:               false,
+		},
+		{
+			name:               "visible service and hidden method",
+			opts:               runtime.RegisterOptions{VisibilitySelectors: []string{"INTERNAL"}},
+			method:             "example.EchoService.Echo",
+			serviceRestriction: "INTERNAL",
+			restriction:        "PREVIEW",
+			want:               false,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			if got := spec.opts.Includes(spec.method, spec.serviceRestriction, spec.restriction); got != spec.want {
+				t.Errorf("%#v.Includes(%q, %q, %q) = %t; want %t", spec.opts, spec.method, spec.serviceRestriction, spec.restriction, got, spec.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package runtime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultRequestIDHeader is the header carrying request IDs unless
+	// WithRequestIDHeader is given to WithRequestID.
+	DefaultRequestIDHeader = "X-Request-ID"
+
+	// maxRequestIDLength is the maximum length of the request IDs accepted
+	// from clients. Longer IDs are replaced by generated ones.
+	maxRequestIDLength = 128
+)
+
+// RequestIDGenerator returns a new, unique request ID.
+type RequestIDGenerator func() string
+
+// RequestIDOption is an option that can be given to WithRequestID.
+type RequestIDOption func(*requestIDConfig)
+
+type requestIDConfig struct {
+	header    string
+	generator RequestIDGenerator
+}
+
+// WithRequestIDHeader sets the header from which request IDs are read and on
+// which they are echoed. The gRPC metadata key is the lowercased header name.
+// The default is DefaultRequestIDHeader.
+func WithRequestIDHeader(header string) RequestIDOption {
+	return func(c *requestIDConfig) {
+		c.header = textproto.CanonicalMIMEHeaderKey(header)
+	}
+}
+
+// WithRequestIDGenerator sets the generator of the IDs of the requests which
+// do not carry one. The default is GenerateUUIDv7.
+func WithRequestIDGenerator(generator RequestIDGenerator) RequestIDOption {
+	return func(c *requestIDConfig) {
+		c.generator = generator
+	}
+}
+
+// WithRequestID returns a ServeMuxOption that gives an ID to every request.
+//
+// The ID is read from the request header, or generated if the header is
+// missing or is not a printable ASCII string of at most 128 characters. It is
+// then:
+//   - available to handlers and middlewares through RequestIDFromContext,
+//   - echoed on the HTTP response header,
+//   - sent to the gRPC server in the outgoing metadata,
+//   - attached as a google.rpc.RequestInfo detail to the errors written by
+//     DefaultHTTPErrorHandler and to stream error chunks.
+func WithRequestID(opts ...RequestIDOption) ServeMuxOption {
+	c := &requestIDConfig{
+		header:    DefaultRequestIDHeader,
+		generator: GenerateUUIDv7,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return func(serveMux *ServeMux) {
+		serveMux.requestID = c
+	}
+}
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the ID given to the request by WithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// annotate returns ctx with the ID of r, and echoes it on w.
+func (c *requestIDConfig) annotate(ctx context.Context, w http.ResponseWriter, r *http.Request) context.Context {
+	id := r.Header.Get(c.header)
+	if !isValidRequestID(id) {
+		id = c.generator()
+	}
+	w.Header().Set(c.header, id)
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// metadataKey returns the outgoing metadata key of the request IDs.
+func (c *requestIDConfig) metadataKey() string {
+	return strings.ToLower(c.header)
+}
+
+func isValidRequestID(id string) bool {
+	return id != "" && len(id) <= maxRequestIDLength && isValidGRPCMetadataTextValue(id)
+}
+
+// withRequestInfo returns st with a RequestInfo detail carrying the request
+// ID of ctx, unless it already has one.
+func withRequestInfo(ctx context.Context, st *status.Status) *status.Status {
+	id, ok := RequestIDFromContext(ctx)
+	if !ok {
+		return st
+	}
+	for _, d := range st.Details() {
+		if _, ok := d.(*errdetails.RequestInfo); ok {
+			return st
+		}
+	}
+	withID, err := st.WithDetails(&errdetails.RequestInfo{RequestId: id})
+	if err != nil {
+		return st
+	}
+	return withID
+}
+
+// GenerateUUIDv7 is a RequestIDGenerator returning RFC 9562 version 7 UUIDs,
+// which sort by creation time.
+func GenerateUUIDv7() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	_, _ = rand.Read(b[6:])
+	b[6] = b[6]&0x0f | 0x70 // version 7
+	b[8] = b[8]&0x3f | 0x80 // RFC 9562 variant
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+	return string(buf[:])
+}
+
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// GenerateULID is a RequestIDGenerator returning ULIDs, which sort by creation time.
+func GenerateULID() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	_, _ = rand.Read(b[6:])
+
+	// Encode the 128 bits as 26 base32 digits, from the least significant.
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var buf [26]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockfordBase32[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
@@ -0,0 +1,152 @@
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	uuidv7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulidPattern   = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+)
+
+func TestWithRequestID(t *testing.T) {
+	var gotID, gotMD string
+	mux := runtime.NewServeMux(runtime.WithRequestID())
+	if err := mux.HandlePath(http.MethodGet, "/v1/echo", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		gotID, _ = runtime.RequestIDFromContext(r.Context())
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/example.Echo/Echo")
+		if err != nil {
+			t.Fatalf("runtime.AnnotateContext() failed with %v", err)
+		}
+		md, _ := metadata.FromOutgoingContext(ctx)
+		gotMD = strings.Join(md.Get("x-request-id"), ",")
+	}); err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v", err)
+	}
+
+	for _, spec := range []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "generated"},
+		{name: "forwarded", incoming: "abc-123", keep: true},
+		{name: "too long", incoming: strings.Repeat("a", 129)},
+		{name: "not printable", incoming: "abc\x01"},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/v1/echo", nil)
+			if spec.incoming != "" {
+				r.Header.Set("X-Request-ID", spec.incoming)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+
+			echoed := w.Header().Get("X-Request-ID")
+			if echoed != gotID || gotMD != gotID {
+				t.Errorf("response header = %q, context = %q, metadata = %q; want the same ID", echoed, gotID, gotMD)
+			}
+			if spec.keep && gotID != spec.incoming {
+				t.Errorf("request ID = %q; want %q", gotID, spec.incoming)
+			}
+			if !spec.keep && !uuidv7Pattern.MatchString(gotID) {
+				t.Errorf("request ID = %q; want a generated UUIDv7", gotID)
+			}
+		})
+	}
+}
+
+func TestWithRequestIDOptions(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithRequestID(
+		runtime.WithRequestIDHeader("x-correlation-id"),
+		runtime.WithRequestIDGenerator(func() string { return "generated" }),
+	))
+	var gotID string
+	if err := mux.HandlePath(http.MethodGet, "/v1/echo", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		gotID, _ = runtime.RequestIDFromContext(r.Context())
+	}); err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/echo", nil))
+	if gotID != "generated" || w.Header().Get("X-Correlation-Id") != "generated" {
+		t.Errorf("request ID = %q, header = %q; want %q", gotID, w.Header().Get("X-Correlation-Id"), "generated")
+	}
+}
+
+func TestRequestIDInErrors(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithRequestID())
+	handleGenerated(t, mux, http.MethodGet, "/v1/echo", "/example.Echo/Echo", replyWith(func() (proto.Message, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}), nil)
+	handleGenerated(t, mux, http.MethodGet, "/v1/stream", "/example.Echo/Stream", replyWith(func() (proto.Message, error) {
+		return nil, nil
+	}), []proto.Message{wrapperspb.String("a"), nil})
+
+	for _, path := range []string{"/v1/echo", "/v1/stream", "/unknown"} {
+		t.Run(path, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, path, nil)
+			r.Header.Set("X-Request-ID", "req-1")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+
+			lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+			var body struct {
+				Error   json.RawMessage `json:"error"`
+				Details []struct {
+					Type      string `json:"@type"`
+					RequestID string `json:"requestId"`
+				} `json:"details"`
+			}
+			last := lines[len(lines)-1]
+			if err := json.Unmarshal([]byte(last), &body); err != nil {
+				t.Fatalf("json.Unmarshal(%q) failed with %v", last, err)
+			}
+			if body.Error != nil {
+				if err := json.Unmarshal(body.Error, &body); err != nil {
+					t.Fatalf("json.Unmarshal(%q) failed with %v", body.Error, err)
+				}
+			}
+			if len(body.Details) != 1 || body.Details[0].Type != "type.googleapis.com/google.rpc.RequestInfo" || body.Details[0].RequestID != "req-1" {
+				t.Errorf("error %s does not carry the request ID", last)
+			}
+		})
+	}
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	if id, ok := runtime.RequestIDFromContext(context.Background()); ok {
+		t.Errorf("runtime.RequestIDFromContext() = %q; want none", id)
+	}
+}
+
+func TestRequestIDGenerators(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		id := runtime.GenerateUUIDv7()
+		if !uuidv7Pattern.MatchString(id) || seen[id] {
+			t.Fatalf("runtime.GenerateUUIDv7() = %q; want a new UUIDv7", id)
+		}
+		seen[id] = true
+
+		id = runtime.GenerateULID()
+		if !ulidPattern.MatchString(id) || seen[id] {
+			t.Fatalf("runtime.GenerateULID() = %q; want a new ULID", id)
+		}
+		seen[id] = true
+	}
+}
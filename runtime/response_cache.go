@@ -0,0 +1,489 @@
+package runtime
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+// CachedResponse is a response stored in a ResponseCache.
+type CachedResponse struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Header holds the headers of the response.
+	Header http.Header
+	// Body is the body of the response.
+	Body []byte
+	// StoredAt is the time at which the response was stored.
+	StoredAt time.Time
+}
+
+// ResponseCache stores the responses of the routes of a ServeMux. It may be
+// backed by a store shared by several gateways.
+type ResponseCache interface {
+	// Get returns the response stored for key, or nil if there is none or it
+	// has expired.
+	Get(ctx context.Context, key string) (*CachedResponse, error)
+	// Set stores resp for key, for ttl.
+	Set(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error
+}
+
+// CacheStatus describes how the response cache handled a request.
+type CacheStatus string
+
+const (
+	// CacheHit is the status of a request served from the cache.
+	CacheHit CacheStatus = "hit"
+	// CacheMiss is the status of a request which was not found in the cache,
+	// or whose client asked for a fresh response with Cache-Control: no-cache.
+	CacheMiss CacheStatus = "miss"
+	// CacheBypass is the status of a request which could not use the cache,
+	// like requests with Cache-Control: no-store or with credentials which
+	// are not part of the cache key.
+	CacheBypass CacheStatus = "bypass"
+)
+
+// ResponseCachePolicy configures the caching of the responses of some gRPC
+// methods.
+type ResponseCachePolicy struct {
+	// Selector is the full name of a gRPC method, like "package.Service.Method".
+	// It may end with ".*" to select all the methods of a service or package,
+	// or be "*" to select every method.
+	Selector string
+	// TTL is the time responses are cached for when the gRPC server does not
+	// send cache-control metadata. Responses are only cached when the server
+	// does if it is zero, and never if it is negative.
+	TTL time.Duration
+	// Headers are the request headers, besides Accept and Content-Type, which
+	// change the response and are part of the cache key. Requests with an
+	// Authorization or Cookie header which is not listed are not cached.
+	Headers []string
+}
+
+// WithResponseCache returns a ServeMuxOption caching the responses of the
+// unary GET routes of the ServeMux in cache. The policy of the first
+// ResponseCachePolicy selecting the gRPC method of a route applies.
+//
+// Responses are cached for the max-age, or s-maxage, of the cache-control
+// header metadata sent by the gRPC server, or the TTL of the policy if the
+// server sends none. Servers can prevent caching with no-store, no-cache or
+// private. Only successful responses of requests which do not accept trailers
+// are cached.
+//
+// The cache key combines the route, the path parameters, the query and the
+// values of the Accept and Content-Type headers and of the headers of the
+// policy, which are listed in the Vary header of the responses. Clients can
+// skip the cache with Cache-Control: no-cache, in which case the fresh
+// response is stored, or no-store. Cached responses are served with an Age
+// header.
+//
+// Cache hits and misses are reported to the MetricsCollector in
+// RequestMetrics.Cache, and to the GatewayStatsHandlers with StatsCacheLookup
+// events. Cache hits do not call the handler of the route, so they are not
+// subject to the limits enforced by the handlers, like WithRateLimit.
+func WithResponseCache(cache ResponseCache, policies ...ResponseCachePolicy) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.responseCache = &responseCacheConfig{cache: cache, policies: policies}
+	}
+}
+
+type responseCacheConfig struct {
+	cache    ResponseCache
+	policies []ResponseCachePolicy
+}
+
+func (c *responseCacheConfig) policyFor(rpcMethodName string) ResponseCachePolicy {
+	if rpcMethodName == "" {
+		return ResponseCachePolicy{}
+	}
+	fullMethod := fullMethodName(rpcMethodName)
+	for _, p := range c.policies {
+		if selectorMatches(p.Selector, fullMethod) {
+			return p
+		}
+	}
+	return ResponseCachePolicy{}
+}
+
+// keyHeaders returns the canonical names of the request headers which are
+// part of the cache key of the policy.
+func (p ResponseCachePolicy) keyHeaders() []string {
+	headers := []string{acceptHeader, contentTypeHeader}
+	for _, h := range p.Headers {
+		h = textproto.CanonicalMIMEHeaderKey(h)
+		if !containsString(headers, h) {
+			headers = append(headers, h)
+		}
+	}
+	return headers
+}
+
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// requestCacheKey returns a key identifying the requests to the route h with
+// the same path parameters, query and values of headers. The key is hashed,
+// so that the credentials of the requests are not stored in caches.
+func requestCacheKey(h handler, r *http.Request, pathParams map[string]string, headers []string) string {
+	var b strings.Builder
+	b.WriteString(r.Method)
+	b.WriteByte(0)
+	b.WriteString(h.pat.String())
+	names := make([]string, 0, len(pathParams))
+	for name := range pathParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteByte(0)
+		b.WriteString(strconv.Quote(name))
+		b.WriteString(strconv.Quote(pathParams[name]))
+	}
+	b.WriteByte(0)
+	b.WriteString(r.URL.Query().Encode())
+	for _, header := range headers {
+		b.WriteByte(0)
+		b.WriteString(header)
+		for _, v := range r.Header.Values(header) {
+			b.WriteString(strconv.Quote(v))
+		}
+	}
+	if state := visibilityStateFromContext(r.Context()); state != nil {
+		b.WriteByte(0)
+		b.WriteString(state.key)
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheable reports whether the request may use the cache of the policy.
+func (p ResponseCachePolicy) cacheable(r *http.Request) bool {
+	if p.TTL < 0 || requestAcceptsTrailers(r) {
+		return false
+	}
+	headers := p.keyHeaders()
+	for _, credentials := range []string{"Authorization", "Cookie"} {
+		if r.Header.Get(credentials) != "" && !containsString(headers, credentials) {
+			return false
+		}
+	}
+	return true
+}
+
+// cacheControl holds the directives of Cache-Control headers which matter to
+// the response cache.
+type cacheControl struct {
+	noStore, noCache, private bool
+	maxAge, sMaxAge           time.Duration
+	hasMaxAge, hasSMaxAge     bool
+}
+
+func parseCacheControl(values []string) cacheControl {
+	var cc cacheControl
+	for _, value := range values {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			switch strings.ToLower(name) {
+			case "no-store":
+				cc.noStore = true
+			case "no-cache":
+				cc.noCache = true
+			case "private":
+				cc.private = true
+			case "max-age", "s-maxage":
+				seconds, err := strconv.Atoi(strings.Trim(arg, `"`))
+				if err != nil || seconds < 0 {
+					continue
+				}
+				if strings.ToLower(name) == "max-age" {
+					cc.maxAge, cc.hasMaxAge = time.Duration(seconds)*time.Second, true
+				} else {
+					cc.sMaxAge, cc.hasSMaxAge = time.Duration(seconds)*time.Second, true
+				}
+			}
+		}
+	}
+	return cc
+}
+
+// ttl returns the time a response with the directives of cc may be cached
+// for, or policyTTL if cc does not say.
+func (cc cacheControl) ttl(policyTTL time.Duration) time.Duration {
+	switch {
+	case cc.noStore || cc.noCache || cc.private:
+		return 0
+	case cc.hasSMaxAge:
+		return cc.sMaxAge
+	case cc.hasMaxAge:
+		return cc.maxAge
+	default:
+		return policyTTL
+	}
+}
+
+// responseCacheState records the unary response forwarded by the handler of
+// a request, which may be cached.
+type responseCacheState struct {
+	forwarded    bool
+	cacheControl cacheControl
+}
+
+type responseCacheStateKey struct{}
+
+func responseCacheStateFromContext(ctx context.Context) *responseCacheState {
+	if ctx == nil {
+		return nil
+	}
+	state, _ := ctx.Value(responseCacheStateKey{}).(*responseCacheState)
+	return state
+}
+
+// responseForwarded records that ForwardResponseMessage forwarded the unary
+// response of the call with server metadata md.
+func (s *responseCacheState) responseForwarded(md ServerMetadata) {
+	if s == nil {
+		return
+	}
+	s.forwarded = true
+	s.cacheControl = parseCacheControl(md.HeaderMD.Get("cache-control"))
+}
+
+// uncachedHeaders are the response headers which are specific to a request.
+var uncachedHeaders = []string{RetriesHeader, "Ratelimit-Limit", "Ratelimit-Remaining", "Ratelimit-Reset", "Retry-After", "Vary"}
+
+// serve serves the GET request r with the handler h, from the cache if
+// possible, and stores its response otherwise.
+func (c *responseCacheConfig) serve(h handler, w http.ResponseWriter, r *http.Request, pathParams map[string]string, next func(http.ResponseWriter, *http.Request)) {
+	ctx := r.Context()
+	rpcMethod := h.stats.method()
+	policy := c.policyFor(rpcMethod)
+	if policy.TTL < 0 {
+		next(w, r)
+		return
+	}
+	// The gRPC method of the route, and so its policy, may only be known once
+	// the handler has annotated the context, so Vary is set when the response
+	// is written.
+	cw := &cacheResponseWriter{ResponseWriter: w, writeHeader: func() {
+		if policy := c.policyFor(h.stats.method()); policy.TTL >= 0 {
+			w.Header().Add("Vary", strings.Join(policy.keyHeaders(), ", "))
+		}
+	}}
+
+	stats := requestStatsFromContext(ctx)
+	requestCC := parseCacheControl(r.Header.Values("Cache-Control"))
+	if requestCC.noStore || !policy.cacheable(r) {
+		stats.cacheLookup(ctx, CacheBypass)
+		cw.discard = true
+		next(cw, r)
+		return
+	}
+
+	key := requestCacheKey(h, r, pathParams, policy.keyHeaders())
+	if !requestCC.noCache && !(requestCC.hasMaxAge && requestCC.maxAge == 0) {
+		cached, err := c.cache.Get(ctx, key)
+		if err != nil {
+			grpclog.Errorf("Failed to get response from cache: %v", err)
+		}
+		if cached != nil {
+			stats.setRPCMethod(rpcMethod)
+			stats.cacheLookup(ctx, CacheHit)
+			writeCachedResponse(cw, cached)
+			return
+		}
+	}
+	stats.cacheLookup(ctx, CacheMiss)
+
+	before := make(map[string]bool, len(w.Header()))
+	for name := range w.Header() {
+		before[name] = true
+	}
+	state := &responseCacheState{}
+	next(cw, r.WithContext(context.WithValue(ctx, responseCacheStateKey{}, state)))
+	if !state.forwarded || cw.status != http.StatusOK || cw.discard {
+		return
+	}
+
+	if rpcMethod == "" {
+		// The policy of the route was not known before this request.
+		rpcMethod = h.stats.method()
+		policy = c.policyFor(rpcMethod)
+		if !policy.cacheable(r) {
+			return
+		}
+		key = requestCacheKey(h, r, pathParams, policy.keyHeaders())
+	}
+	ttl := state.cacheControl.ttl(policy.TTL)
+	if ttl <= 0 || w.Header().Get("Set-Cookie") != "" {
+		return
+	}
+	header := w.Header().Clone()
+	for name := range before {
+		header.Del(name)
+	}
+	for _, name := range uncachedHeaders {
+		header.Del(name)
+	}
+	resp := &CachedResponse{
+		StatusCode: cw.status,
+		Header:     header,
+		Body:       cw.body.Bytes(),
+		StoredAt:   time.Now(),
+	}
+	if err := c.cache.Set(ctx, key, resp, ttl); err != nil {
+		grpclog.Errorf("Failed to store response in cache: %v", err)
+	}
+}
+
+func writeCachedResponse(w http.ResponseWriter, cached *CachedResponse) {
+	for name, values := range cached.Header {
+		w.Header()[name] = append([]string(nil), values...)
+	}
+	w.Header().Set("Age", strconv.Itoa(int(time.Since(cached.StoredAt).Seconds())))
+	w.WriteHeader(cached.StatusCode)
+	if _, err := w.Write(cached.Body); err != nil {
+		grpclog.Errorf("Failed to write response: %v", err)
+	}
+}
+
+// cacheResponseWriter calls writeHeader before the header of the response is
+// written, and keeps a copy of the response unless it is discarded, like
+// streamed responses.
+type cacheResponseWriter struct {
+	http.ResponseWriter
+	writeHeader func()
+	status      int
+	body        bytes.Buffer
+	discard     bool
+}
+
+func (w *cacheResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+		w.writeHeader()
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.discard {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// FlushError flushes the underlying ResponseWriter. Only streams are flushed,
+// so the response is not cached.
+func (w *cacheResponseWriter) FlushError() error {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.discard = true
+	w.body = bytes.Buffer{}
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Flush implements http.Flusher.
+func (w *cacheResponseWriter) Flush() {
+	_ = w.FlushError()
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (w *cacheResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// MemoryResponseCache is a ResponseCache keeping the responses in memory. It
+// discards the least recently used responses once it holds its maximum number
+// of responses.
+type MemoryResponseCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type memoryCacheEntry struct {
+	key     string
+	resp    *CachedResponse
+	expires time.Time
+}
+
+// NewMemoryResponseCache returns a new MemoryResponseCache holding at most
+// maxEntries responses, or 1000 if maxEntries is not positive.
+func NewMemoryResponseCache(maxEntries int) *MemoryResponseCache {
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+	return &MemoryResponseCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Get implements ResponseCache.
+func (c *MemoryResponseCache) Get(_ context.Context, key string) (*CachedResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if !time.Now().Before(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, nil
+	}
+	c.lru.MoveToFront(elem)
+	return entry.resp, nil
+}
+
+// Set implements ResponseCache.
+func (c *MemoryResponseCache) Set(_ context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &memoryCacheEntry{key: key, resp: resp, expires: time.Now().Add(ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return nil
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of responses held by the cache, including the
+// expired ones which were not discarded yet.
+func (c *MemoryResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,184 @@
+package runtime_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestResponseCache(t *testing.T) {
+	collector := &recordingMetricsCollector{}
+	mux := runtime.NewServeMux(
+		runtime.WithResponseCache(runtime.NewMemoryResponseCache(100), runtime.ResponseCachePolicy{
+			Selector: "example.Books.*",
+			TTL:      time.Minute,
+			Headers:  []string{"x-tenant"},
+		}),
+		runtime.WithMetricsCollector(collector),
+	)
+	calls := 0
+	handleGenerated(t, mux, http.MethodGet, "/v1/books/{id}", "/example.Books/Get", func(_ context.Context, r *http.Request, _ string) (proto.Message, metadata.MD, error) {
+		calls++
+		if r.URL.Query().Get("fail") != "" {
+			return nil, nil, status.Error(codes.NotFound, "not found")
+		}
+		return wrapperspb.String("book " + strconv.Itoa(calls)), nil, nil
+	}, nil)
+
+	for _, spec := range []struct {
+		name       string
+		path       string
+		header     []string
+		wantCalls  int
+		wantStatus runtime.CacheStatus
+	}{
+		{name: "first request", path: "/v1/books/1", wantCalls: 1, wantStatus: runtime.CacheMiss},
+		{name: "cached", path: "/v1/books/1", wantCalls: 1, wantStatus: runtime.CacheHit},
+		{name: "other path parameter", path: "/v1/books/2", wantCalls: 2, wantStatus: runtime.CacheMiss},
+		{name: "query", path: "/v1/books/1?view=full", wantCalls: 3, wantStatus: runtime.CacheMiss},
+		{name: "cached query", path: "/v1/books/1?view=full", wantCalls: 3, wantStatus: runtime.CacheHit},
+		{name: "selected header", path: "/v1/books/1", header: []string{"X-Tenant", "acme"}, wantCalls: 4, wantStatus: runtime.CacheMiss},
+		{name: "cached selected header", path: "/v1/books/1", header: []string{"X-Tenant", "acme"}, wantCalls: 4, wantStatus: runtime.CacheHit},
+		{name: "other header", path: "/v1/books/1", header: []string{"X-Other", "1"}, wantCalls: 4, wantStatus: runtime.CacheHit},
+		{name: "accept", path: "/v1/books/1", header: []string{"Accept", "application/octet-stream"}, wantCalls: 5, wantStatus: runtime.CacheMiss},
+		{name: "no-cache", path: "/v1/books/1", header: []string{"Cache-Control", "no-cache"}, wantCalls: 6, wantStatus: runtime.CacheMiss},
+		{name: "no-store", path: "/v1/books/1", header: []string{"Cache-Control", "no-store"}, wantCalls: 7, wantStatus: runtime.CacheBypass},
+		{name: "authorization", path: "/v1/books/1", header: []string{"Authorization", "Bearer token"}, wantCalls: 8, wantStatus: runtime.CacheBypass},
+		{name: "error", path: "/v1/books/1?fail=1", wantCalls: 9, wantStatus: runtime.CacheMiss},
+		{name: "error is not cached", path: "/v1/books/1?fail=1", wantCalls: 10, wantStatus: runtime.CacheMiss},
+	} {
+		r := httptest.NewRequest(http.MethodGet, spec.path, nil)
+		for i := 0; i < len(spec.header); i += 2 {
+			r.Header.Set(spec.header[i], spec.header[i+1])
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		if calls != spec.wantCalls {
+			t.Errorf("%s: calls = %d; want %d", spec.name, calls, spec.wantCalls)
+		}
+		if got, want := w.Header().Get("Vary"), "Accept, Content-Type, X-Tenant"; got != want {
+			t.Errorf("%s: Vary = %q; want %q", spec.name, got, want)
+		}
+		if got := w.Header().Get("Age") != ""; got != (spec.wantStatus == runtime.CacheHit) {
+			t.Errorf("%s: Age = %q; want it only for cache hits", spec.name, w.Header().Get("Age"))
+		}
+		m := collector.metrics[len(collector.metrics)-1]
+		if m.Cache != spec.wantStatus {
+			t.Errorf("%s: RequestMetrics.Cache = %q; want %q", spec.name, m.Cache, spec.wantStatus)
+		}
+		if m.RPCMethod != "/example.Books/Get" {
+			t.Errorf("%s: RequestMetrics.RPCMethod = %q; want %q", spec.name, m.RPCMethod, "/example.Books/Get")
+		}
+	}
+
+	// The fresh response fetched with no-cache replaced the cached one.
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/books/1", nil))
+	if got, want := w.Body.String(), `"book 6"`; got != want {
+		t.Errorf("body = %s; want %s", got, want)
+	}
+}
+
+func TestResponseCacheServerCacheControl(t *testing.T) {
+	for _, spec := range []struct {
+		name         string
+		policies     []runtime.ResponseCachePolicy
+		cacheControl string
+		wantCached   bool
+	}{
+		{name: "max-age", cacheControl: "public, max-age=60", wantCached: true},
+		{name: "s-maxage", cacheControl: "max-age=60, s-maxage=0"},
+		{name: "no-store", cacheControl: "no-store"},
+		{name: "private", cacheControl: "private, max-age=60"},
+		{name: "no cache-control"},
+		{
+			name:       "policy TTL",
+			policies:   []runtime.ResponseCachePolicy{{Selector: "*", TTL: time.Minute}},
+			wantCached: true,
+		},
+		{
+			name:         "server overrides policy",
+			policies:     []runtime.ResponseCachePolicy{{Selector: "*", TTL: time.Minute}},
+			cacheControl: "no-cache",
+		},
+		{
+			name:         "disabled by policy",
+			policies:     []runtime.ResponseCachePolicy{{Selector: "example.Books.Get", TTL: -1}},
+			cacheControl: "max-age=60",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			cache := runtime.NewMemoryResponseCache(10)
+			mux := runtime.NewServeMux(runtime.WithResponseCache(cache, spec.policies...))
+			calls := 0
+			handleGenerated(t, mux, http.MethodGet, "/v1/books/{id}", "/example.Books/Get", func(context.Context, *http.Request, string) (proto.Message, metadata.MD, error) {
+				calls++
+				var md metadata.MD
+				if spec.cacheControl != "" {
+					md = metadata.Pairs("cache-control", spec.cacheControl)
+				}
+				return wrapperspb.String("book"), md, nil
+			}, nil)
+			for i := 0; i < 3; i++ {
+				mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/books/1", nil))
+			}
+			wantCalls := 3
+			if spec.wantCached {
+				wantCalls = 1
+			}
+			if calls != wantCalls {
+				t.Errorf("calls = %d; want %d", calls, wantCalls)
+			}
+		})
+	}
+}
+
+func TestMemoryResponseCache(t *testing.T) {
+	ctx := context.Background()
+	cache := runtime.NewMemoryResponseCache(2)
+	set := func(key string, ttl time.Duration) {
+		if err := cache.Set(ctx, key, &runtime.CachedResponse{StatusCode: http.StatusOK, Body: []byte(key)}, ttl); err != nil {
+			t.Fatalf("cache.Set(%q) failed with %v", key, err)
+		}
+	}
+	get := func(key string) bool {
+		resp, err := cache.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("cache.Get(%q) failed with %v", key, err)
+		}
+		return resp != nil
+	}
+
+	set("a", time.Minute)
+	set("b", time.Minute)
+	if !get("a") {
+		t.Errorf("a is not cached")
+	}
+	// b is the least recently used response.
+	set("c", time.Minute)
+	if get("b") {
+		t.Errorf("b is still cached; want it evicted")
+	}
+	if !get("a") || !get("c") {
+		t.Errorf("a and c are not cached")
+	}
+
+	set("d", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if get("d") {
+		t.Errorf("d is still cached; want it expired")
+	}
+	if got := cache.Len(); got != 1 {
+		t.Errorf("cache.Len() = %d; want 1", got)
+	}
+}
@@ -0,0 +1,354 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// RetriesHeader is the response header set to the number of times the gRPC
+// method of a request was retried, or hedged, when a RetryPolicy applies.
+const RetriesHeader = "Grpc-Gateway-Retries"
+
+// RetryPolicy configures the retries of the calls to a gRPC method.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls, including the first one.
+	// Calls are not retried when it is lower than 2.
+	MaxAttempts int
+	// InitialBackoff is the maximum time waited before the first retry.
+	// Defaults to 100 milliseconds.
+	InitialBackoff time.Duration
+	// MaxBackoff bounds the maximum time waited between retries. Defaults to
+	// 1 second.
+	MaxBackoff time.Duration
+	// BackoffMultiplier multiplies the maximum time waited after each retry.
+	// Defaults to 2.
+	BackoffMultiplier float64
+	// RetryableCodes are the codes of the errors which are retried. Defaults
+	// to Unavailable.
+	RetryableCodes []codes.Code
+	// HedgingDelay enables hedging when positive: instead of waiting for a
+	// call to fail, a new call is started each time HedgingDelay passes
+	// without a response, up to MaxAttempts calls. The first response which
+	// is a success or a non retryable error is used, and the other calls are
+	// canceled. A call failing with a retryable code starts the next one right
+	// away. The backoff is not used.
+	HedgingDelay time.Duration
+	// NonIdempotent applies the policy to every request. By default, it only
+	// applies to GET and HEAD requests, and to the methods whose
+	// idempotency_level option is NO_SIDE_EFFECTS or IDEMPOTENT.
+	NonIdempotent bool
+}
+
+// MethodRetryPolicy overrides the retry policy of some gRPC methods.
+type MethodRetryPolicy struct {
+	// Selector is the full name of a gRPC method, like "package.Service.Method".
+	// It may end with ".*" to select all the methods of a service or package,
+	// or be "*" to select every method.
+	Selector string
+	// Policy replaces the default policy for the selected methods.
+	Policy RetryPolicy
+}
+
+// WithRetryPolicy returns a ServeMuxOption retrying the unary calls of the
+// handlers of the ServeMux which fail with a retryable code. The policy of
+// the first MethodRetryPolicy selecting the gRPC method of a request is used,
+// or defaults if none does.
+//
+// Only the requests which are safe to retry are: GET and HEAD requests, and
+// requests to methods declared with
+//
+//	option idempotency_level = NO_SIDE_EFFECTS; // or IDEMPOTENT
+//
+// unless the policy is NonIdempotent. The request body is buffered so that it
+// can be sent again. The number of retries is set in the Grpc-Gateway-Retries
+// response header, reported to the MetricsCollector in RequestMetrics.Retries
+// and to the GatewayStatsHandlers with StatsUpstreamRetry events.
+//
+// Streaming methods are never retried. Handlers generated before RetryUnary
+// was introduced do not retry their calls.
+func WithRetryPolicy(defaults RetryPolicy, policies ...MethodRetryPolicy) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.retry = &retryConfig{defaults: defaults, policies: policies}
+	}
+}
+
+type retryConfig struct {
+	defaults RetryPolicy
+	policies []MethodRetryPolicy
+}
+
+func (c *retryConfig) policyFor(rpcMethodName string) RetryPolicy {
+	fullMethod := fullMethodName(rpcMethodName)
+	for _, p := range c.policies {
+		if selectorMatches(p.Selector, fullMethod) {
+			return p.Policy
+		}
+	}
+	return c.defaults
+}
+
+type retryConfigKey struct{}
+
+func withRetryConfig(ctx context.Context, c *retryConfig) context.Context {
+	return context.WithValue(ctx, retryConfigKey{}, c)
+}
+
+func retryConfigFromContext(ctx context.Context) *retryConfig {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(retryConfigKey{}).(*retryConfig)
+	return c
+}
+
+// idempotentMethods caches whether the methods of GlobalFiles are declared
+// idempotent, by full method name.
+var idempotentMethods sync.Map
+
+// isIdempotentMethod reports whether the idempotency_level option of the
+// method, looked up in GlobalFiles, is NO_SIDE_EFFECTS or IDEMPOTENT.
+func isIdempotentMethod(fullMethod string) bool {
+	if v, ok := idempotentMethods.Load(fullMethod); ok {
+		return v.(bool)
+	}
+	idempotent := false
+	if d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(fullMethod)); err == nil {
+		if md, ok := d.(protoreflect.MethodDescriptor); ok {
+			opts, _ := md.Options().(*descriptorpb.MethodOptions)
+			switch opts.GetIdempotencyLevel() {
+			case descriptorpb.MethodOptions_NO_SIDE_EFFECTS, descriptorpb.MethodOptions_IDEMPOTENT:
+				idempotent = true
+			}
+		}
+	}
+	idempotentMethods.Store(fullMethod, idempotent)
+	return idempotent
+}
+
+// UnaryAttempt calls a unary gRPC method with the request req, using ctx for
+// the call.
+type UnaryAttempt func(ctx context.Context, req *http.Request) (proto.Message, ServerMetadata, error)
+
+// RetryUnary calls attempt, and calls it again as configured by the
+// WithRetryPolicy option of the ServeMux when it fails. ctx must be the
+// context returned by AnnotateContext. Each attempt is given its own copy of
+// the body of req, and a context which is canceled when another attempt wins
+// a hedged call. Generated handlers of unary methods call it around the
+// client-rpc-request-func.
+func RetryUnary(ctx context.Context, req *http.Request, attempt UnaryAttempt) (proto.Message, ServerMetadata, error) {
+	c := retryConfigFromContext(ctx)
+	if c == nil {
+		return attempt(ctx, req)
+	}
+	rpcMethodName, _ := RPCMethod(ctx)
+	policy := c.policyFor(rpcMethodName)
+	if policy.MaxAttempts < 2 {
+		return attempt(ctx, req)
+	}
+	if !policy.NonIdempotent && req.Method != http.MethodGet && req.Method != http.MethodHead && !isIdempotentMethod(fullMethodName(rpcMethodName)) {
+		return attempt(ctx, req)
+	}
+
+	r := &retrier{ctx: ctx, req: req, attempt: attempt, policy: policy, rpcMethodName: rpcMethodName}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			// Let the attempt report the error like it would have without
+			// retries.
+			r.body = &bufferedBody{data: body, err: err}
+			return attempt(ctx, r.request(ctx))
+		}
+		r.body = &bufferedBody{data: body}
+	}
+
+	var (
+		resp proto.Message
+		md   ServerMetadata
+		err  error
+	)
+	if policy.HedgingDelay > 0 {
+		resp, md, err = r.hedge()
+	} else {
+		resp, md, err = r.retry()
+	}
+	if header, ok := responseHeaderFromContext(ctx); ok {
+		header.Set(RetriesHeader, strconv.Itoa(r.retries))
+	}
+	return resp, md, err
+}
+
+// bufferedBody is a request body read in memory, until err if it failed.
+type bufferedBody struct {
+	data []byte
+	err  error
+}
+
+type retrier struct {
+	ctx           context.Context
+	req           *http.Request
+	body          *bufferedBody
+	attempt       UnaryAttempt
+	policy        RetryPolicy
+	rpcMethodName string
+	retries       int
+}
+
+// request returns a copy of the request using ctx, with its own copy of the
+// buffered body.
+func (r *retrier) request(ctx context.Context) *http.Request {
+	req := r.req.WithContext(ctx)
+	if r.body != nil {
+		var body io.Reader = bytes.NewReader(r.body.data)
+		if r.body.err != nil {
+			body = io.MultiReader(body, &errorReader{err: r.body.err})
+		}
+		req.Body = io.NopCloser(body)
+	}
+	return req
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r *errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func (r *retrier) retryable(err error) bool {
+	if err == nil || r.ctx.Err() != nil {
+		return false
+	}
+	if _, ok := status.FromError(err); !ok {
+		return false
+	}
+	code := status.Code(err)
+	if len(r.policy.RetryableCodes) == 0 {
+		return code == codes.Unavailable
+	}
+	for _, c := range r.policy.RetryableCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the time to wait before the retry number n, chosen at
+// random up to the maximum backoff, like gRPC retries.
+func (r *retrier) backoff(n int) time.Duration {
+	initial, maxBackoff, multiplier := r.policy.InitialBackoff, r.policy.MaxBackoff, r.policy.BackoffMultiplier
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = time.Second
+	}
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	d := math.Min(float64(initial)*math.Pow(multiplier, float64(n-1)), float64(maxBackoff))
+	return time.Duration(rand.Float64() * d)
+}
+
+// report records that attempt number n is started after err, or after a
+// hedging delay if hedged.
+func (r *retrier) report(n int, err error, backoff time.Duration, hedged bool) {
+	r.retries = n - 1
+	stats := requestStatsFromContext(r.ctx)
+	stats.retried()
+	stats.emit(r.ctx, &StatsUpstreamRetry{
+		RPCMethod: r.rpcMethodName,
+		Attempt:   n,
+		Err:       err,
+		Backoff:   backoff,
+		Hedged:    hedged,
+	})
+}
+
+func (r *retrier) retry() (proto.Message, ServerMetadata, error) {
+	for n := 1; ; n++ {
+		resp, md, err := r.attempt(r.ctx, r.request(r.ctx))
+		if n >= r.policy.MaxAttempts || !r.retryable(err) {
+			return resp, md, err
+		}
+		backoff := r.backoff(n)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-r.ctx.Done():
+			timer.Stop()
+			return resp, md, err
+		case <-timer.C:
+		}
+		r.report(n+1, err, backoff, false)
+	}
+}
+
+type attemptResult struct {
+	resp proto.Message
+	md   ServerMetadata
+	err  error
+}
+
+func (r *retrier) hedge() (proto.Message, ServerMetadata, error) {
+	results := make(chan attemptResult, r.policy.MaxAttempts)
+	var cancels []context.CancelFunc
+	defer func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}()
+	started, pending := 0, 0
+	start := func(err error) {
+		started++
+		pending++
+		if started > 1 {
+			r.report(started, err, 0, err == nil)
+		}
+		ctx, cancel := context.WithCancel(r.ctx)
+		cancels = append(cancels, cancel)
+		req := r.request(ctx)
+		go func() {
+			resp, md, err := r.attempt(ctx, req)
+			results <- attemptResult{resp: resp, md: md, err: err}
+		}()
+	}
+
+	start(nil)
+	timer := time.NewTimer(r.policy.HedgingDelay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			if started < r.policy.MaxAttempts && r.ctx.Err() == nil {
+				start(nil)
+				timer.Reset(r.policy.HedgingDelay)
+			}
+		case res := <-results:
+			pending--
+			if !r.retryable(res.err) {
+				return res.resp, res.md, res.err
+			}
+			if started < r.policy.MaxAttempts {
+				start(res.err)
+				timer.Reset(r.policy.HedgingDelay)
+			} else if pending == 0 {
+				return res.resp, res.md, res.err
+			}
+		}
+	}
+}
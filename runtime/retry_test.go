@@ -0,0 +1,248 @@
+package runtime_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// registerRetryFile registers a service whose Get method is declared
+// idempotent in GlobalFiles.
+var registerRetryFile = sync.OnceValue(func() error {
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("retry.proto"),
+		Package:    proto.String("retrytest"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/wrappers.proto"},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Books"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{
+					Name:       proto.String("Get"),
+					InputType:  proto.String(".google.protobuf.StringValue"),
+					OutputType: proto.String(".google.protobuf.StringValue"),
+					Options: &descriptorpb.MethodOptions{
+						IdempotencyLevel: descriptorpb.MethodOptions_IDEMPOTENT.Enum(),
+					},
+				},
+				{
+					Name:       proto.String("Create"),
+					InputType:  proto.String(".google.protobuf.StringValue"),
+					OutputType: proto.String(".google.protobuf.StringValue"),
+				},
+			},
+		}},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		return err
+	}
+	return protoregistry.GlobalFiles.RegisterFile(fd)
+})
+
+func TestRetryUnary(t *testing.T) {
+	if err := registerRetryFile(); err != nil {
+		t.Fatalf("failed to register retry.proto: %v", err)
+	}
+	defaults := runtime.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	for _, spec := range []struct {
+		name      string
+		policies  []runtime.MethodRetryPolicy
+		method    string
+		rpcMethod string
+		// failures are the errors of the first calls.
+		failures    []codes.Code
+		wantCalls   int
+		wantStatus  int
+		wantRetries string
+	}{
+		{
+			name:        "GET",
+			method:      http.MethodGet,
+			rpcMethod:   "/retrytest.Books/Create",
+			failures:    []codes.Code{codes.Unavailable, codes.Unavailable},
+			wantCalls:   3,
+			wantStatus:  http.StatusOK,
+			wantRetries: "2",
+		},
+		{
+			name:        "too many failures",
+			method:      http.MethodGet,
+			rpcMethod:   "/retrytest.Books/Create",
+			failures:    []codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable},
+			wantCalls:   3,
+			wantStatus:  http.StatusServiceUnavailable,
+			wantRetries: "2",
+		},
+		{
+			name:        "not retryable",
+			method:      http.MethodGet,
+			rpcMethod:   "/retrytest.Books/Create",
+			failures:    []codes.Code{codes.NotFound},
+			wantCalls:   1,
+			wantStatus:  http.StatusNotFound,
+			wantRetries: "0",
+		},
+		{
+			name:       "POST",
+			method:     http.MethodPost,
+			rpcMethod:  "/retrytest.Books/Create",
+			failures:   []codes.Code{codes.Unavailable},
+			wantCalls:  1,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:        "POST to idempotent method",
+			method:      http.MethodPost,
+			rpcMethod:   "/retrytest.Books/Get",
+			failures:    []codes.Code{codes.Unavailable},
+			wantCalls:   2,
+			wantStatus:  http.StatusOK,
+			wantRetries: "1",
+		},
+		{
+			name: "non idempotent policy",
+			policies: []runtime.MethodRetryPolicy{{
+				Selector: "retrytest.Books.Create",
+				Policy:   runtime.RetryPolicy{MaxAttempts: 2, NonIdempotent: true, RetryableCodes: []codes.Code{codes.Aborted}},
+			}},
+			method:      http.MethodPost,
+			rpcMethod:   "/retrytest.Books/Create",
+			failures:    []codes.Code{codes.Aborted},
+			wantCalls:   2,
+			wantStatus:  http.StatusOK,
+			wantRetries: "1",
+		},
+		{
+			name: "disabled by method policy",
+			policies: []runtime.MethodRetryPolicy{{
+				Selector: "retrytest.Books.*",
+				Policy:   runtime.RetryPolicy{MaxAttempts: 1},
+			}},
+			method:     http.MethodGet,
+			rpcMethod:  "/retrytest.Books/Create",
+			failures:   []codes.Code{codes.Unavailable},
+			wantCalls:  1,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(runtime.WithRetryPolicy(defaults, spec.policies...))
+			var (
+				calls  int
+				bodies []string
+			)
+			handleGenerated(t, mux, spec.method, "/v1/books", spec.rpcMethod, func(_ context.Context, _ *http.Request, body string) (proto.Message, metadata.MD, error) {
+				calls++
+				bodies = append(bodies, body)
+				if calls <= len(spec.failures) {
+					return nil, nil, status.Error(spec.failures[calls-1], "failed")
+				}
+				return wrapperspb.String("book"), nil, nil
+			}, nil)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(spec.method, "/v1/books", strings.NewReader(`"body"`)))
+			if w.Code != spec.wantStatus {
+				t.Errorf("status = %d; want %d", w.Code, spec.wantStatus)
+			}
+			if calls != spec.wantCalls {
+				t.Errorf("calls = %d; want %d", calls, spec.wantCalls)
+			}
+			for i, body := range bodies {
+				if body != "body" {
+					t.Errorf("body of call %d = %q; want %q", i, body, "body")
+				}
+			}
+			if got := w.Header().Get(runtime.RetriesHeader); got != spec.wantRetries {
+				t.Errorf("%s = %q; want %q", runtime.RetriesHeader, got, spec.wantRetries)
+			}
+		})
+	}
+}
+
+func TestRetryUnaryHedging(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithRetryPolicy(runtime.RetryPolicy{
+		MaxAttempts:  3,
+		HedgingDelay: 20 * time.Millisecond,
+	}))
+	var (
+		calls    atomic.Int32
+		canceled = make(chan struct{})
+	)
+	handleGenerated(t, mux, http.MethodGet, "/v1/books", "/retrytest.Books/Create", func(ctx context.Context, _ *http.Request, _ string) (proto.Message, metadata.MD, error) {
+		if calls.Add(1) == 1 {
+			// The first call is slow, and canceled once the hedged one replies.
+			<-ctx.Done()
+			close(canceled)
+			return nil, nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return wrapperspb.String("book"), nil, nil
+	}, nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/books", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d; want %d", w.Code, http.StatusOK)
+	}
+	if got, want := w.Header().Get(runtime.RetriesHeader), "1"; got != want {
+		t.Errorf("%s = %q; want %q", runtime.RetriesHeader, got, want)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Errorf("the slow call was not canceled")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("calls = %d; want 2", got)
+	}
+}
+
+func TestRetryUnaryStats(t *testing.T) {
+	collector := &recordingMetricsCollector{}
+	stats := &recordingStatsHandler{}
+	mux := runtime.NewServeMux(
+		runtime.WithRetryPolicy(runtime.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		runtime.WithMetricsCollector(collector),
+		runtime.WithStatsHandler(stats),
+	)
+	calls := 0
+	handleGenerated(t, mux, http.MethodGet, "/v1/books", "/retrytest.Books/Create", replyWith(func() (proto.Message, error) {
+		if calls++; calls == 1 {
+			return nil, status.Error(codes.Unavailable, "restarting")
+		}
+		return wrapperspb.String("book"), nil
+	}), nil)
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/books", nil))
+
+	if len(collector.metrics) != 1 || collector.metrics[0].Retries != 1 {
+		t.Errorf("metrics = %+v; want 1 retry", collector.metrics)
+	}
+	var retries []*runtime.StatsUpstreamRetry
+	for _, e := range stats.events {
+		if retry, ok := e.(*runtime.StatsUpstreamRetry); ok {
+			retries = append(retries, retry)
+		}
+	}
+	if len(retries) != 1 {
+		t.Fatalf("retry events = %v; want 1", retries)
+	}
+	if got := retries[0]; got.RPCMethod != "/retrytest.Books/Create" || got.Attempt != 2 || status.Code(got.Err) != codes.Unavailable || got.Hedged {
+		t.Errorf("retry event = %+v; want the second attempt after an Unavailable error", got)
+	}
+}
@@ -0,0 +1,289 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GatewayStatsHandler is notified of each stage of the requests handled by
+// the routes of a ServeMux, so that logging, metrics and tracing can be
+// plugged into a single place.
+//
+// The events are emitted by the ServeMux and by the runtime functions called
+// by generated handlers. Handlers generated before ReportUpstreamBegin and
+// ReportUpstreamEnd were introduced do not emit the upstream events.
+type GatewayStatsHandler interface {
+	// TagRequest is called when a request matches a route, before any event
+	// is emitted. The returned context is used for the rest of the request,
+	// for example to carry a tracing span to the upstream call.
+	TagRequest(ctx context.Context, info *StatsRouteMatched) context.Context
+	// HandleStats is called for each event of the request. It may be called
+	// concurrently, as client and bidirectional streams are decoded in their
+	// own goroutine, and must not block.
+	HandleStats(ctx context.Context, s GatewayStats)
+}
+
+// WithStatsHandler returns a ServeMuxOption that notifies handler of the
+// stages of every request matching a route of the ServeMux. The option can be
+// given several times, in which case the handlers are notified in order.
+func WithStatsHandler(handler GatewayStatsHandler) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.statsHandlers = append(serveMux.statsHandlers, handler)
+	}
+}
+
+// GatewayStats is an event of the lifecycle of a request. It is one of
+// *StatsRouteMatched, *StatsMarshalerChosen, *StatsBodyDecoded,
+// *StatsCacheLookup, *StatsUpstreamBegin, *StatsUpstreamRetry,
+// *StatsUpstreamEnd, *StatsResponseMarshaled, *StatsStreamMessageSent and
+// *StatsRequestFinished.
+type GatewayStats interface {
+	isGatewayStats()
+}
+
+// StatsRouteMatched is emitted when a request matches a route of the ServeMux.
+type StatsRouteMatched struct {
+	// HTTPMethod is the HTTP method of the request.
+	HTTPMethod string
+	// HTTPPathPattern is the path template of the matched route.
+	HTTPPathPattern string
+	// PathParams holds the values captured by the path template.
+	PathParams map[string]string
+	// BeginTime is the time at which the request was matched.
+	BeginTime time.Time
+	// Request is the matched request. Its body must not be read.
+	Request *http.Request
+}
+
+// StatsMarshalerChosen is emitted by MarshalerForRequest.
+type StatsMarshalerChosen struct {
+	// Inbound is the marshaler used to decode the request body.
+	Inbound Marshaler
+	// Outbound is the marshaler used to encode the response.
+	Outbound Marshaler
+}
+
+// StatsBodyDecoded is emitted each time a message is decoded from the
+// request body with the inbound marshaler. Client and bidirectional streams
+// emit it for each message, and once more with io.EOF when the body ends.
+type StatsBodyDecoded struct {
+	// Bytes is the number of bytes read from the body to decode the message.
+	// Decoders may read ahead, so this is an approximation for streams.
+	Bytes int64
+	// Err is the decoding error, if any.
+	Err error
+}
+
+// StatsCacheLookup is emitted when a GET request is looked up in the response
+// cache configured by WithResponseCache, before its handler is called.
+type StatsCacheLookup struct {
+	// Status tells whether the response is served from the cache.
+	Status CacheStatus
+}
+
+// StatsUpstreamBegin is emitted by ReportUpstreamBegin, right before the gRPC
+// method is called.
+type StatsUpstreamBegin struct {
+	// RPCMethod is the full gRPC method name, in the format "/package.service/method".
+	RPCMethod string
+	// BeginTime is the time at which the call started.
+	BeginTime time.Time
+}
+
+// StatsUpstreamRetry is emitted by RetryUnary before the gRPC method is called
+// again, as configured by WithRetryPolicy. Each attempt also emits
+// StatsUpstreamBegin, while StatsUpstreamEnd is only emitted once, with the
+// result of the attempt which is used.
+type StatsUpstreamRetry struct {
+	// RPCMethod is the full gRPC method name, in the format "/package.service/method".
+	RPCMethod string
+	// Attempt is the number of the attempt about to start, from 2.
+	Attempt int
+	// Err is the error of the previous attempt, or nil if the attempt is
+	// hedged while the previous ones are still running.
+	Err error
+	// Backoff is the time waited since the previous attempt failed.
+	Backoff time.Duration
+	// Hedged reports whether the attempt was started by hedging.
+	Hedged bool
+}
+
+// StatsUpstreamEnd is emitted by ReportUpstreamEnd once the gRPC method has
+// replied, or has started its response stream for server streaming methods.
+type StatsUpstreamEnd struct {
+	// RPCMethod is the full gRPC method name, in the format "/package.service/method".
+	RPCMethod string
+	// Code is the gRPC status code of the call.
+	Code codes.Code
+	// Err is the error returned by the call, if any.
+	Err error
+	// Header and Trailer are the metadata sent by the gRPC server.
+	Header  metadata.MD
+	Trailer metadata.MD
+	// BeginTime and EndTime are the times at which the call started and ended.
+	BeginTime time.Time
+	EndTime   time.Time
+}
+
+// StatsResponseMarshaled is emitted when a response message, or a message of
+// a response stream, has been marshaled with the outbound marshaler.
+type StatsResponseMarshaled struct {
+	// Bytes is the size of the marshaled message.
+	Bytes int
+	// Duration is the time spent marshaling the message.
+	Duration time.Duration
+	// Err is the marshaling error, if any.
+	Err error
+}
+
+// StatsStreamMessageSent is emitted when a message of a response stream has
+// been written by ForwardResponseStream.
+type StatsStreamMessageSent struct {
+	// Bytes is the size of the message, without its delimiter.
+	Bytes int
+	// Count is the number of messages sent so far, including this one.
+	Count int
+}
+
+// StatsRequestFinished is emitted once the handler of the route has returned.
+// It carries the same summary of the request as a MetricsCollector receives.
+type StatsRequestFinished struct {
+	RequestMetrics
+	// EndTime is the time at which the handler returned.
+	EndTime time.Time
+}
+
+func (*StatsRouteMatched) isGatewayStats()      {}
+func (*StatsMarshalerChosen) isGatewayStats()   {}
+func (*StatsBodyDecoded) isGatewayStats()       {}
+func (*StatsCacheLookup) isGatewayStats()       {}
+func (*StatsUpstreamBegin) isGatewayStats()     {}
+func (*StatsUpstreamRetry) isGatewayStats()     {}
+func (*StatsUpstreamEnd) isGatewayStats()       {}
+func (*StatsResponseMarshaled) isGatewayStats() {}
+func (*StatsStreamMessageSent) isGatewayStats() {}
+func (*StatsRequestFinished) isGatewayStats()   {}
+
+// ReportUpstreamBegin records that the gRPC method of the request is about to
+// be called. Generated handlers call it once the request message is populated.
+func ReportUpstreamBegin(ctx context.Context) {
+	m := requestStatsFromContext(ctx)
+	if m == nil {
+		return
+	}
+	now := time.Now()
+	m.mu.Lock()
+	m.upstreamStart = now
+	m.upstreamBegin = now
+	m.mu.Unlock()
+
+	method, _ := RPCMethod(ctx)
+	m.emit(ctx, &StatsUpstreamBegin{RPCMethod: method, BeginTime: now})
+}
+
+// ReportUpstreamEnd records the result of the gRPC call started after
+// ReportUpstreamBegin. ctx must carry the ServerMetadata of the call, as
+// returned by NewServerMetadataContext. Nothing is reported if the request
+// failed before the call was started.
+func ReportUpstreamEnd(ctx context.Context, err error) {
+	m := requestStatsFromContext(ctx)
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	begin := m.upstreamBegin
+	m.upstreamBegin = time.Time{}
+	m.mu.Unlock()
+	if begin.IsZero() {
+		return
+	}
+	m.endUpstream()
+
+	md, _ := ServerMetadataFromContext(ctx)
+	method, _ := RPCMethod(ctx)
+	m.emit(ctx, &StatsUpstreamEnd{
+		RPCMethod: method,
+		Code:      status.Code(err),
+		Err:       err,
+		Header:    md.HeaderMD,
+		Trailer:   md.TrailerMD,
+		BeginTime: begin,
+		EndTime:   time.Now(),
+	})
+}
+
+func (m *requestStats) emit(ctx context.Context, s GatewayStats) {
+	if m == nil {
+		return
+	}
+	for _, h := range m.handlers {
+		h.HandleStats(ctx, s)
+	}
+}
+
+// marshalerChosen reports the marshalers selected for the request and returns
+// the inbound marshaler to use, which reports the decoded messages.
+func (m *requestStats) marshalerChosen(ctx context.Context, inbound, outbound Marshaler) Marshaler {
+	if m == nil || len(m.handlers) == 0 {
+		return inbound
+	}
+	m.emit(ctx, &StatsMarshalerChosen{Inbound: inbound, Outbound: outbound})
+	return &statsMarshaler{Marshaler: inbound, ctx: ctx, stats: m}
+}
+
+// responseMarshaled records the marshaling of a response message which
+// started at start.
+func (m *requestStats) responseMarshaled(ctx context.Context, start time.Time, n int, err error) {
+	if m == nil {
+		return
+	}
+	d := time.Since(start)
+	m.addMarshalDuration(d)
+	m.emit(ctx, &StatsResponseMarshaled{Bytes: n, Duration: d, Err: err})
+}
+
+// streamMessageSent records a message of n bytes written to a response stream.
+func (m *requestStats) streamMessageSent(ctx context.Context, n int) {
+	if m == nil {
+		return
+	}
+	count := m.incStreamMessagesSent()
+	m.emit(ctx, &StatsStreamMessageSent{Bytes: n, Count: count})
+}
+
+// statsMarshaler wraps the inbound marshaler of a request to report the
+// messages decoded from its body.
+type statsMarshaler struct {
+	Marshaler
+	ctx   context.Context
+	stats *requestStats
+}
+
+func (m *statsMarshaler) NewDecoder(r io.Reader) Decoder {
+	cr := &countingReader{r: r}
+	dec := m.Marshaler.NewDecoder(cr)
+	return DecoderFunc(func(v interface{}) error {
+		before := cr.n
+		err := dec.Decode(v)
+		m.stats.emit(m.ctx, &StatsBodyDecoded{Bytes: cr.n - before, Err: err})
+		return err
+	})
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
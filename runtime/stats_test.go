@@ -0,0 +1,157 @@
+package runtime_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type statsTagKey struct{}
+
+type recordingStatsHandler struct {
+	mu     sync.Mutex
+	events []runtime.GatewayStats
+	tagged []bool
+}
+
+func (h *recordingStatsHandler) TagRequest(ctx context.Context, _ *runtime.StatsRouteMatched) context.Context {
+	return context.WithValue(ctx, statsTagKey{}, true)
+}
+
+func (h *recordingStatsHandler) HandleStats(ctx context.Context, s runtime.GatewayStats) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, s)
+	h.tagged = append(h.tagged, ctx.Value(statsTagKey{}) != nil)
+}
+
+func (h *recordingStatsHandler) reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events, h.tagged = nil, nil
+}
+
+func (h *recordingStatsHandler) kinds() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var kinds []string
+	for _, e := range h.events {
+		kinds = append(kinds, strings.TrimPrefix(fmt.Sprintf("%T", e), "*runtime.Stats"))
+	}
+	return kinds
+}
+
+func TestWithStatsHandler(t *testing.T) {
+	handler := &recordingStatsHandler{}
+	mux := runtime.NewServeMux(runtime.WithStatsHandler(handler))
+	handleGenerated(t, mux, http.MethodPost, "/v1/echo/{id}", "/example.Echo/Echo", replyWith(func() (proto.Message, error) {
+		return wrapperspb.String("hello"), nil
+	}), nil)
+	handleGenerated(t, mux, http.MethodDelete, "/v1/echo/{id}", "/example.Echo/Delete", replyWith(func() (proto.Message, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}), nil)
+	handleGenerated(t, mux, http.MethodGet, "/v1/stream", "/example.Echo/Stream", replyWith(func() (proto.Message, error) {
+		return nil, nil
+	}), []proto.Message{wrapperspb.String("a"), wrapperspb.String("b")})
+
+	t.Run("unary", func(t *testing.T) {
+		handler.reset()
+		req := httptest.NewRequest(http.MethodPost, "/v1/echo/1", strings.NewReader(`"hi"`))
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+
+		want := []string{"RouteMatched", "MarshalerChosen", "BodyDecoded", "UpstreamBegin", "UpstreamEnd", "ResponseMarshaled", "RequestFinished"}
+		if got := handler.kinds(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("events = %v; want %v", got, want)
+		}
+		for i, tagged := range handler.tagged {
+			if !tagged {
+				t.Errorf("event %d was not given the context returned by TagRequest", i)
+			}
+		}
+
+		matched := handler.events[0].(*runtime.StatsRouteMatched)
+		if matched.HTTPMethod != http.MethodPost || matched.HTTPPathPattern != "/v1/echo/{id=*}" || matched.PathParams["id"] != "1" {
+			t.Errorf("route matched = %+v", matched)
+		}
+		if decoded := handler.events[2].(*runtime.StatsBodyDecoded); decoded.Bytes != 4 || decoded.Err != nil {
+			t.Errorf("body decoded = %+v; want 4 bytes", decoded)
+		}
+		begin := handler.events[3].(*runtime.StatsUpstreamBegin)
+		if begin.RPCMethod != "/example.Echo/Echo" {
+			t.Errorf("upstream begin RPC method = %q", begin.RPCMethod)
+		}
+		end := handler.events[4].(*runtime.StatsUpstreamEnd)
+		if end.Code != codes.OK || end.Err != nil || end.RPCMethod != "/example.Echo/Echo" {
+			t.Errorf("upstream end = %+v", end)
+		}
+		if got := end.Header.Get("x-upstream"); len(got) != 1 {
+			t.Errorf("upstream end header = %v; want x-upstream", end.Header)
+		}
+		if end.EndTime.Before(end.BeginTime) {
+			t.Errorf("upstream end time %v is before its begin time %v", end.EndTime, end.BeginTime)
+		}
+		marshaled := handler.events[5].(*runtime.StatsResponseMarshaled)
+		if marshaled.Bytes != len(`"hello"`) || marshaled.Err != nil {
+			t.Errorf("response marshaled = %+v", marshaled)
+		}
+		finished := handler.events[6].(*runtime.StatsRequestFinished)
+		if finished.HTTPStatus != http.StatusOK || finished.Code != codes.OK || finished.RPCMethod != "/example.Echo/Echo" || finished.ResponseBytes != int64(marshaled.Bytes) {
+			t.Errorf("request finished = %+v", finished)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		handler.reset()
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/v1/echo/1", nil))
+
+		want := []string{"RouteMatched", "MarshalerChosen", "UpstreamBegin", "UpstreamEnd", "RequestFinished"}
+		if got := handler.kinds(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("events = %v; want %v", got, want)
+		}
+		if end := handler.events[3].(*runtime.StatsUpstreamEnd); end.Code != codes.NotFound || end.Err == nil {
+			t.Errorf("upstream end = %+v; want NotFound", end)
+		}
+		if finished := handler.events[4].(*runtime.StatsRequestFinished); finished.HTTPStatus != http.StatusNotFound || finished.Code != codes.NotFound {
+			t.Errorf("request finished = %+v", finished)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		handler.reset()
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stream", nil))
+
+		want := []string{"RouteMatched", "MarshalerChosen", "UpstreamBegin", "UpstreamEnd",
+			"ResponseMarshaled", "StreamMessageSent", "ResponseMarshaled", "StreamMessageSent", "RequestFinished"}
+		if got := handler.kinds(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("events = %v; want %v", got, want)
+		}
+		if sent := handler.events[7].(*runtime.StatsStreamMessageSent); sent.Count != 2 || sent.Bytes == 0 {
+			t.Errorf("stream message sent = %+v; want the second message", sent)
+		}
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		handler.reset()
+		req := httptest.NewRequest(http.MethodPost, "/v1/echo/1", strings.NewReader(`{`))
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+
+		want := []string{"RouteMatched", "MarshalerChosen", "BodyDecoded", "RequestFinished"}
+		if got := handler.kinds(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("events = %v; want %v", got, want)
+		}
+		if decoded := handler.events[2].(*runtime.StatsBodyDecoded); decoded.Err == nil {
+			t.Errorf("body decoded = %+v; want an error", decoded)
+		}
+	})
+}
@@ -0,0 +1,527 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/genproto/googleapis/api/visibility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// VisibilityResolver returns the google.api.VisibilityRule labels visible to
+// a request, like "INTERNAL" or "PREVIEW".
+type VisibilityResolver func(ctx context.Context, r *http.Request) []string
+
+// VisibilityOption is an option that can be given to WithVisibility.
+type VisibilityOption func(*visibilityConfig)
+
+type visibilityConfig struct {
+	header     string
+	resolver   VisibilityResolver
+	dropFields bool
+
+	// restrictions caches the restrictions of descriptors, by descriptor.
+	restrictions sync.Map
+	// views caches the types returned by visibilityState.viewType, by
+	// viewKey.
+	views sync.Map
+}
+
+// WithVisibilityHeader reads the labels visible to a request from a header,
+// as a comma separated list. The header must only be trusted if it is set by
+// a trusted proxy, as clients could otherwise select any label.
+func WithVisibilityHeader(header string) VisibilityOption {
+	return func(c *visibilityConfig) {
+		c.header = textproto.CanonicalMIMEHeaderKey(header)
+	}
+}
+
+// WithVisibilityResolver sets a resolver of the labels visible to a request,
+// for example from its authenticated identity. They are added to the labels
+// of the header given to WithVisibilityHeader.
+func WithVisibilityResolver(resolver VisibilityResolver) VisibilityOption {
+	return func(c *visibilityConfig) {
+		c.resolver = resolver
+	}
+}
+
+// WithVisibilityDropHiddenFields makes requests setting fields which are not
+// visible succeed, with these fields cleared. By default they are rejected.
+func WithVisibilityDropHiddenFields() VisibilityOption {
+	return func(c *visibilityConfig) {
+		c.dropFields = true
+	}
+}
+
+// WithVisibility returns a ServeMuxOption that enforces the
+// google.api.VisibilityRule restrictions of the services, methods, fields and
+// enum values of the protos, like the visibility_restriction_selectors option
+// of protoc-gen-openapiv2 does in the OpenAPI documents.
+//
+// An element with a restriction is visible to a request if one of its labels
+// is given by WithVisibilityHeader or WithVisibilityResolver; elements without
+// restriction are always visible. For each request:
+//   - methods which are not visible, or whose service is not, are not found,
+//   - fields and enum values which are not visible are rejected as unknown
+//     fields in request bodies and query parameters, or dropped with
+//     WithVisibilityDropHiddenFields,
+//   - fields and enum values which are not visible are removed from
+//     responses.
+//
+// The restrictions of services and methods are read from the descriptors of
+// protoregistry.GlobalFiles, and those of fields and enum values from the
+// descriptors of the messages.
+func WithVisibility(opts ...VisibilityOption) ServeMuxOption {
+	c := &visibilityConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return func(serveMux *ServeMux) {
+		serveMux.visibility = c
+	}
+}
+
+// visibilityState holds the labels visible to a request.
+type visibilityState struct {
+	config *visibilityConfig
+	labels map[string]bool
+	// key identifies the labels in visibilityConfig.views.
+	key string
+}
+
+type visibilityStateKey struct{}
+
+func withVisibilityState(ctx context.Context, state *visibilityState) context.Context {
+	return context.WithValue(ctx, visibilityStateKey{}, state)
+}
+
+func visibilityStateFromContext(ctx context.Context) *visibilityState {
+	if ctx == nil {
+		return nil
+	}
+	state, _ := ctx.Value(visibilityStateKey{}).(*visibilityState)
+	return state
+}
+
+// newState returns the visibility state of r.
+func (c *visibilityConfig) newState(ctx context.Context, r *http.Request) *visibilityState {
+	state := &visibilityState{config: c, labels: make(map[string]bool)}
+	if c.header != "" {
+		for _, v := range r.Header.Values(c.header) {
+			for _, label := range strings.Split(v, ",") {
+				if label = strings.TrimSpace(label); label != "" {
+					state.labels[label] = true
+				}
+			}
+		}
+	}
+	if c.resolver != nil {
+		for _, label := range c.resolver(ctx, r) {
+			state.labels[strings.TrimSpace(label)] = true
+		}
+	}
+	labels := make([]string, 0, len(state.labels))
+	for label := range state.labels {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	state.key = strings.Join(labels, ",")
+	return state
+}
+
+// restriction returns the visibility restriction of d, which is a service,
+// method, field or enum value.
+func (c *visibilityConfig) restriction(d protoreflect.Descriptor) string {
+	if r, ok := c.restrictions.Load(d); ok {
+		return r.(string)
+	}
+	var rule *visibility.VisibilityRule
+	switch d := d.(type) {
+	case protoreflect.ServiceDescriptor:
+		rule, _ = proto.GetExtension(d.Options(), visibility.E_ApiVisibility).(*visibility.VisibilityRule)
+	case protoreflect.MethodDescriptor:
+		rule, _ = proto.GetExtension(d.Options(), visibility.E_MethodVisibility).(*visibility.VisibilityRule)
+	case protoreflect.FieldDescriptor:
+		rule, _ = proto.GetExtension(d.Options(), visibility.E_FieldVisibility).(*visibility.VisibilityRule)
+	case protoreflect.EnumValueDescriptor:
+		rule, _ = proto.GetExtension(d.Options(), visibility.E_ValueVisibility).(*visibility.VisibilityRule)
+	}
+	r := rule.GetRestriction()
+	c.restrictions.Store(d, r)
+	return r
+}
+
+func (s *visibilityState) visible(d protoreflect.Descriptor) bool {
+	restriction := s.config.restriction(d)
+	if strings.TrimSpace(restriction) == "" {
+		return true
+	}
+	for _, label := range strings.Split(restriction, ",") {
+		if s.labels[strings.TrimSpace(label)] {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMethod returns a NotFound error if the gRPC method rpcMethodName, like
+// "/package.Service/Method", is not visible.
+func (s *visibilityState) checkMethod(rpcMethodName string) error {
+	if s == nil {
+		return nil
+	}
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(fullMethodName(rpcMethodName)))
+	if err != nil {
+		return nil
+	}
+	md, ok := d.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil
+	}
+	if !s.visible(md.Parent()) || !s.visible(md) {
+		return status.Error(codes.NotFound, http.StatusText(http.StatusNotFound))
+	}
+	return nil
+}
+
+// checkRequest rejects or clears the fields of the request message msg which
+// are not visible.
+func (s *visibilityState) checkRequest(msg proto.Message) error {
+	if s == nil || msg == nil {
+		return nil
+	}
+	hidden := s.hide(msg.ProtoReflect(), "", s.config.dropFields)
+	if len(hidden) > 0 && !s.config.dropFields {
+		return fmt.Errorf("unknown field %q", hidden[0])
+	}
+	return nil
+}
+
+// stripResponse clears the fields of the response message msg which are not
+// visible.
+func (s *visibilityState) stripResponse(msg proto.Message) {
+	if s == nil || msg == nil {
+		return
+	}
+	s.hide(msg.ProtoReflect(), "", true)
+}
+
+// responseView returns the value to marshal for the response value v. Clearing
+// the fields which are not visible is not enough to hide them from marshalers
+// emitting unpopulated fields, so messages with such fields are marshaled as
+// messages of a type without them.
+func (s *visibilityState) responseView(v interface{}) interface{} {
+	if s == nil {
+		return v
+	}
+	switch v := v.(type) {
+	case proto.Message:
+		return s.messageView(v)
+	case []proto.Message:
+		views := make([]proto.Message, len(v))
+		for i, msg := range v {
+			views[i] = s.messageView(msg)
+		}
+		return views
+	}
+	return v
+}
+
+func (s *visibilityState) messageView(msg proto.Message) proto.Message {
+	if msg == nil {
+		return msg
+	}
+	typ := s.viewType(msg.ProtoReflect().Descriptor())
+	if typ == nil {
+		return msg
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return msg
+	}
+	view := typ.New().Interface()
+	if err := (proto.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, view); err != nil {
+		return msg
+	}
+	return view
+}
+
+type viewKey struct {
+	labels string
+	name   protoreflect.FullName
+}
+
+// viewType returns the type of the messages of md without the fields which
+// are not visible, or nil if all the fields which md may contain are visible.
+func (s *visibilityState) viewType(md protoreflect.MessageDescriptor) protoreflect.MessageType {
+	key := viewKey{labels: s.key, name: md.FullName()}
+	if typ, ok := s.config.views.Load(key); ok {
+		typ, _ := typ.(protoreflect.MessageType)
+		return typ
+	}
+	var typ protoreflect.MessageType
+	if s.hasHiddenFields(md, make(map[protoreflect.FullName]bool)) {
+		typ = s.buildViewType(md)
+	}
+	s.config.views.Store(key, typ)
+	return typ
+}
+
+func (s *visibilityState) hasHiddenFields(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]bool) bool {
+	if seen[md.FullName()] {
+		return false
+	}
+	seen[md.FullName()] = true
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !s.visible(fd) {
+			return true
+		}
+		if fd.Message() != nil && s.hasHiddenFields(fd.Message(), seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRestrictedFields reports whether fields of the messages of md, or values
+// of their enums, have a visibility restriction, whatever the labels of the
+// requests.
+func (c *visibilityConfig) hasRestrictedFields(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]bool) bool {
+	if seen[md.FullName()] {
+		return false
+	}
+	seen[md.FullName()] = true
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if strings.TrimSpace(c.restriction(fd)) != "" {
+			return true
+		}
+		if ed := fd.Enum(); ed != nil {
+			values := ed.Values()
+			for j := 0; j < values.Len(); j++ {
+				if strings.TrimSpace(c.restriction(values.Get(j))) != "" {
+					return true
+				}
+			}
+		}
+		if fd.Message() != nil && c.hasRestrictedFields(fd.Message(), seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildViewType builds the type returned by viewType, from copies of the
+// files of md and of their dependencies without the fields which are not
+// visible.
+func (s *visibilityState) buildViewType(md protoreflect.MessageDescriptor) protoreflect.MessageType {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		fdp := protodesc.ToFileDescriptorProto(fd)
+		s.removeHiddenFields(fdp.GetMessageType(), fd.Messages())
+		set.File = append(set.File, fdp)
+	}
+	add(md.ParentFile())
+
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil
+	}
+	d, err := files.FindDescriptorByName(md.FullName())
+	if err != nil {
+		return nil
+	}
+	view, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil
+	}
+	return dynamicpb.NewMessageType(view)
+}
+
+func (s *visibilityState) removeHiddenFields(messages []*descriptorpb.DescriptorProto, mds protoreflect.MessageDescriptors) {
+	for i, mp := range messages {
+		md := mds.Get(i)
+		var fields []*descriptorpb.FieldDescriptorProto
+		usedOneofs := make(map[int32]bool)
+		for j, fp := range mp.GetField() {
+			if !s.visible(md.Fields().Get(j)) {
+				continue
+			}
+			fields = append(fields, fp)
+			if fp.OneofIndex != nil {
+				usedOneofs[fp.GetOneofIndex()] = true
+			}
+		}
+		mp.Field = fields
+
+		// Oneofs must not be empty.
+		oneofIndexes := make(map[int32]int32)
+		var oneofs []*descriptorpb.OneofDescriptorProto
+		for j, op := range mp.GetOneofDecl() {
+			if usedOneofs[int32(j)] {
+				oneofIndexes[int32(j)] = int32(len(oneofs))
+				oneofs = append(oneofs, op)
+			}
+		}
+		mp.OneofDecl = oneofs
+		for _, fp := range fields {
+			if fp.OneofIndex != nil {
+				fp.OneofIndex = proto.Int32(oneofIndexes[fp.GetOneofIndex()])
+			}
+		}
+
+		s.removeHiddenFields(mp.GetNestedType(), md.Messages())
+	}
+}
+
+// hide returns the paths of the populated fields of m which are not visible,
+// or hold enum values which are not visible, and clears them if clear is set.
+func (s *visibilityState) hide(m protoreflect.Message, prefix string, clear bool) []string {
+	if !m.IsValid() {
+		return nil
+	}
+	var hidden []string
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		path := prefix + fd.TextName()
+		if !s.visible(fd) {
+			hidden = append(hidden, path)
+			if clear {
+				m.Clear(fd)
+			}
+			return true
+		}
+		switch {
+		case fd.IsList():
+			hidden = append(hidden, s.hideList(m, fd, v.List(), path, clear)...)
+		case fd.IsMap():
+			hidden = append(hidden, s.hideMap(fd, v.Map(), path, clear)...)
+		case fd.Enum() != nil:
+			if !s.visibleEnum(fd.Enum(), v.Enum()) {
+				hidden = append(hidden, path)
+				if clear {
+					m.Clear(fd)
+				}
+			}
+		case fd.Message() != nil:
+			hidden = append(hidden, s.hide(v.Message(), path+".", clear)...)
+		}
+		return true
+	})
+	return hidden
+}
+
+func (s *visibilityState) hideList(m protoreflect.Message, fd protoreflect.FieldDescriptor, list protoreflect.List, path string, clear bool) []string {
+	var hidden []string
+	switch {
+	case fd.Enum() != nil:
+		kept := 0
+		for i := 0; i < list.Len(); i++ {
+			if !s.visibleEnum(fd.Enum(), list.Get(i).Enum()) {
+				hidden = append(hidden, fmt.Sprintf("%s[%d]", path, i))
+				continue
+			}
+			if clear {
+				list.Set(kept, list.Get(i))
+			}
+			kept++
+		}
+		if clear && kept < list.Len() {
+			list.Truncate(kept)
+			if kept == 0 {
+				m.Clear(fd)
+			}
+		}
+	case fd.Message() != nil:
+		for i := 0; i < list.Len(); i++ {
+			hidden = append(hidden, s.hide(list.Get(i).Message(), fmt.Sprintf("%s[%d].", path, i), clear)...)
+		}
+	}
+	return hidden
+}
+
+func (s *visibilityState) hideMap(fd protoreflect.FieldDescriptor, mp protoreflect.Map, path string, clear bool) []string {
+	var (
+		hidden  []string
+		removed []protoreflect.MapKey
+	)
+	valueFd := fd.MapValue()
+	mp.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		switch {
+		case valueFd.Enum() != nil:
+			if !s.visibleEnum(valueFd.Enum(), v.Enum()) {
+				hidden = append(hidden, fmt.Sprintf("%s[%v]", path, k.Interface()))
+				removed = append(removed, k)
+			}
+		case valueFd.Message() != nil:
+			hidden = append(hidden, s.hide(v.Message(), fmt.Sprintf("%s[%v].", path, k.Interface()), clear)...)
+		}
+		return true
+	})
+	if clear {
+		for _, k := range removed {
+			mp.Clear(k)
+		}
+	}
+	return hidden
+}
+
+func (s *visibilityState) visibleEnum(ed protoreflect.EnumDescriptor, n protoreflect.EnumNumber) bool {
+	vd := ed.Values().ByNumber(n)
+	return vd == nil || s.visible(vd)
+}
+
+// visibilityMarshaler wraps the inbound marshaler of a request to enforce the
+// visibility of the fields of request messages.
+type visibilityMarshaler struct {
+	Marshaler
+	state *visibilityState
+}
+
+func (m *visibilityMarshaler) Unmarshal(data []byte, v interface{}) error {
+	if err := m.Marshaler.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if msg, ok := v.(proto.Message); ok {
+		return m.state.checkRequest(msg)
+	}
+	return nil
+}
+
+func (m *visibilityMarshaler) NewDecoder(r io.Reader) Decoder {
+	dec := m.Marshaler.NewDecoder(r)
+	return DecoderFunc(func(v interface{}) error {
+		if err := dec.Decode(v); err != nil {
+			return err
+		}
+		if msg, ok := v.(proto.Message); ok {
+			return m.state.checkRequest(msg)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,339 @@
+package runtime
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/visibility"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// visibilityBook returns the descriptor of a message with INTERNAL fields and
+// enum values:
+//
+//	message Book {
+//	  enum State {
+//	    STATE_UNSPECIFIED = 0;
+//	    PUBLISHED = 1;
+//	    DRAFT = 2 [INTERNAL];
+//	  }
+//	  string title = 1;
+//	  string secret = 2 [INTERNAL];
+//	  repeated State states = 3;
+//	  map<string, State> labels = 4;
+//	  repeated Book children = 5;
+//	  map<string, Book> by_name = 6;
+//	  oneof internal_kind {
+//	    string internal_id = 7 [INTERNAL];
+//	  }
+//	  oneof edition {
+//	    int32 year = 8;
+//	    string name = 9;
+//	  }
+//	}
+func visibilityBook(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	internalField := func() *descriptorpb.FieldOptions {
+		opts := &descriptorpb.FieldOptions{}
+		proto.SetExtension(opts, visibility.E_FieldVisibility, &visibility.VisibilityRule{Restriction: "INTERNAL"})
+		return opts
+	}
+	internalValue := &descriptorpb.EnumValueOptions{}
+	proto.SetExtension(internalValue, visibility.E_ValueVisibility, &visibility.VisibilityRule{Restriction: "INTERNAL"})
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     typ.Enum(),
+		}
+		if typeName != "" {
+			fd.TypeName = proto.String(typeName)
+		}
+		return fd
+	}
+	const (
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+		str      = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		enum     = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+		message  = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	)
+	mapEntry := func(name, valueType string, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("key", 1, optional, str, ""),
+				field("value", 2, optional, typ, valueType),
+			},
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+	}
+
+	secret := field("secret", 2, optional, str, "")
+	secret.Options = internalField()
+	internalID := field("internal_id", 7, optional, str, "")
+	internalID.Options = internalField()
+	internalID.OneofIndex = proto.Int32(0)
+	year := field("year", 8, optional, descriptorpb.FieldDescriptorProto_TYPE_INT32, "")
+	year.OneofIndex = proto.Int32(1)
+	name := field("name", 9, optional, str, "")
+	name.OneofIndex = proto.Int32(1)
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("visibility_book.proto"),
+		Package: proto.String("visibility"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Book"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("title", 1, optional, str, ""),
+				secret,
+				field("states", 3, repeated, enum, ".visibility.Book.State"),
+				field("labels", 4, repeated, message, ".visibility.Book.LabelsEntry"),
+				field("children", 5, repeated, message, ".visibility.Book"),
+				field("by_name", 6, repeated, message, ".visibility.Book.ByNameEntry"),
+				internalID,
+				year,
+				name,
+			},
+			NestedType: []*descriptorpb.DescriptorProto{
+				mapEntry("LabelsEntry", ".visibility.Book.State", enum),
+				mapEntry("ByNameEntry", ".visibility.Book", message),
+			},
+			EnumType: []*descriptorpb.EnumDescriptorProto{{
+				Name: proto.String("State"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("STATE_UNSPECIFIED"), Number: proto.Int32(0)},
+					{Name: proto.String("PUBLISHED"), Number: proto.Int32(1)},
+					{Name: proto.String("DRAFT"), Number: proto.Int32(2), Options: internalValue},
+				},
+			}},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{
+				{Name: proto.String("internal_kind")},
+				{Name: proto.String("edition")},
+			},
+		}},
+	}
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatalf("protodesc.NewFile(...) failed with %v", err)
+	}
+	return fd.Messages().Get(0)
+}
+
+func newTestVisibilityState(labels ...string) *visibilityState {
+	state := &visibilityState{config: &visibilityConfig{}, labels: make(map[string]bool)}
+	for _, label := range labels {
+		state.labels[label] = true
+	}
+	sort.Strings(labels)
+	state.key = strings.Join(labels, ",")
+	return state
+}
+
+func TestVisibilityHide(t *testing.T) {
+	md := visibilityBook(t)
+	for _, spec := range []struct {
+		name       string
+		labels     []string
+		msg        string
+		wantHidden []string
+		wantMsg    string
+	}{
+		{
+			name:    "visible fields",
+			msg:     `{"title": "t", "states": ["PUBLISHED"], "labels": {"a": "PUBLISHED"}, "year": 2000}`,
+			wantMsg: `{"title": "t", "states": ["PUBLISHED"], "labels": {"a": "PUBLISHED"}, "year": 2000}`,
+		},
+		{
+			name:       "hidden fields",
+			msg:        `{"title": "t", "secret": "s", "internal_id": "i"}`,
+			wantHidden: []string{"internal_id", "secret"},
+			wantMsg:    `{"title": "t"}`,
+		},
+		{
+			name:       "hidden list enum values",
+			msg:        `{"states": ["DRAFT", "PUBLISHED", "DRAFT"]}`,
+			wantHidden: []string{"states[0]", "states[2]"},
+			wantMsg:    `{"states": ["PUBLISHED"]}`,
+		},
+		{
+			name:       "only hidden list enum values",
+			msg:        `{"states": ["DRAFT"]}`,
+			wantHidden: []string{"states[0]"},
+			wantMsg:    `{}`,
+		},
+		{
+			name:       "hidden fields of list messages",
+			msg:        `{"children": [{"title": "a"}, {"title": "b", "secret": "s"}]}`,
+			wantHidden: []string{"children[1].secret"},
+			wantMsg:    `{"children": [{"title": "a"}, {"title": "b"}]}`,
+		},
+		{
+			name:       "hidden map enum values",
+			msg:        `{"labels": {"a": "DRAFT", "b": "PUBLISHED", "c": "DRAFT"}}`,
+			wantHidden: []string{"labels[a]", "labels[c]"},
+			wantMsg:    `{"labels": {"b": "PUBLISHED"}}`,
+		},
+		{
+			name:       "hidden fields of map messages",
+			msg:        `{"by_name": {"a": {"title": "a", "secret": "s", "states": ["DRAFT"]}}}`,
+			wantHidden: []string{"by_name[a].secret", "by_name[a].states[0]"},
+			wantMsg:    `{"by_name": {"a": {"title": "a"}}}`,
+		},
+		{
+			name:    "visible to the label",
+			labels:  []string{"INTERNAL"},
+			msg:     `{"secret": "s", "states": ["DRAFT"], "labels": {"a": "DRAFT"}, "internal_id": "i"}`,
+			wantMsg: `{"secret": "s", "states": ["DRAFT"], "labels": {"a": "DRAFT"}, "internal_id": "i"}`,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			unmarshal := func(s string) *dynamicpb.Message {
+				msg := dynamicpb.NewMessage(md)
+				if err := protojson.Unmarshal([]byte(s), msg); err != nil {
+					t.Fatalf("protojson.Unmarshal(%s) failed with %v", s, err)
+				}
+				return msg
+			}
+			state := newTestVisibilityState(spec.labels...)
+
+			msg := unmarshal(spec.msg)
+			hidden := state.hide(msg, "", false)
+			sort.Strings(hidden)
+			if strings.Join(hidden, " ") != strings.Join(spec.wantHidden, " ") {
+				t.Errorf("hide(...) = %q; want %q", hidden, spec.wantHidden)
+			}
+			if !proto.Equal(msg, unmarshal(spec.msg)) {
+				t.Errorf("hide(...) without clear changed the message to %v", msg)
+			}
+
+			hidden = state.hide(msg, "", true)
+			sort.Strings(hidden)
+			if strings.Join(hidden, " ") != strings.Join(spec.wantHidden, " ") {
+				t.Errorf("hide(...) with clear = %q; want %q", hidden, spec.wantHidden)
+			}
+			if want := unmarshal(spec.wantMsg); !proto.Equal(msg, want) {
+				t.Errorf("hide(...) with clear left %v; want %v", msg, want)
+			}
+		})
+	}
+}
+
+func TestVisibilityViewType(t *testing.T) {
+	md := visibilityBook(t)
+	state := newTestVisibilityState()
+	typ := state.viewType(md)
+	if typ == nil {
+		t.Fatalf("viewType(%s) = nil; want a type without the hidden fields", md.FullName())
+	}
+	view := typ.Descriptor()
+	for _, name := range []protoreflect.Name{"secret", "internal_id"} {
+		if view.Fields().ByName(name) != nil {
+			t.Errorf("view has the hidden field %q", name)
+		}
+	}
+	// The oneof of the hidden field is removed, and the fields of the next
+	// oneof refer to its new index.
+	if got := view.Oneofs().Len(); got != 1 {
+		t.Fatalf("view has %d oneofs; want 1", got)
+	}
+	for _, name := range []protoreflect.Name{"year", "name"} {
+		if of := view.Fields().ByName(name).ContainingOneof(); of == nil || of.Name() != "edition" {
+			t.Errorf("field %q is in oneof %v; want edition", name, of)
+		}
+	}
+	if children := view.Fields().ByName("children").Message(); children.Fields().ByName("secret") != nil {
+		t.Errorf("view of the children has the hidden field %q", "secret")
+	}
+
+	// Views are cached by labels and message.
+	other := newTestVisibilityState()
+	other.config = state.config
+	if got := other.viewType(md); got != typ {
+		t.Errorf("viewType(%s) for other states with the same labels = %v; want the cached %v", md.FullName(), got, typ)
+	}
+	state.config = &visibilityConfig{}
+	if got := state.viewType(md); got == typ {
+		t.Errorf("viewType(%s) with another config returned the type cached by the first one", md.FullName())
+	}
+	internal := newTestVisibilityState("INTERNAL")
+	internal.config = state.config
+	if got := internal.viewType(md); got != nil {
+		t.Errorf("viewType(%s) with every field visible = %v; want nil", md.FullName(), got)
+	}
+	if _, ok := state.config.views.Load(viewKey{labels: "INTERNAL", name: md.FullName()}); !ok {
+		t.Errorf("viewType(%s) with every field visible was not cached", md.FullName())
+	}
+}
+
+func TestVisibilityQueryParametersOneof(t *testing.T) {
+	ctx := withVisibilityState(context.Background(), newTestVisibilityState())
+	for _, spec := range []struct {
+		name    string
+		msg     *examplepb.Proto3Message
+		values  url.Values
+		wantErr bool
+	}{
+		{
+			name:   "unset oneof",
+			msg:    &examplepb.Proto3Message{},
+			values: url.Values{"oneof_string_value": {"x"}},
+		},
+		{
+			name:    "other member set",
+			msg:     &examplepb.Proto3Message{OneofValue: &examplepb.Proto3Message_OneofBoolValue{OneofBoolValue: true}},
+			values:  url.Values{"oneof_string_value": {"x"}},
+			wantErr: true,
+		},
+		{
+			name:    "same member set",
+			msg:     &examplepb.Proto3Message{OneofValue: &examplepb.Proto3Message_OneofStringValue{OneofStringValue: "a"}},
+			values:  url.Values{"oneof_string_value": {"x"}},
+			wantErr: true,
+		},
+		{
+			name: "field of the message member set",
+			msg: &examplepb.Proto3Message{NestedOneofValue: &examplepb.Proto3Message_NestedOneofValueOne{
+				NestedOneofValueOne: &examplepb.Proto3Message{Int32Value: 1},
+			}},
+			values: url.Values{"nested_oneof_value_one.string_value": {"x"}},
+		},
+		{
+			name: "nested oneof set",
+			msg: &examplepb.Proto3Message{Nested: &examplepb.Proto3Message{
+				OneofValue: &examplepb.Proto3Message_OneofBoolValue{OneofBoolValue: true},
+			}},
+			values:  url.Values{"nested.oneof_string_value": {"x"}},
+			wantErr: true,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			// The query is parsed with visibility like without it.
+			withoutVisibility := proto.Clone(spec.msg)
+			errWithout := PopulateQueryParametersContext(context.Background(), withoutVisibility, spec.values, utilities.NewDoubleArray(nil))
+			err := PopulateQueryParametersContext(ctx, spec.msg, spec.values, utilities.NewDoubleArray(nil))
+			if (err != nil) != spec.wantErr || (errWithout != nil) != spec.wantErr {
+				t.Fatalf("PopulateQueryParametersContext(...) = %v, and %v without visibility; want error %t", err, errWithout, spec.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "field already set for oneof") {
+				t.Errorf("PopulateQueryParametersContext(...) = %v; want a oneof error", err)
+			}
+			if err == nil && !proto.Equal(spec.msg, withoutVisibility) {
+				t.Errorf("PopulateQueryParametersContext(...) = %v; want %v", spec.msg, withoutVisibility)
+			}
+		})
+	}
+}